@@ -1,13 +1,14 @@
 package zabbix
 
 import (
-	"bytes"
-	"encoding/json"
+	"context"
 	"fmt"
-	"io"
-	"net/http"
+	"log/slog"
 )
 
+// AuthRequest is the JSON-RPC request of user.login.
+//
+// Deprecated: logins are sent like every other call and no longer use it.
 type AuthRequest struct {
 	JSONRPC string            `json:"jsonrpc"`
 	Method  string            `json:"method"`
@@ -15,60 +16,43 @@ type AuthRequest struct {
 	ID      int               `json:"id"`
 }
 
-type authResponse struct {
-	JSONRPC string `json:"jsonrpc"`
-	Result  string `json:"result"`
-	ID      int    `json:"id"`
-	Error   *struct {
-		Code    int    `json:"code"`
-		Message string `json:"message"`
-		Data    string `json:"data"`
-	} `json:"error,omitempty"`
-}
-
 func (client *zabbixClient) Authenticate() error {
-	if client.apiToken != "" {
-		client.bearerToken = client.apiToken
-		return nil
-	}
-
-	authReq := AuthRequest{
-		JSONRPC: "2.0",
-		Method:  "user.login",
-		Params: map[string]string{
-			"username": client.username,
-			"password": client.password,
-		},
-		ID: 1,
-	}
-
-	reqBody, err := json.Marshal(authReq)
-	if err != nil {
+	if err := client.authenticate(); err != nil {
 		return err
 	}
 
-	resp, err := http.Post(client.url, "application/json-rpc", bytes.NewBuffer(reqBody))
-	if err != nil {
-		return fmt.Errorf("auth request failed: %v", err)
+	if client.apiToken != "" {
+		client.log(context.Background(), slog.LevelInfo, "zabbix login", slog.String("auth", "api token"))
+	} else {
+		client.log(context.Background(), slog.LevelInfo, "zabbix login", slog.String("auth", "password"), slog.String("username", client.username))
 	}
-	defer resp.Body.Close()
+	return nil
+}
 
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return err
+// authenticate sets the bearer token, logging in with the username and
+// password if no API token is used.
+func (client *zabbixClient) authenticate() error {
+	if client.apiToken != "" {
+		client.bearerTokenLock.Lock()
+		client.bearerToken = client.apiToken
+		client.bearerTokenLock.Unlock()
+		return nil
 	}
-	var authResp authResponse
-	err = json.Unmarshal(body, &authResp)
-	if err != nil {
-		return err
+
+	params := map[string]string{
+		"username": client.username,
+		"password": client.password,
 	}
 
-	if authResp.Error != nil {
-		return fmt.Errorf("auth failed: %s", authResp.Error.Data)
+	// Logins go through makeRequest like every other call, so that they
+	// are subject to the same limits and hooks.
+	var sessionID string
+	if err := client.makeRequest(context.Background(), "user.login", params, &sessionID); err != nil {
+		return fmt.Errorf("auth failed: %w", err)
 	}
 
 	client.bearerTokenLock.Lock()
-	client.bearerToken = authResp.Result
+	client.bearerToken = sessionID
 	client.bearerTokenLock.Unlock()
 	return nil
 }
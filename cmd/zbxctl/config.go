@@ -0,0 +1,96 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v3"
+)
+
+// config holds the settings of zbxctl.
+type config struct {
+	URL      string `yaml:"url"`
+	User     string `yaml:"user"`
+	Password string `yaml:"password"`
+	Token    string `yaml:"token"`
+	Output   string `yaml:"output"`
+}
+
+// loadConfig fills the settings missing from flags with the environment
+// variables, then with the config file at path. If path is empty, the file
+// given by ZBXCTL_CONFIG or the default file is read if it exists.
+func loadConfig(flags config, path string, getenv func(string) string) (config, error) {
+	env := config{
+		URL:      getenv("ZABBIX_URL"),
+		User:     getenv("ZABBIX_USER"),
+		Password: getenv("ZABBIX_PASSWORD"),
+		Token:    getenv("ZABBIX_TOKEN"),
+		Output:   getenv("ZBXCTL_OUTPUT"),
+	}
+
+	required := path != ""
+	if path == "" {
+		path = getenv("ZBXCTL_CONFIG")
+		required = path != ""
+	}
+	if path == "" {
+		if home := getenv("HOME"); home != "" {
+			path = filepath.Join(home, ".config", "zbxctl", "config.yaml")
+		}
+	}
+
+	var file config
+	if path != "" {
+		data, err := os.ReadFile(path)
+		switch {
+		case errors.Is(err, fs.ErrNotExist) && !required:
+		case err != nil:
+			return config{}, err
+		default:
+			if err := yaml.Unmarshal(data, &file); err != nil {
+				return config{}, fmt.Errorf("invalid config file %s: %w", path, err)
+			}
+		}
+	}
+
+	cfg := merge(merge(flags, env), file)
+
+	// The token or user is taken from the first source giving either, so
+	// that a token in the environment overrides a user in the config file
+	switch {
+	case flags.Token != "" || flags.User != "":
+		cfg.Token, cfg.User = flags.Token, flags.User
+	case env.Token != "" || env.User != "":
+		cfg.Token, cfg.User = env.Token, env.User
+	}
+	if cfg.Token != "" && cfg.User != "" {
+		return config{}, errors.New("both an API token and a user are given")
+	}
+	if cfg.Token != "" {
+		cfg.Password = ""
+	}
+	return cfg, nil
+}
+
+// merge returns cfg with its empty settings taken from fallback.
+func merge(cfg, fallback config) config {
+	return config{
+		URL:      first(cfg.URL, fallback.URL),
+		User:     first(cfg.User, fallback.User),
+		Password: first(cfg.Password, fallback.Password),
+		Token:    first(cfg.Token, fallback.Token),
+		Output:   first(cfg.Output, fallback.Output),
+	}
+}
+
+func first(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
@@ -0,0 +1,232 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"maps"
+	"slices"
+	"strings"
+
+	zabbix "github.com/nimok/nim-go-zabbix"
+	"github.com/nimok/nim-go-zabbix/zabbixreport"
+)
+
+func hostsList(ctx context.Context, c *cli, args []string) error {
+	var groups stringsFlag
+	fs := c.newFlagSet("hosts list")
+	fs.Var(&groups, "group", "list only the hosts of the host group (repeatable)")
+	search := fs.String("search", "", "list only the hosts whose name contains text")
+	if _, err := parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	params := zabbix.HostGetParameters{
+		GetParameters:    zabbix.GetParameters{Output: zabbix.SelectExtendedOutput, Sortfield: []string{"host"}},
+		SelectHostGroups: []string{"name"},
+	}
+	if len(groups) > 0 {
+		ids, err := zabbix.NewResolver(c.client).HostGroupIDs(ctx, groups...)
+		if err != nil {
+			return err
+		}
+		params.GroupIDs = slices.Sorted(maps.Values(ids))
+	}
+	if *search != "" {
+		params.Search = map[string]string{"name": *search}
+	}
+
+	hosts, err := c.client.HostGet(ctx, params)
+	if err != nil {
+		return err
+	}
+	return c.print(hosts, hostTable(hosts, false))
+}
+
+func hostsGet(ctx context.Context, c *cli, args []string) error {
+	names, err := parseFlags(c.newFlagSet("hosts get"), args)
+	if err != nil {
+		return err
+	}
+	if len(names) == 0 {
+		return errors.New("no host given")
+	}
+
+	ids, err := zabbix.NewResolver(c.client).HostIDs(ctx, names...)
+	if err != nil {
+		return err
+	}
+	hosts, err := c.client.HostGet(ctx, zabbix.HostGetParameters{
+		GetParameters:         zabbix.GetParameters{Output: zabbix.SelectExtendedOutput, Sortfield: []string{"host"}},
+		HostIDs:               slices.Sorted(maps.Values(ids)),
+		SelectHostGroups:      []string{"name"},
+		SelectInterfaces:      zabbix.SelectExtendedOutput,
+		SelectParentTemplates: []string{"host"},
+		SelectTags:            zabbix.SelectExtendedOutput,
+	})
+	if err != nil {
+		return err
+	}
+	return c.print(hosts, hostTable(hosts, true))
+}
+
+// hostTable returns the table of hosts, with their interfaces and
+// templates if detailed.
+func hostTable(hosts []zabbix.Host, detailed bool) *table {
+	t := &table{header: []string{"HOSTID", "HOST", "NAME", "STATUS", "GROUPS"}}
+	if detailed {
+		t.header = append(t.header, "INTERFACES", "TEMPLATES")
+	}
+
+	for _, host := range hosts {
+		status := "enabled"
+		if host.Status != nil && *host.Status == 1 {
+			status = "disabled"
+		}
+		var groups []string
+		for _, group := range host.HostGroups {
+			groups = append(groups, group.Name)
+		}
+		row := []string{host.HostID, host.Host, host.Name, status, strings.Join(groups, ",")}
+
+		if detailed {
+			var interfaces, templates []string
+			for _, iface := range host.Interfaces {
+				address := iface.DNS
+				if iface.UseIP == zabbix.UseIPOptionIP {
+					address = iface.IP
+				}
+				interfaces = append(interfaces, address+":"+iface.Port)
+			}
+			for _, template := range host.ParentTemplates {
+				templates = append(templates, template.Host)
+			}
+			row = append(row, strings.Join(interfaces, ","), strings.Join(templates, ","))
+		}
+		t.add(row...)
+	}
+	return t
+}
+
+func hostsCreate(ctx context.Context, c *cli, args []string) error {
+	var groups, templates stringsFlag
+	fs := c.newFlagSet("hosts create")
+	fs.Var(&groups, "group", "host group of the host (repeatable, required)")
+	fs.Var(&templates, "template", "template linked to the host (repeatable)")
+	visibleName := fs.String("name", "", "visible name of the host")
+	ip := fs.String("ip", "", "IP address of the agent interface")
+	dns := fs.String("dns", "", "DNS name of the agent interface")
+	port := fs.String("port", "10050", "port of the agent interface")
+	proxy := fs.String("proxy", "", "proxy monitoring the host")
+	createGroups := fs.Bool("create-groups", false, "create the host groups that don't exist")
+	names, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(names) != 1 {
+		return errors.New("exactly one host name must be given")
+	}
+	if len(groups) == 0 {
+		return errors.New("at least one --group must be given")
+	}
+	if *ip != "" && *dns != "" {
+		return errors.New("--ip and --dns can't be both given")
+	}
+
+	var opts []zabbix.ResolverOption
+	if *createGroups {
+		opts = append(opts, zabbix.WithCreateMissingHostGroups())
+	}
+	resolver := zabbix.NewResolver(c.client, opts...)
+
+	host := zabbix.Host{Host: names[0], Name: *visibleName}
+	groupIDs, err := resolver.HostGroupIDs(ctx, groups...)
+	if err != nil {
+		return err
+	}
+	for _, id := range slices.Sorted(maps.Values(groupIDs)) {
+		host.Groups = append(host.Groups, zabbix.HostGroup{GroupID: id})
+	}
+	if len(templates) > 0 {
+		templateIDs, err := resolver.TemplateIDs(ctx, templates...)
+		if err != nil {
+			return err
+		}
+		for _, id := range slices.Sorted(maps.Values(templateIDs)) {
+			host.Templates = append(host.Templates, zabbix.Template{TemplateID: id})
+		}
+	}
+	if *proxy != "" {
+		if host.ProxyID, err = resolver.ProxyID(ctx, *proxy); err != nil {
+			return err
+		}
+		host.MonitoredBy = zabbix.MonitoredByProxy
+	}
+	if *ip != "" || *dns != "" {
+		iface := zabbix.HostInterface{Type: zabbix.InterfaceTypeAgent, Main: zabbix.MainInterfaceYes, IP: *ip, DNS: *dns, Port: *port}
+		if *ip != "" {
+			iface.UseIP = zabbix.UseIPOptionIP
+		}
+		host.Interfaces = []zabbix.HostInterface{iface}
+	}
+
+	resp, err := c.client.HostCreate(ctx, []zabbix.Host{host})
+	if err != nil {
+		return err
+	}
+	t := &table{header: []string{"HOSTID", "HOST"}}
+	t.add(resp.HostIDs[0], host.Host)
+	return c.print(resp, t)
+}
+
+func hostsDelete(ctx context.Context, c *cli, args []string) error {
+	names, err := parseFlags(c.newFlagSet("hosts delete"), args)
+	if err != nil {
+		return err
+	}
+	if len(names) == 0 {
+		return errors.New("no host given")
+	}
+
+	ids, err := zabbix.NewResolver(c.client).HostIDs(ctx, names...)
+	if err != nil {
+		return err
+	}
+	resp, err := c.client.HostDelete(ctx, slices.Sorted(maps.Values(ids)))
+	if err != nil {
+		return err
+	}
+
+	t := &table{header: []string{"HOSTID", "HOST"}}
+	for _, name := range names {
+		t.add(ids[name], name)
+	}
+	return c.print(resp, t)
+}
+
+func hostsUnavailable(ctx context.Context, c *cli, args []string) error {
+	var groups stringsFlag
+	fs := c.newFlagSet("hosts unavailable")
+	fs.Var(&groups, "group", "report only on the hosts of the host group (repeatable)")
+	minDuration := fs.Duration("min-duration", 0, "leave out the interfaces unavailable for less than the duration")
+	if _, err := parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	opts := []zabbixreport.Option{zabbixreport.WithMinDuration(*minDuration)}
+	if len(groups) > 0 {
+		ids, err := zabbix.NewResolver(c.client).HostGroupIDs(ctx, groups...)
+		if err != nil {
+			return err
+		}
+		opts = append(opts, zabbixreport.WithHostGroups(slices.Sorted(maps.Values(ids))...))
+	}
+
+	report, err := zabbixreport.Availability(ctx, c.client, opts...)
+	if err != nil {
+		return err
+	}
+	if c.output == "table" {
+		return report.WriteText(c.stdout)
+	}
+	return c.print(report, nil)
+}
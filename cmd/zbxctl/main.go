@@ -0,0 +1,243 @@
+// Command zbxctl runs common Zabbix tasks from the command line.
+//
+// Usage:
+//
+//	zbxctl [global flags] <command> <subcommand> [flags] [args]
+//
+// The commands are:
+//
+//	hosts list [--group NAME]... [--search TEXT]
+//	hosts get NAME...
+//	hosts create NAME --group NAME... [--template NAME]... [--ip IP | --dns NAME] [--port PORT] [--proxy NAME]
+//	hosts delete NAME...
+//	hosts unavailable [--group NAME]... [--min-duration DURATION]
+//	problems list [--severity SEVERITY] [--host NAME]... [--limit N]
+//	proxies list
+//	templates list [--search TEXT]
+//	tokens create NAME [--expires DURATION]
+//	tokens delete ID...
+//	maintenance start NAME (--host NAME | --group NAME)... [--duration DURATION] [--no-data]
+//
+// The URL and credentials of the server are read from the global flags, then
+// from the ZABBIX_URL, ZABBIX_USER, ZABBIX_PASSWORD and ZABBIX_TOKEN
+// environment variables, then from the YAML config file given by --config or
+// ZBXCTL_CONFIG, $HOME/.config/zbxctl/config.yaml by default:
+//
+//	url: https://zabbix.example.com/api_jsonrpc.php
+//	token: 6f0f7ab6f3c1e8a4...
+//	output: table
+//
+// There is no --password flag, since command line arguments are visible to
+// the other users of the machine; the password is read from the file given by
+// --password-file instead.
+//
+// Results are printed as a table, JSON or YAML depending on --output, the
+// ZBXCTL_OUTPUT environment variable or output in the config file.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	zabbix "github.com/nimok/nim-go-zabbix"
+)
+
+// command is a subcommand of zbxctl, e.g. "hosts list".
+type command struct {
+	name  string
+	usage string // Flags and args, e.g. "NAME..."
+	run   func(ctx context.Context, c *cli, args []string) error
+}
+
+var commands []command
+
+// Set in init since the commands refer to it for their usage.
+func init() {
+	commands = []command{
+		{"hosts list", "[--group NAME]... [--search TEXT]", hostsList},
+		{"hosts get", "NAME...", hostsGet},
+		{"hosts create", "NAME --group NAME... [--template NAME]... [--ip IP | --dns NAME] [--port PORT] [--proxy NAME]", hostsCreate},
+		{"hosts delete", "NAME...", hostsDelete},
+		{"hosts unavailable", "[--group NAME]... [--min-duration DURATION]", hostsUnavailable},
+		{"problems list", "[--severity SEVERITY] [--host NAME]... [--limit N]", problemsList},
+		{"proxies list", "", proxiesList},
+		{"templates list", "[--search TEXT]", templatesList},
+		{"tokens create", "NAME [--expires DURATION]", tokensCreate},
+		{"tokens delete", "ID...", tokensDelete},
+		{"maintenance start", "NAME (--host NAME | --group NAME)... [--duration DURATION] [--no-data]", maintenanceStart},
+	}
+}
+
+// cli is the state shared by the commands.
+type cli struct {
+	stdout io.Writer
+	stderr io.Writer
+	output string // Output format: "table", "json" or "yaml"
+	client zabbix.Client
+}
+
+func main() {
+	if err := run(context.Background(), os.Args[1:], os.Getenv, os.Stdout, os.Stderr); err != nil {
+		if !errors.Is(err, flag.ErrHelp) {
+			fmt.Fprintln(os.Stderr, "zbxctl:", err)
+		}
+		os.Exit(1)
+	}
+}
+
+// run runs zbxctl with args, reading the environment variables with getenv.
+func run(ctx context.Context, args []string, getenv func(string) string, stdout, stderr io.Writer) error {
+	c := &cli{stdout: stdout, stderr: stderr}
+
+	var cfg config
+	fs := flag.NewFlagSet("zbxctl", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.Usage = func() { c.usage(fs) }
+	configPath := fs.String("config", "", "path of the config file")
+	fs.StringVar(&cfg.URL, "url", "", "URL of the Zabbix API, e.g. https://zabbix.example.com/api_jsonrpc.php")
+	fs.StringVar(&cfg.User, "user", "", "username to log in with")
+	passwordFile := fs.String("password-file", "", "file holding the password to log in with, instead of ZABBIX_PASSWORD")
+	fs.StringVar(&cfg.Token, "token", "", "API token to authenticate with instead of a password")
+	fs.StringVar(&cfg.Output, "output", "", "output format: table, json or yaml (default table)")
+	timeout := fs.Duration("timeout", 30*time.Second, "timeout of each request")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *passwordFile != "" {
+		data, err := os.ReadFile(*passwordFile)
+		if err != nil {
+			return err
+		}
+		cfg.Password = strings.TrimRight(string(data), "\r\n")
+	}
+
+	args = fs.Args()
+	if len(args) < 2 {
+		fs.Usage()
+		return flag.ErrHelp
+	}
+	name := args[0] + " " + args[1]
+	i := indexCommand(name)
+	if i < 0 {
+		fs.Usage()
+		return fmt.Errorf("unknown command %q", name)
+	}
+
+	cfg, err := loadConfig(cfg, *configPath, getenv)
+	if err != nil {
+		return err
+	}
+	if err := c.connect(cfg, *timeout); err != nil {
+		return err
+	}
+	defer c.disconnect(ctx, cfg)
+
+	return commands[i].run(ctx, c, args[2:])
+}
+
+func indexCommand(name string) int {
+	for i, cmd := range commands {
+		if cmd.name == name {
+			return i
+		}
+	}
+	return -1
+}
+
+func (c *cli) usage(fs *flag.FlagSet) {
+	fmt.Fprintln(c.stderr, "Usage: zbxctl [global flags] <command> <subcommand> [flags] [args]")
+	fmt.Fprintln(c.stderr, "\nCommands:")
+	for _, cmd := range commands {
+		fmt.Fprintln(c.stderr, " ", strings.TrimSpace(cmd.name+" "+cmd.usage))
+	}
+	fmt.Fprintln(c.stderr, "\nGlobal flags:")
+	fs.PrintDefaults()
+}
+
+// connect creates the client and logs in.
+func (c *cli) connect(cfg config, timeout time.Duration) error {
+	switch cfg.Output {
+	case "", "table":
+		c.output = "table"
+	case "json", "yaml":
+		c.output = cfg.Output
+	default:
+		return fmt.Errorf("unknown output format %q", cfg.Output)
+	}
+
+	if cfg.URL == "" {
+		return errors.New("no URL given: set --url, ZABBIX_URL or url in the config file")
+	}
+	opts := []zabbix.ClientOption{zabbix.WithHTTPClient(&http.Client{Timeout: timeout})}
+	if cfg.Token != "" {
+		opts = append(opts, zabbix.WithAPIToken(cfg.Token))
+	} else {
+		opts = append(opts, zabbix.WithUserPass(cfg.User, cfg.Password))
+	}
+
+	client, err := zabbix.NewClient(cfg.URL, opts...)
+	if err != nil {
+		return err
+	}
+	if err := client.Authenticate(); err != nil {
+		return err
+	}
+	c.client = client
+	return nil
+}
+
+// disconnect ends the session opened by connect, if any.
+func (c *cli) disconnect(ctx context.Context, cfg config) {
+	if cfg.Token == "" {
+		if _, err := c.client.Logout(ctx); err != nil {
+			fmt.Fprintln(c.stderr, "zbxctl: logout failed:", err)
+		}
+	}
+}
+
+// parseFlags parses the flags of a command, which may follow its args, and
+// returns the args.
+func parseFlags(fs *flag.FlagSet, args []string) ([]string, error) {
+	var positional []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			return nil, err
+		}
+		args = fs.Args()
+		if len(args) == 0 {
+			return positional, nil
+		}
+		positional = append(positional, args[0])
+		args = args[1:]
+	}
+}
+
+// newFlagSet returns the flag set of the command with name.
+func (c *cli) newFlagSet(name string) *flag.FlagSet {
+	fs := flag.NewFlagSet("zbxctl "+name, flag.ContinueOnError)
+	fs.SetOutput(c.stderr)
+	fs.Usage = func() {
+		fmt.Fprintln(c.stderr, "Usage: zbxctl", strings.TrimSpace(name+" "+commands[indexCommand(name)].usage))
+		fs.PrintDefaults()
+	}
+	return fs
+}
+
+// stringsFlag is a flag that can be repeated.
+type stringsFlag []string
+
+func (f *stringsFlag) String() string {
+	return strings.Join(*f, ",")
+}
+
+func (f *stringsFlag) Set(value string) error {
+	*f = append(*f, value)
+	return nil
+}
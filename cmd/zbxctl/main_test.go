@@ -0,0 +1,232 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	zabbix "github.com/nimok/nim-go-zabbix"
+	"github.com/nimok/nim-go-zabbix/zabbixtest"
+	"gopkg.in/yaml.v3"
+)
+
+// zbxctl runs zbxctl against srv and returns its output.
+func zbxctl(t *testing.T, srv *zabbixtest.Server, args ...string) (string, error) {
+	t.Helper()
+
+	env := map[string]string{
+		"ZABBIX_URL":      srv.URL,
+		"ZABBIX_USER":     zabbixtest.DefaultUsername,
+		"ZABBIX_PASSWORD": zabbixtest.DefaultPassword,
+	}
+	var stdout, stderr bytes.Buffer
+	err := run(context.Background(), args, func(key string) string { return env[key] }, &stdout, &stderr)
+	return stdout.String(), err
+}
+
+func TestHosts(t *testing.T) {
+	srv := zabbixtest.NewServer()
+	defer srv.Close()
+
+	out, err := zbxctl(t, srv, "hosts", "create", "web-01", "--group", "Web servers", "--create-groups",
+		"--template", "ICMP Ping", "--ip", "10.0.0.1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(out, "web-01") {
+		t.Errorf("unexpected create output:\n%s", out)
+	}
+
+	out, err = zbxctl(t, srv, "hosts", "list", "--group", "Web servers")
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	if len(lines) != 2 || !strings.HasPrefix(lines[0], "HOSTID") || !strings.Contains(lines[1], "Web servers") {
+		t.Errorf("unexpected list output:\n%s", out)
+	}
+
+	out, err = zbxctl(t, srv, "--output", "json", "hosts", "get", "web-01")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var hosts []zabbix.Host
+	if err := json.Unmarshal([]byte(out), &hosts); err != nil {
+		t.Fatal(err)
+	}
+	if len(hosts) != 1 || len(hosts[0].Interfaces) != 1 || hosts[0].Interfaces[0].IP != "10.0.0.1" ||
+		len(hosts[0].ParentTemplates) != 1 || hosts[0].ParentTemplates[0].Host != "ICMP Ping" {
+		t.Errorf("unexpected hosts %+v", hosts)
+	}
+
+	if _, err := zbxctl(t, srv, "hosts", "get", "web-02"); err == nil || err.Error() != "host not found: web-02" {
+		t.Errorf("got error %v", err)
+	}
+
+	if _, err := zbxctl(t, srv, "hosts", "delete", "web-01"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := zbxctl(t, srv, "hosts", "get", "web-01"); err == nil {
+		t.Error("host not deleted")
+	}
+}
+
+func TestHostsUnavailable(t *testing.T) {
+	srv := zabbixtest.NewServer()
+	defer srv.Close()
+
+	// The interface of the seeded "Zabbix server" host
+	if err := srv.SetInterfaceAvailability("1", zabbix.InterfaceStateUnavailable, "Connection refused", time.Now().Add(-time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+
+	out, err := zbxctl(t, srv, "hosts", "unavailable", "--min-duration", "30m")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(out, "1 unavailable interfaces on 1 of 1 hosts") || !strings.Contains(out, "Zabbix server  agent  127.0.0.1:10050") {
+		t.Errorf("unexpected output:\n%s", out)
+	}
+
+	out, err = zbxctl(t, srv, "--output", "json", "hosts", "unavailable", "--min-duration", "2h")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var report map[string]any
+	if err := json.Unmarshal([]byte(out), &report); err != nil {
+		t.Fatal(err)
+	}
+	if groups, ok := report["groups"].([]any); report["hosts"] != 1.0 || !ok || len(groups) != 0 {
+		t.Errorf("unexpected report:\n%s", out)
+	}
+}
+
+func TestProblems(t *testing.T) {
+	srv := zabbixtest.NewServer()
+	defer srv.Close()
+
+	clock := time.Now().Add(-time.Hour)
+	srv.AddProblem("10084", zabbix.Problem{Name: "High CPU load", Severity: zabbix.SeverityWarning, Clock: clock})
+	srv.AddProblem("10084", zabbix.Problem{Name: "Zabbix server is down", Severity: zabbix.SeverityDisaster, Clock: clock})
+
+	out, err := zbxctl(t, srv, "--output", "yaml", "problems", "list", "--severity", "high")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var problems []map[string]any
+	if err := yaml.Unmarshal([]byte(out), &problems); err != nil {
+		t.Fatal(err)
+	}
+	if len(problems) != 1 || problems[0]["name"] != "Zabbix server is down" {
+		t.Errorf("unexpected problems:\n%s", out)
+	}
+
+	if _, err := zbxctl(t, srv, "problems", "list", "--severity", "critical"); err == nil {
+		t.Error("want an error for an unknown severity")
+	}
+}
+
+func TestTokens(t *testing.T) {
+	srv := zabbixtest.NewServer()
+	defer srv.Close()
+
+	out, err := zbxctl(t, srv, "--output", "json", "tokens", "create", "ci", "--expires", "24h")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var tokens []zabbix.TokenGenerateResponse
+	if err := json.Unmarshal([]byte(out), &tokens); err != nil {
+		t.Fatal(err)
+	}
+	if len(tokens) != 1 || tokens[0].Token == "" {
+		t.Fatalf("unexpected tokens %+v", tokens)
+	}
+
+	// The new token authenticates by itself
+	var stdout bytes.Buffer
+	err = run(context.Background(), []string{"--url", srv.URL, "--token", tokens[0].Token, "templates", "list", "--search", "Ping"},
+		func(string) string { return "" }, &stdout, &stdout)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(stdout.String(), "ICMP Ping") {
+		t.Errorf("unexpected templates output:\n%s", stdout.String())
+	}
+
+	if _, err := zbxctl(t, srv, "tokens", "delete", tokens[0].TokenId); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestMaintenance(t *testing.T) {
+	srv := zabbixtest.NewServer()
+	defer srv.Close()
+
+	out, err := zbxctl(t, srv, "maintenance", "start", "upgrade", "--host", "Zabbix server", "--group", "Linux servers", "--duration", "2h")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(out, "upgrade") {
+		t.Errorf("unexpected output:\n%s", out)
+	}
+
+	if _, err := zbxctl(t, srv, "maintenance", "start", "upgrade", "--host", "Zabbix server"); err == nil {
+		t.Error("want an error for a duplicate maintenance")
+	}
+	if _, err := zbxctl(t, srv, "maintenance", "start", "empty"); err == nil {
+		t.Error("want an error without hosts and groups")
+	}
+}
+
+func TestConfig(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.yaml")
+	if err := os.WriteFile(path, []byte("url: http://file/api_jsonrpc.php\nuser: file-user\npassword: file-pass\noutput: yaml\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	env := map[string]string{"HOME": dir, "ZBXCTL_CONFIG": path, "ZABBIX_TOKEN": "env-token"}
+	cfg, err := loadConfig(config{URL: "http://flag/api_jsonrpc.php"}, "", func(key string) string { return env[key] })
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := config{URL: "http://flag/api_jsonrpc.php", Token: "env-token", Output: "yaml"}
+	if cfg != want {
+		t.Errorf("got %+v, want %+v", cfg, want)
+	}
+
+	if _, err := loadConfig(config{}, filepath.Join(dir, "missing.yaml"), func(string) string { return "" }); err == nil {
+		t.Error("want an error for a missing config file")
+	}
+	if _, err := loadConfig(config{}, "", func(key string) string { return map[string]string{"HOME": dir}[key] }); err != nil {
+		t.Errorf("missing default config file: %v", err)
+	}
+}
+
+func TestPasswordFile(t *testing.T) {
+	srv := zabbixtest.NewServer()
+	defer srv.Close()
+
+	path := filepath.Join(t.TempDir(), "password")
+	if err := os.WriteFile(path, []byte(zabbixtest.DefaultPassword+"\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	env := map[string]string{"ZABBIX_URL": srv.URL, "ZABBIX_USER": zabbixtest.DefaultUsername, "ZABBIX_PASSWORD": "wrong"}
+	getenv := func(key string) string { return env[key] }
+
+	var stdout, stderr bytes.Buffer
+	if err := run(context.Background(), []string{"--password-file", path, "proxies", "list"}, getenv, &stdout, &stderr); err != nil {
+		t.Fatal(err)
+	}
+	if err := run(context.Background(), []string{"proxies", "list"}, getenv, &stdout, &stderr); err == nil {
+		t.Error("want an error for a wrong password")
+	}
+	if err := run(context.Background(), []string{"--password", "x", "proxies", "list"}, getenv, &stdout, &stderr); err == nil {
+		t.Error("want an error for the --password flag")
+	}
+}
@@ -0,0 +1,82 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"maps"
+	"slices"
+	"time"
+
+	zabbix "github.com/nimok/nim-go-zabbix"
+)
+
+func maintenanceStart(ctx context.Context, c *cli, args []string) error {
+	var hosts, groups stringsFlag
+	fs := c.newFlagSet("maintenance start")
+	fs.Var(&hosts, "host", "host put in maintenance (repeatable)")
+	fs.Var(&groups, "group", "host group put in maintenance (repeatable)")
+	duration := fs.Duration("duration", time.Hour, "duration of the maintenance")
+	noData := fs.Bool("no-data", false, "stop collecting data during the maintenance")
+	description := fs.String("description", "", "description of the maintenance")
+	names, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(names) != 1 {
+		return errors.New("exactly one maintenance name must be given")
+	}
+	if len(hosts) == 0 && len(groups) == 0 {
+		return errors.New("at least one --host or --group must be given")
+	}
+	if *duration < time.Minute {
+		return errors.New("the duration must be at least a minute")
+	}
+
+	now := time.Now()
+	maintenance := zabbix.Maintenance{
+		Name:        names[0],
+		Description: *description,
+		ActiveSince: zabbix.FlexInt64(now.Unix()),
+		ActiveTill:  zabbix.FlexInt64(now.Add(*duration).Unix()),
+		TimePeriods: []zabbix.TimePeriod{{
+			TimePeriodType: zabbix.TimePeriodTypeOnce,
+			StartDate:      zabbix.FlexInt64(now.Unix()),
+			Period:         zabbix.FlexInt64(duration.Seconds()),
+		}},
+	}
+	if *noData {
+		maintenance.MaintenanceType = zabbix.MaintenanceTypeNoData
+	}
+
+	resolver := zabbix.NewResolver(c.client)
+	if len(hosts) > 0 {
+		ids, err := resolver.HostIDs(ctx, hosts...)
+		if err != nil {
+			return err
+		}
+		for _, id := range slices.Sorted(maps.Values(ids)) {
+			maintenance.Hosts = append(maintenance.Hosts, zabbix.Host{HostID: id})
+		}
+	}
+	if len(groups) > 0 {
+		ids, err := resolver.HostGroupIDs(ctx, groups...)
+		if err != nil {
+			return err
+		}
+		for _, id := range slices.Sorted(maps.Values(ids)) {
+			maintenance.Groups = append(maintenance.Groups, zabbix.HostGroup{GroupID: id})
+		}
+	}
+
+	resp, err := c.client.MaintenanceCreate(ctx, []zabbix.Maintenance{maintenance})
+	if err != nil {
+		return err
+	}
+	if len(resp.MaintenanceIDs) == 0 {
+		return errors.New("no maintenance created")
+	}
+
+	t := &table{header: []string{"MAINTENANCEID", "NAME", "UNTIL"}}
+	t.add(resp.MaintenanceIDs[0], maintenance.Name, formatUnix(int64(maintenance.ActiveTill)))
+	return c.print(resp, t)
+}
@@ -0,0 +1,103 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// table is the table output of a command.
+type table struct {
+	header []string
+	rows   [][]string
+}
+
+func (t *table) add(row ...string) {
+	t.rows = append(t.rows, row)
+}
+
+// print prints the result of a command: t for the table output, v encoded
+// as the Zabbix API does for the JSON and YAML outputs.
+func (c *cli) print(v any, t *table) error {
+	switch c.output {
+	case "json":
+		data, err := json.MarshalIndent(v, "", "  ")
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintf(c.stdout, "%s\n", data)
+		return err
+
+	case "yaml":
+		// Encode through JSON for the field names of the API
+		data, err := json.Marshal(v)
+		if err != nil {
+			return err
+		}
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		decoder.UseNumber()
+		var doc any
+		if err := decoder.Decode(&doc); err != nil {
+			return err
+		}
+		data, err = yaml.Marshal(decodeNumbers(doc))
+		if err != nil {
+			return err
+		}
+		_, err = c.stdout.Write(data)
+		return err
+
+	default:
+		w := tabwriter.NewWriter(c.stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, strings.Join(t.header, "\t"))
+		for _, row := range t.rows {
+			fmt.Fprintln(w, strings.Join(row, "\t"))
+		}
+		return w.Flush()
+	}
+}
+
+// decodeNumbers replaces the json.Number values of v, which YAML would
+// encode as strings, with int64 or float64 values.
+func decodeNumbers(v any) any {
+	switch v := v.(type) {
+	case map[string]any:
+		for k, e := range v {
+			v[k] = decodeNumbers(e)
+		}
+	case []any:
+		for i, e := range v {
+			v[i] = decodeNumbers(e)
+		}
+	case json.Number:
+		if n, err := v.Int64(); err == nil {
+			return n
+		}
+		if f, err := v.Float64(); err == nil {
+			return f
+		}
+	}
+	return v
+}
+
+// formatTime formats t for the table output, empty if it is zero.
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Local().Format(time.DateTime)
+}
+
+// formatUnix formats a Unix timestamp for the table output, "never" if it
+// is zero.
+func formatUnix(sec int64) string {
+	if sec == 0 {
+		return "never"
+	}
+	return formatTime(time.Unix(sec, 0))
+}
@@ -0,0 +1,80 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	zabbix "github.com/nimok/nim-go-zabbix"
+)
+
+func problemsList(ctx context.Context, c *cli, args []string) error {
+	var hosts stringsFlag
+	fs := c.newFlagSet("problems list")
+	fs.Var(&hosts, "host", "list only the problems of the host (repeatable)")
+	severity := fs.String("severity", "", "minimum severity, as a name (e.g. high) or a number from 0 to 5")
+	limit := fs.Int("limit", 0, "maximum number of problems listed, the most recent first")
+	if _, err := parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	params := zabbix.ProblemGetParams{
+		GetParameters: zabbix.GetParameters{
+			Output:    zabbix.SelectExtendedOutput,
+			Sortfield: []string{"eventid"},
+			Sortorder: zabbix.GetParametersSortOrderDESC,
+			Limit:     *limit,
+		},
+	}
+	if *severity != "" {
+		min, err := parseSeverity(*severity)
+		if err != nil {
+			return err
+		}
+		for s := min; s <= zabbix.SeverityDisaster; s++ {
+			params.Severities = append(params.Severities, int(s))
+		}
+	}
+	if len(hosts) > 0 {
+		ids, err := zabbix.NewResolver(c.client).HostIDs(ctx, hosts...)
+		if err != nil {
+			return err
+		}
+		params.HostIDs = slices.Sorted(maps.Values(ids))
+	}
+
+	problems, err := c.client.ProblemGet(ctx, params)
+	if err != nil {
+		return err
+	}
+
+	t := &table{header: []string{"EVENTID", "SEVERITY", "SINCE", "DURATION", "ACK", "NAME"}}
+	for _, problem := range *problems {
+		ack := "no"
+		if problem.Acknowledged {
+			ack = "yes"
+		}
+		t.add(problem.EventID, problem.Severity.String(), formatTime(problem.Clock), problem.Duration().Truncate(time.Second).String(), ack, problem.Name)
+	}
+	return c.print(problems, t)
+}
+
+// parseSeverity parses a severity given as a number or a name, ignoring
+// case and spaces, e.g. "4", "High" or "notclassified".
+func parseSeverity(s string) (zabbix.Severity, error) {
+	if n, err := strconv.Atoi(s); err == nil && n >= int(zabbix.SeverityNotClassified) && n <= int(zabbix.SeverityDisaster) {
+		return zabbix.Severity(n), nil
+	}
+
+	normalize := func(s string) string { return strings.ToLower(strings.ReplaceAll(s, " ", "")) }
+	for severity := zabbix.SeverityNotClassified; severity <= zabbix.SeverityDisaster; severity++ {
+		if normalize(severity.String()) == normalize(s) {
+			return severity, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown severity %q", s)
+}
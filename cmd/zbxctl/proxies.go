@@ -0,0 +1,31 @@
+package main
+
+import (
+	"context"
+
+	zabbix "github.com/nimok/nim-go-zabbix"
+)
+
+func proxiesList(ctx context.Context, c *cli, args []string) error {
+	if _, err := parseFlags(c.newFlagSet("proxies list"), args); err != nil {
+		return err
+	}
+
+	proxies, err := c.client.ProxyGet(ctx, zabbix.ProxyGetParameters{
+		GetParameters: zabbix.GetParameters{Output: zabbix.SelectExtendedOutput},
+		SortField:     "name",
+	})
+	if err != nil {
+		return err
+	}
+
+	t := &table{header: []string{"PROXYID", "NAME", "MODE", "ADDRESS", "LASTACCESS"}}
+	for _, proxy := range proxies {
+		mode, address := "active", proxy.AllowedAddresses
+		if proxy.OperatingMode == 1 {
+			mode, address = "passive", proxy.Address+":"+proxy.Port
+		}
+		t.add(proxy.ProxyID, proxy.Name, mode, address, formatUnix(int64(proxy.LastAccess)))
+	}
+	return c.print(proxies, t)
+}
@@ -0,0 +1,34 @@
+package main
+
+import (
+	"context"
+
+	zabbix "github.com/nimok/nim-go-zabbix"
+)
+
+func templatesList(ctx context.Context, c *cli, args []string) error {
+	fs := c.newFlagSet("templates list")
+	search := fs.String("search", "", "list only the templates whose name contains text")
+	if _, err := parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	params := zabbix.TemplateGetParameters{
+		GetParameters: zabbix.GetParameters{Output: zabbix.SelectExtendedOutput},
+		SortField:     "host",
+	}
+	if *search != "" {
+		params.Search = map[string]string{"name": *search}
+	}
+
+	templates, err := c.client.TemplateGet(ctx, params)
+	if err != nil {
+		return err
+	}
+
+	t := &table{header: []string{"TEMPLATEID", "HOST", "NAME"}}
+	for _, template := range templates {
+		t.add(template.TemplateID, template.Host, template.Name)
+	}
+	return c.print(templates, t)
+}
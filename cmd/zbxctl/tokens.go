@@ -0,0 +1,61 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	zabbix "github.com/nimok/nim-go-zabbix"
+)
+
+func tokensCreate(ctx context.Context, c *cli, args []string) error {
+	fs := c.newFlagSet("tokens create")
+	expires := fs.Duration("expires", 0, "time after which the token expires (default never)")
+	names, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(names) != 1 {
+		return errors.New("exactly one token name must be given")
+	}
+
+	token := zabbix.Token{Name: names[0]}
+	if *expires > 0 {
+		token.ExpiresAt = zabbix.FlexInt64(time.Now().Add(*expires).Unix())
+	}
+	created, err := c.client.TokenCreate(ctx, token)
+	if err != nil {
+		return err
+	}
+	generated, err := c.client.TokenGenerate(ctx, zabbix.TokenGenerateParameters(created.TokenIDs))
+	if err != nil {
+		return err
+	}
+
+	t := &table{header: []string{"TOKENID", "NAME", "TOKEN"}}
+	for _, g := range generated {
+		t.add(g.TokenId, token.Name, g.Token)
+	}
+	return c.print(generated, t)
+}
+
+func tokensDelete(ctx context.Context, c *cli, args []string) error {
+	ids, err := parseFlags(c.newFlagSet("tokens delete"), args)
+	if err != nil {
+		return err
+	}
+	if len(ids) == 0 {
+		return errors.New("no token ID given")
+	}
+
+	resp, err := c.client.TokenDelete(ctx, zabbix.TokenDeleteParameters(ids))
+	if err != nil {
+		return err
+	}
+
+	t := &table{header: []string{"TOKENID"}}
+	for _, id := range resp.TokenIDs {
+		t.add(id)
+	}
+	return c.print(resp, t)
+}
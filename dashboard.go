@@ -0,0 +1,161 @@
+package zabbix
+
+import "context"
+
+// Permissions of the users and user groups a dashboard or map is shared
+// with.
+const (
+	PermissionReadOnly  = 2
+	PermissionReadWrite = 3
+)
+
+// Dashboard represents a dashboard in Zabbix.
+type Dashboard struct {
+	DashboardID   string            `json:"dashboardid,omitempty"`    // ID of the dashboard; read-only, required for update operations
+	Name          string            `json:"name,omitempty"`           // Name of the dashboard; required for create operations
+	UserID        string            `json:"userid,omitempty"`         // ID of the owner; default is the current user
+	Private       *FlexInt          `json:"private,omitempty"`        // Sharing type; 0 public, 1 private; nil for the default, private
+	DisplayPeriod FlexInt           `json:"display_period,omitempty"` // Default time in seconds each page is shown in slideshows; default is 30
+	AutoStart     FlexInt           `json:"auto_start,omitempty"`     // Whether the slideshow starts automatically; 0 no, 1 (default) yes
+	UUID          string            `json:"uuid,omitempty"`           // Universal unique identifier; read-only
+	Pages         []DashboardPage   `json:"pages,omitempty"`          // Pages of the dashboard; required for create operations
+	Users         []SharedUser      `json:"users,omitempty"`          // Users the dashboard is shared with
+	UserGroups    []SharedUserGroup `json:"userGroups,omitempty"`     // User groups the dashboard is shared with
+}
+
+// DashboardPage is a page of a dashboard. Pages replace the existing ones on
+// update; existing pages are kept by DashboardPageID.
+type DashboardPage struct {
+	DashboardPageID string            `json:"dashboard_pageid,omitempty"` // ID of the page; read-only
+	Name            string            `json:"name,omitempty"`             // Name of the page
+	DisplayPeriod   FlexInt           `json:"display_period,omitempty"`   // Time in seconds the page is shown in slideshows; 0 for the dashboard default
+	Widgets         []DashboardWidget `json:"widgets,omitempty"`          // Widgets of the page
+}
+
+// DashboardWidget is a widget of a dashboard page, placed on a grid of 72
+// columns and 64 rows in Zabbix 7.0, of 24 columns and 32 rows before.
+type DashboardWidget struct {
+	WidgetID string        `json:"widgetid,omitempty"`  // ID of the widget; read-only
+	Type     string        `json:"type"`                // Type of the widget, e.g. "problems"; required
+	Name     string        `json:"name,omitempty"`      // Custom name of the widget
+	X        FlexInt       `json:"x,omitempty"`         // Horizontal position from the left of the page
+	Y        FlexInt       `json:"y,omitempty"`         // Vertical position from the top of the page
+	Width    FlexInt       `json:"width,omitempty"`     // Width of the widget in columns
+	Height   FlexInt       `json:"height,omitempty"`    // Height of the widget in rows
+	ViewMode FlexInt       `json:"view_mode,omitempty"` // 0 (default) with header, 1 header hidden
+	Fields   []WidgetField `json:"fields,omitempty"`    // Configuration of the widget
+}
+
+// Types of widget fields, which tell how their value is interpreted.
+const (
+	WidgetFieldTypeInteger        = 0
+	WidgetFieldTypeString         = 1
+	WidgetFieldTypeHostGroup      = 2
+	WidgetFieldTypeHost           = 3
+	WidgetFieldTypeItem           = 4
+	WidgetFieldTypeItemPrototype  = 5
+	WidgetFieldTypeGraph          = 6
+	WidgetFieldTypeGraphPrototype = 7
+	WidgetFieldTypeMap            = 8
+	WidgetFieldTypeService        = 9
+	WidgetFieldTypeSLA            = 10
+	WidgetFieldTypeUser           = 11
+	WidgetFieldTypeAction         = 12
+	WidgetFieldTypeMediaType      = 13
+)
+
+// WidgetField is a configuration field of a widget. The value of ID fields,
+// such as WidgetFieldTypeHost, is the ID of the object. Fields with several
+// values are repeated with an index in their name, e.g. "hostids.0" and
+// "hostids.1".
+type WidgetField struct {
+	Type  FlexInt `json:"type"`  // Type of the field, one of the WidgetFieldType constants
+	Name  string  `json:"name"`  // Name of the field
+	Value string  `json:"value"` // Value of the field, as a string even for integers
+}
+
+// SharedUser is a user a dashboard or map is shared with.
+type SharedUser struct {
+	UserID     string  `json:"userid"`     // ID of the user
+	Permission FlexInt `json:"permission"` // PermissionReadOnly or PermissionReadWrite
+}
+
+// SharedUserGroup is a user group a dashboard or map is shared with.
+type SharedUserGroup struct {
+	UserGroupID string  `json:"usrgrpid"`   // ID of the user group
+	Permission  FlexInt `json:"permission"` // PermissionReadOnly or PermissionReadWrite
+}
+
+type DashboardGetParameters struct {
+	GetParameters
+
+	DashboardIDs     []string `json:"dashboardids,omitempty"`
+	SelectPages      any      `json:"selectPages,omitempty"`
+	SelectUsers      any      `json:"selectUsers,omitempty"`
+	SelectUserGroups any      `json:"selectUserGroups,omitempty"`
+}
+
+type DashboardCreateResponse struct {
+	DashboardIDs []string `json:"dashboardids"` // IDs of the created dashboards
+}
+
+type DashboardUpdateResponse struct {
+	DashboardIDs []string `json:"dashboardids"` // IDs of the updated dashboards
+}
+
+type DashboardDeleteResponse struct {
+	DashboardIDs []string `json:"dashboardids"` // IDs of the deleted dashboards
+}
+
+func (z *zabbixClient) DashboardGet(ctx context.Context, params DashboardGetParameters) ([]Dashboard, error) {
+
+	var result []Dashboard
+
+	err := z.makeRequest(ctx, "dashboard.get", params, &result)
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (z *zabbixClient) DashboardCreate(ctx context.Context, params []Dashboard) (*DashboardCreateResponse, error) {
+
+	var result DashboardCreateResponse
+
+	err := z.makeRequest(ctx, "dashboard.create", params, &result)
+	if err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+func (z *zabbixClient) DashboardUpdate(ctx context.Context, params Dashboard, fields ...string) (*DashboardUpdateResponse, error) {
+
+	var result DashboardUpdateResponse
+
+	update, err := withFields(params, fields)
+	if err != nil {
+		return nil, err
+	}
+
+	err = z.makeRequest(ctx, "dashboard.update", update, &result)
+	if err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+func (z *zabbixClient) DashboardDelete(ctx context.Context, params []string) (*DashboardDeleteResponse, error) {
+
+	var result DashboardDeleteResponse
+
+	err := z.makeRequest(ctx, "dashboard.delete", params, &result)
+	if err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
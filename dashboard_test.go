@@ -0,0 +1,140 @@
+package zabbix_test
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	zabbix "github.com/nimok/nim-go-zabbix"
+)
+
+func TestDashboardCreateAndUpdate(t *testing.T) {
+	ctx := context.Background()
+
+	client, err := zabbix.NewClient(url, zabbix.WithUserPass(user, passwd))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Authenticate
+	if err := client.Authenticate(); err != nil {
+		t.Fatal("Initial auth failed:", err)
+	}
+
+	hosts, err := client.HostGet(ctx, zabbix.HostGetParameters{
+		GetParameters: zabbix.GetParameters{Filter: map[string]any{"host": "Zabbix server"}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(hosts) == 0 {
+		t.Fatal("No hosts found")
+	}
+	hostIDs := []string{hosts[0].HostID}
+
+	resp, err := client.DashboardCreate(ctx, []zabbix.Dashboard{{
+		Name: "testing-dashboard",
+		Pages: []zabbix.DashboardPage{{
+			Name: "Overview",
+			Widgets: []zabbix.DashboardWidget{
+				zabbix.NewProblemsWidget(hostIDs, []zabbix.Severity{zabbix.SeverityHigh, zabbix.SeverityDisaster},
+					zabbix.WithWidgetName("Team problems"), zabbix.WithWidgetSize(36, 8)),
+				zabbix.NewTopHostsWidget(hostIDs, []zabbix.TopHostsColumn{
+					{Name: "Host", Data: zabbix.TopHostsDataHostName},
+					{Name: "CPU", Item: "CPU utilization"},
+				}, zabbix.WithWidgetPosition(36, 0)),
+			},
+		}},
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	dashboardID := resp.DashboardIDs[0]
+	defer client.DashboardDelete(ctx, []string{dashboardID})
+
+	get := func() zabbix.Dashboard {
+		t.Helper()
+		dashboards, err := client.DashboardGet(ctx, zabbix.DashboardGetParameters{
+			DashboardIDs:     []string{dashboardID},
+			SelectPages:      "extend",
+			SelectUsers:      "extend",
+			SelectUserGroups: "extend",
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(dashboards) != 1 {
+			t.Fatalf("expected 1 dashboard, got %d", len(dashboards))
+		}
+		return dashboards[0]
+	}
+
+	// Without Private, the dashboard is private as by default
+	dashboard := get()
+	if dashboard.Private == nil || *dashboard.Private != 1 {
+		t.Errorf("expected a private dashboard, got private %v", dashboard.Private)
+	}
+	if len(dashboard.Pages) != 1 || len(dashboard.Pages[0].Widgets) != 2 {
+		t.Fatalf("unexpected pages %+v", dashboard.Pages)
+	}
+	problems := dashboard.Pages[0].Widgets[0]
+	if problems.Type != "problems" || problems.Name != "Team problems" || problems.Width != 36 {
+		t.Errorf("unexpected widget %+v", problems)
+	}
+	if got := problems.FieldValues("hostids"); !slices.Equal(got, hostIDs) {
+		t.Errorf("expected host IDs %v, got %v", hostIDs, got)
+	}
+	if got := problems.FieldValues("severities"); !slices.Equal(got, []string{"4", "5"}) {
+		t.Errorf("unexpected severities %v", got)
+	}
+
+	// Pages and widgets given with their ID are kept
+	pageID := dashboard.Pages[0].DashboardPageID
+	dashboard.Pages[0].Widgets = dashboard.Pages[0].Widgets[:1]
+	dashboard.Pages = append(dashboard.Pages, zabbix.DashboardPage{Name: "Details"})
+	if _, err := client.DashboardUpdate(ctx, zabbix.Dashboard{
+		DashboardID: dashboardID,
+		Pages:       dashboard.Pages,
+		Users:       []zabbix.SharedUser{{UserID: dashboard.UserID, Permission: zabbix.PermissionReadWrite}},
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	dashboard = get()
+	if len(dashboard.Pages) != 2 || dashboard.Pages[0].DashboardPageID != pageID {
+		t.Fatalf("expected page %s to be kept, got %+v", pageID, dashboard.Pages)
+	}
+	if len(dashboard.Pages[0].Widgets) != 1 || dashboard.Pages[0].Widgets[0].WidgetID != problems.WidgetID {
+		t.Errorf("expected widget %s to be kept, got %+v", problems.WidgetID, dashboard.Pages[0].Widgets)
+	}
+	if len(dashboard.Users) != 1 || dashboard.Users[0].Permission != zabbix.PermissionReadWrite {
+		t.Errorf("unexpected users %+v", dashboard.Users)
+	}
+}
+
+func TestDashboardCreateFailWidgetOutsidePage(t *testing.T) {
+	ctx := context.Background()
+
+	client, err := zabbix.NewClient(url, zabbix.WithUserPass(user, passwd))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Authenticate
+	if err := client.Authenticate(); err != nil {
+		t.Fatal("Initial auth failed:", err)
+	}
+
+	resp, err := client.DashboardCreate(ctx, []zabbix.Dashboard{{
+		Name: "testing-dashboard-invalid",
+		Pages: []zabbix.DashboardPage{{
+			Widgets: []zabbix.DashboardWidget{
+				zabbix.NewProblemsWidget(nil, nil, zabbix.WithWidgetPosition(0, 0), zabbix.WithWidgetSize(100, 5)),
+			},
+		}},
+	}})
+	if err == nil {
+		client.DashboardDelete(ctx, resp.DashboardIDs)
+		t.Fatal("expected a widget wider than the page to fail")
+	}
+}
@@ -0,0 +1,145 @@
+package zabbix
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+)
+
+// FlexInt is an int that decodes from both JSON numbers and the
+// string-encoded numbers Zabbix returns for most integer properties.
+type FlexInt int
+
+// FlexInt64 is the int64 counterpart of FlexInt, used for timestamps.
+type FlexInt64 int64
+
+// FlexFloat is a float64 that decodes from both JSON numbers and the
+// string-encoded decimals Zabbix returns, e.g. "99.9000" for an SLO.
+type FlexFloat float64
+
+func (n *FlexInt) UnmarshalJSON(data []byte) error {
+	return unmarshalInt(data, n)
+}
+
+func (n *FlexInt64) UnmarshalJSON(data []byte) error {
+	return unmarshalInt(data, n)
+}
+
+func (n *FlexFloat) UnmarshalJSON(data []byte) error {
+	data = unquote(data)
+	if len(data) == 0 || bytes.Equal(data, []byte("null")) {
+		*n = 0
+		return nil
+	}
+	f, err := strconv.ParseFloat(string(data), 64)
+	if err != nil {
+		return fmt.Errorf("invalid number %s", data)
+	}
+	*n = FlexFloat(f)
+	return nil
+}
+
+// unmarshalInt decodes a possibly string-encoded number into any integer
+// type. It backs the UnmarshalJSON methods of the enum types.
+func unmarshalInt[T ~int | ~int64](data []byte, v *T) error {
+	n, err := parseInt(data)
+	if err != nil {
+		return err
+	}
+	*v = T(n)
+	return nil
+}
+
+// isEmptyArray reports whether data is the empty JSON array Zabbix returns in
+// place of an empty object, e.g. for interface details or host inventory.
+func isEmptyArray(data []byte) bool {
+	return bytes.Equal(bytes.TrimSpace(data), []byte("[]"))
+}
+
+// decodeResponse decodes a JSON-RPC response straight into result.
+func decodeResponse(r io.Reader, result any) error {
+	res := apiResponse{
+		Result: result,
+	}
+
+	decoder := json.NewDecoder(r)
+	if err := decoder.Decode(&res); err != nil {
+		return fmt.Errorf("json decode error: %v", err)
+	}
+
+	if res.Error != nil {
+		return res.Error
+	}
+
+	return nil
+}
+
+// unquote strips the quotes Zabbix puts around most numeric values.
+func unquote(data []byte) []byte {
+	if len(data) >= 2 && data[0] == '"' && data[len(data)-1] == '"' {
+		return data[1 : len(data)-1]
+	}
+	return data
+}
+
+// parseInt decodes a JSON number or a string-encoded number. Empty strings and
+// null decode to 0.
+func parseInt(data []byte) (int64, error) {
+	data = unquote(data)
+	if len(data) == 0 || bytes.Equal(data, []byte("null")) {
+		return 0, nil
+	}
+	n, err := strconv.ParseInt(string(data), 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid number %s", data)
+	}
+	return n, nil
+}
+
+// parseBool decodes a JSON boolean, or the "0"/"1" Zabbix uses for flags.
+func parseBool(data []byte) (bool, error) {
+	data = unquote(data)
+	switch string(data) {
+	case "", "null", "0", "false":
+		return false, nil
+	case "1", "true":
+		return true, nil
+	}
+	return false, fmt.Errorf("invalid boolean %s", data)
+}
+
+// parseUnixTime decodes Unix seconds (and optionally nanoseconds) into a
+// time.Time. A zero timestamp decodes to the zero time.
+func parseUnixTime(sec, nsec []byte) (time.Time, error) {
+	s, err := parseInt(sec)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if s == 0 {
+		return time.Time{}, nil
+	}
+	ns, err := parseInt(nsec)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return time.Unix(s, ns), nil
+}
+
+// formatUnixTime encodes t the way Zabbix does, as string-encoded Unix
+// seconds. The zero time encodes to "0".
+func formatUnixTime(t time.Time) string {
+	if t.IsZero() {
+		return "0"
+	}
+	return strconv.FormatInt(t.Unix(), 10)
+}
+
+func formatBool(b bool) string {
+	if b {
+		return "1"
+	}
+	return "0"
+}
@@ -0,0 +1,159 @@
+package zabbix
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+const hostResponse = `{"jsonrpc":"2.0","id":1,"result":[{
+	"hostid":"10084","host":"Zabbix server","status":"0","flags":"0","inventory_mode":"1",
+	"maintenance_from":"1700000000","monitored_by":"1","proxyid":"5","tls_connect":"2","tls_accept":3,
+	"interfaces":[
+		{"interfaceid":"1","hostid":"10084","type":"1","ip":"127.0.0.1","dns":"","port":"10050","useip":"1","main":"1",
+		 "available":"2","error":"connection refused","errors_from":"1700000100","disable_until":"0","details":[]},
+		{"interfaceid":"2","hostid":"10084","type":"2","ip":"127.0.0.1","dns":"","port":"161","useip":"1","main":"1",
+		 "details":{"version":"3","bulk":"1","max_repetitions":"10","securitylevel":"2","authprotocol":"1","privprotocol":"3"}}
+	],
+	"inventory":{"macaddress_a":"00:11:22:33:44:55"}
+},{
+	"hostid":"10085","host":"no-inventory","status":"1","inventory":[]
+}]}`
+
+func TestDecodeResponse(t *testing.T) {
+	var hosts []Host
+	if err := decodeResponse(strings.NewReader(hostResponse), &hosts); err != nil {
+		t.Fatal(err)
+	}
+	if len(hosts) != 2 {
+		t.Fatalf("expected 2 hosts, got %d", len(hosts))
+	}
+
+	host := hosts[0]
+	if host.Status == nil || *host.Status != HostStatusMonitored || host.InventoryMode != InventoryAuto {
+		t.Errorf("unexpected host %+v", host)
+	}
+	if host.MaintenanceFrom != 1700000000 || host.MonitoredBy != MonitoredByProxy || host.TlsAccept != TLSNoEncryption|TLSPSK {
+		t.Errorf("unexpected host %+v", host)
+	}
+	if host.Inventory == nil || host.Inventory.MacAddressA != "00:11:22:33:44:55" {
+		t.Errorf("unexpected inventory %+v", host.Inventory)
+	}
+
+	agent, snmp := host.Interfaces[0], host.Interfaces[1]
+	if agent.Type != InterfaceTypeAgent || agent.Available != InterfaceStateUnavailable || agent.ErrorsFrom != 1700000100 {
+		t.Errorf("unexpected agent interface %+v", agent)
+	}
+	if snmp.Details.Version != SNMPv3 || snmp.Details.SecurityLevel != SecurityLevelAuthPriv || snmp.Details.PrivProtocol != PrivProtocolAES256 {
+		t.Errorf("unexpected snmp interface %+v", snmp)
+	}
+
+	if hosts[1].Status == nil || *hosts[1].Status != HostStatusUnmonitored {
+		t.Errorf("unexpected host %+v", hosts[1])
+	}
+}
+
+func TestDecodeResponseError(t *testing.T) {
+	body := `{"jsonrpc":"2.0","id":1,"error":{"code":-32602,"message":"Invalid params.","data":"No permissions to referred object or it does not exist!"}}`
+
+	var hosts []Host
+	err := decodeResponse(strings.NewReader(body), &hosts)
+	if err == nil || !strings.Contains(err.Error(), "-32602") {
+		t.Fatalf("expected API error, got %v", err)
+	}
+}
+
+// largeHostResponse builds a host.get response with n hosts, each with
+// interfaces, macros, groups, tags and inventory selected.
+func largeHostResponse(n int) []byte {
+	var buf bytes.Buffer
+	buf.WriteString(`{"jsonrpc":"2.0","id":1,"result":[`)
+	for i := 0; i < n; i++ {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		fmt.Fprintf(&buf, `{"hostid":"%[1]d","host":"host-%[1]d","name":"Host %[1]d","description":"","status":"0",
+			"flags":"0","inventory_mode":"1","ipmi_authtype":"-1","ipmi_privilege":"2","maintenance_status":"0",
+			"maintenance_type":"0","maintenance_from":"0","monitored_by":"1","proxyid":"7","proxy_groupid":"0",
+			"tls_connect":"1","tls_accept":"1","active_available":"1","assigned_proxyid":"0",
+			"interfaces":[{"interfaceid":"%[1]d","hostid":"%[1]d","type":"1","ip":"10.0.0.1","dns":"","port":"10050",
+				"useip":"1","main":"1","available":"1","error":"","errors_from":"0","disable_until":"0","details":{}},
+				{"interfaceid":"%[1]d1","hostid":"%[1]d","type":"2","ip":"10.0.0.1","dns":"","port":"161","useip":"1",
+				"main":"1","available":"1","error":"","errors_from":"0","disable_until":"0",
+				"details":{"version":"2","bulk":"1","community":"{$SNMP_COMMUNITY}","max_repetitions":"10"}}],
+			"groups":[{"groupid":"2","name":"Linux servers","flags":"0","uuid":"dc579cd7a1a34222933f24f52a68bcd8"}],
+			"tags":[{"tag":"env","value":"prod"},{"tag":"team","value":"platform"}],
+			"macros":[{"macro":"{$SNMP_COMMUNITY}","value":"public","description":""}],
+			"inventory":{"macaddress_a":"00:11:22:33:44:55","macaddress_b":""}}`, i+10000)
+	}
+	buf.WriteString(`]}`)
+	return buf.Bytes()
+}
+
+func BenchmarkDecodeHostGet50k(b *testing.B) {
+	body := largeHostResponse(50000)
+	b.SetBytes(int64(len(body)))
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		var hosts []Host
+		if err := decodeResponse(bytes.NewReader(body), &hosts); err != nil {
+			b.Fatal(err)
+		}
+		if len(hosts) != 50000 {
+			b.Fatalf("expected 50000 hosts, got %d", len(hosts))
+		}
+	}
+}
+
+// BenchmarkHostGet50k measures a whole HostGet call of 50000 hosts, from the
+// request to the decoded result, with and without an interceptor keeping the
+// raw response. See zabbixotel for the OpenTelemetry interceptor.
+func BenchmarkHostGet50k(b *testing.B) {
+	body := largeHostResponse(50000)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write(body)
+	}))
+	defer server.Close()
+
+	passthrough := func(ctx context.Context, call *Call, next Invoker) error {
+		return next(ctx, call)
+	}
+	keep := func(ctx context.Context, call *Call, next Invoker) error {
+		call.KeepResponse = true
+		return next(ctx, call)
+	}
+	for _, bench := range []struct {
+		name string
+		opts []ClientOption
+	}{
+		{"plain", nil},
+		{"interceptor", []ClientOption{WithInterceptors(passthrough)}},
+		{"keep-response", []ClientOption{WithInterceptors(keep)}},
+	} {
+		b.Run(bench.name, func(b *testing.B) {
+			client, err := NewClient(server.URL, append(bench.opts, WithAPIToken("token"))...)
+			if err != nil {
+				b.Fatal(err)
+			}
+			b.SetBytes(int64(len(body)))
+			b.ReportAllocs()
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				hosts, err := client.HostGet(context.Background(), HostGetParameters{})
+				if err != nil {
+					b.Fatal(err)
+				}
+				if len(hosts) != 50000 {
+					b.Fatalf("expected 50000 hosts, got %d", len(hosts))
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,136 @@
+package zabbix
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// Event values (Event.Value) for trigger events.
+const (
+	EventValueOK      = 0
+	EventValueProblem = 1
+)
+
+type EventGetParams struct {
+	GetParameters
+
+	EventIDs  []string `json:"eventids,omitempty"`
+	GroupIDs  []string `json:"groupids,omitempty"`
+	HostIDs   []string `json:"hostids,omitempty"`
+	ObjectIDs []string `json:"objectids,omitempty"`
+
+	// Basic filters
+	Source       *int  `json:"source,omitempty"`       // default 0 (trigger)
+	Object       *int  `json:"object,omitempty"`       // default 0 (trigger)
+	Acknowledged *bool `json:"acknowledged,omitempty"` // true=only acked, false=only unacked
+	Suppressed   *bool `json:"suppressed,omitempty"`   // true=only suppressed
+	Symptom      *bool `json:"symptom,omitempty"`      // true=symptom, false=cause
+	Severities   []int `json:"severities,omitempty"`   // applies only if object=trigger
+	Value        []int `json:"value,omitempty"`        // event values (0 OK, 1 problem for triggers)
+
+	// Tag search rules and tags
+	EvalType *int            `json:"evaltype,omitempty"` // 0 And/Or (default), 2 Or
+	Tags     []ProblemGetTag `json:"tags,omitempty"`
+
+	// Time / range filters
+	EventIDFrom     string `json:"eventid_from,omitempty"`      // >= given ID
+	EventIDTill     string `json:"eventid_till,omitempty"`      // <= given ID
+	TimeFrom        *int64 `json:"time_from,omitempty"`         // Unix timestamp (seconds)
+	TimeTill        *int64 `json:"time_till,omitempty"`         // Unix timestamp (seconds)
+	ProblemTimeFrom *int64 `json:"problem_time_from,omitempty"` // problems active at or after this time
+	ProblemTimeTill *int64 `json:"problem_time_till,omitempty"` // problems active at or before this time
+
+	// Select/expand related data (query type: "extend", "count", or []string)
+	SelectAcknowledges    any `json:"selectAcknowledges,omitempty"`
+	SelectAlerts          any `json:"selectAlerts,omitempty"`
+	SelectRelatedObject   any `json:"selectRelatedObject,omitempty"`
+	SelectTags            any `json:"selectTags,omitempty"`
+	SelectSuppressionData any `json:"selectSuppressionData,omitempty"`
+}
+
+// Event represents one entry returned by event.get.
+type Event struct {
+	EventID       string    `json:"eventid"`       // ID
+	Source        string    `json:"source"`        // "0" trigger, "1" discovery, "2" autoregistration, "3" internal, "4" service
+	Object        string    `json:"object"`        // depends on source
+	ObjectID      string    `json:"objectid"`      // related object ID
+	Clock         time.Time `json:"clock"`         // creation time (clock and ns)
+	Ns            string    `json:"ns"`            // creation nanoseconds (as string)
+	Value         FlexInt   `json:"value"`         // state of the related object (EventValueOK or EventValueProblem for triggers)
+	Name          string    `json:"name"`          // resolved event name
+	Acknowledged  bool      `json:"acknowledged"`  // whether the event is acknowledged
+	Severity      Severity  `json:"severity"`      // current severity
+	REventID      string    `json:"r_eventid"`     // recovery event ID
+	CEventID      string    `json:"c_eventid"`     // ID of the event that closed this one (correlation)
+	CauseEventID  string    `json:"cause_eventid"` // ID of the cause event
+	CorrelationID string    `json:"correlationid"` // correlation rule ID
+	UserID        string    `json:"userid"`        // user who manually closed the problem (if any)
+	Suppressed    bool      `json:"suppressed"`    // whether the event is suppressed
+	OpData        string    `json:"opdata"`        // operational data with expanded macros
+
+	// Added when requested:
+	Hosts           []Host                  `json:"hosts,omitempty"`            // selectHosts
+	Acknowledges    []ProblemAcknowledge    `json:"acknowledges,omitempty"`     // selectAcknowledges
+	Tags            []ProblemTag            `json:"tags,omitempty"`             // selectTags
+	SuppressionData []ProblemSuppressionRef `json:"suppression_data,omitempty"` // selectSuppressionData
+}
+
+func (e *Event) UnmarshalJSON(data []byte) error {
+	type event Event
+
+	aux := struct {
+		*event
+		Clock        json.RawMessage `json:"clock"`
+		Acknowledged json.RawMessage `json:"acknowledged"`
+		Suppressed   json.RawMessage `json:"suppressed"`
+	}{event: (*event)(e)}
+
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+
+	var err error
+	if e.Clock, err = parseUnixTime(aux.Clock, []byte(e.Ns)); err != nil {
+		return fmt.Errorf("invalid event clock: %v", err)
+	}
+	if e.Acknowledged, err = parseBool(aux.Acknowledged); err != nil {
+		return fmt.Errorf("invalid event acknowledged: %v", err)
+	}
+	if e.Suppressed, err = parseBool(aux.Suppressed); err != nil {
+		return fmt.Errorf("invalid event suppressed: %v", err)
+	}
+	return nil
+}
+
+// MarshalJSON encodes the event the way event.get returns it.
+func (e Event) MarshalJSON() ([]byte, error) {
+	type event Event
+
+	return json.Marshal(struct {
+		event
+		Clock        string `json:"clock"`
+		Acknowledged string `json:"acknowledged"`
+		Severity     string `json:"severity"`
+		Suppressed   string `json:"suppressed"`
+	}{
+		event:        event(e),
+		Clock:        formatUnixTime(e.Clock),
+		Acknowledged: formatBool(e.Acknowledged),
+		Severity:     fmt.Sprint(int(e.Severity)),
+		Suppressed:   formatBool(e.Suppressed),
+	})
+}
+
+func (z *zabbixClient) EventGet(ctx context.Context, params EventGetParams) ([]Event, error) {
+
+	var result []Event
+
+	err := z.makeRequest(ctx, "event.get", params, &result)
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
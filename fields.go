@@ -0,0 +1,109 @@
+package zabbix
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// Most fields of the objects are omitted when empty, so an update can't set
+// them to their zero value, e.g. Host.InventoryMode back to InventoryManual
+// or Host.Description to "". The update methods take the names of the fields
+// to send even if they are empty, as named by the API, e.g.:
+//
+//	client.HostUpdate(ctx, zabbix.Host{HostID: "10084"}, "description", "inventory_mode")
+//
+// clears the description of the host and sets its inventory mode to manual.
+// The fields not named are sent only if they are not empty, as without names.
+// Fields of nested objects are named by their path, e.g.:
+//
+//	client.HostInterfaceUpdate(ctx, iface, "details.bulk", "details.securitylevel")
+//
+// sends the details of the interface with bulk requests disabled and no
+// security even though both are 0.
+
+// withFields returns object with the fields named by fields always included,
+// or object itself if fields is empty. Names with dots are paths to the
+// fields of nested structs or non-nil pointers to structs. Unknown names
+// fail, so that a typo doesn't silently leave a field unchanged.
+func withFields(object any, fields []string) (any, error) {
+	if len(fields) == 0 {
+		return object, nil
+	}
+
+	v := reflect.ValueOf(object)
+	names := jsonFields(v.Type())
+	nested := make(map[string][]string)
+	for _, field := range fields {
+		name, rest, isPath := strings.Cut(field, ".")
+		index, ok := names[name]
+		if ok && isPath {
+			t := v.Type().FieldByIndex(index).Type
+			ok = t.Kind() == reflect.Struct || t.Kind() == reflect.Pointer && t.Elem().Kind() == reflect.Struct
+			nested[name] = append(nested[name], rest)
+		}
+		if !ok {
+			return nil, fmt.Errorf("unknown field %q of %s", field, v.Type().Name())
+		}
+	}
+
+	data, err := json.Marshal(object)
+	if err != nil {
+		return nil, err
+	}
+	var m map[string]json.RawMessage
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, err
+	}
+
+	for _, field := range fields {
+		if _, ok := nested[field]; ok || strings.Contains(field, ".") {
+			continue
+		}
+		value, err := json.Marshal(v.FieldByIndex(names[field]).Interface())
+		if err != nil {
+			return nil, err
+		}
+		m[field] = value
+	}
+	for name, paths := range nested {
+		field := v.FieldByIndex(names[name])
+		if field.Kind() == reflect.Pointer {
+			if field.IsNil() {
+				return nil, fmt.Errorf("field %q of %s is nil", name, v.Type().Name())
+			}
+			field = field.Elem()
+		}
+		object, err := withFields(field.Interface(), paths)
+		if err != nil {
+			return nil, err
+		}
+		if m[name], err = json.Marshal(object); err != nil {
+			return nil, err
+		}
+	}
+	return m, nil
+}
+
+// jsonFields returns the indexes of the fields of t by JSON name, including
+// the fields of embedded structs.
+func jsonFields(t reflect.Type) map[string][]int {
+	names := make(map[string][]int)
+	for _, f := range reflect.VisibleFields(t) {
+		if !f.IsExported() || f.Anonymous {
+			continue
+		}
+		name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		if _, ok := names[name]; !ok {
+			names[name] = f.Index
+		}
+	}
+	return names
+}
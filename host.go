@@ -1,34 +1,37 @@
 package zabbix
 
-import "context"
+import (
+	"context"
+	"encoding/json"
+)
 
 // Host represents a Zabbix host object.
 type Host struct {
 	HostID            string          `json:"hostid,omitempty"`             // ID of the host (read-only; required for update operations)
 	Host              string          `json:"host,omitempty"`               // Technical name of the host (required for create operations)
 	Description       string          `json:"description,omitempty"`        // Description of the host
-	Flags             int             `json:"flags,omitempty"`              // Origin of the host (0 - plain host; 4 - discovered host) (read-only)
-	InventoryMode     int             `json:"inventory_mode,omitempty"`     // Host inventory population mode (-1 - disabled; 0 - manual; 1 - automatic)
-	IPMIAuthtype      int             `json:"ipmi_authtype,omitempty"`      // IPMI authentication algorithm (-1 - default; 0 - none; 1 - MD2; 2 - MD5; 4 - straight; 5 - OEM; 6 - RMCP+)
+	Flags             FlexInt         `json:"flags,omitempty"`              // Origin of the host (0 - plain host; 4 - discovered host) (read-only)
+	InventoryMode     FlexInt         `json:"inventory_mode,omitempty"`     // Host inventory population mode (-1 - disabled; 0 - manual; 1 - automatic)
+	IPMIAuthtype      FlexInt         `json:"ipmi_authtype,omitempty"`      // IPMI authentication algorithm (-1 - default; 0 - none; 1 - MD2; 2 - MD5; 4 - straight; 5 - OEM; 6 - RMCP+)
 	IPMIPassword      string          `json:"ipmi_password,omitempty"`      // IPMI password
-	IPMIPrivilege     int             `json:"ipmi_privilege,omitempty"`     // IPMI privilege level (1 - callback; 2 - user; 3 - operator; 4 - admin; 5 - OEM)
+	IPMIPrivilege     FlexInt         `json:"ipmi_privilege,omitempty"`     // IPMI privilege level (1 - callback; 2 - user; 3 - operator; 4 - admin; 5 - OEM)
 	IPMIUsername      string          `json:"ipmi_username,omitempty"`      // IPMI username
-	MaintenanceFrom   int64           `json:"maintenance_from,omitempty"`   // Starting time of the effective maintenance (read-only)
-	MaintenanceStatus int             `json:"maintenance_status,omitempty"` // Effective maintenance status (0 - no maintenance; 1 - maintenance in effect) (read-only)
-	MaintenanceType   int             `json:"maintenance_type,omitempty"`   // Effective maintenance type (0 - with data collection; 1 - without data collection) (read-only)
+	MaintenanceFrom   FlexInt64       `json:"maintenance_from,omitempty"`   // Starting time of the effective maintenance (read-only)
+	MaintenanceStatus FlexInt         `json:"maintenance_status,omitempty"` // Effective maintenance status (0 - no maintenance; 1 - maintenance in effect) (read-only)
+	MaintenanceType   FlexInt         `json:"maintenance_type,omitempty"`   // Effective maintenance type (0 - with data collection; 1 - without data collection) (read-only)
 	MaintenanceID     string          `json:"maintenanceid,omitempty"`      // ID of the maintenance currently in effect on the host (read-only)
 	Name              string          `json:"name,omitempty"`               // Visible name of the host (defaults to 'host' property value)
-	MonitoredBy       int             `json:"monitored_by,omitempty"`       // Source used to monitor the host (0 - Zabbix server; 1 - Proxy; 2 - Proxy group)
+	MonitoredBy       FlexInt         `json:"monitored_by,omitempty"`       // Source used to monitor the host (0 - Zabbix server; 1 - Proxy; 2 - Proxy group)
 	ProxyID           string          `json:"proxyid,omitempty"`            // ID of the proxy monitoring the host (required if 'monitored_by' is set to Proxy)
 	ProxyGroupID      string          `json:"proxy_groupid,omitempty"`      // ID of the proxy group monitoring the host (required if 'monitored_by' is set to Proxy group)
 	Status            *int            `json:"status,omitempty"`             // Status and function of the host (0 - monitored; 1 - unmonitored)
-	TlsConnect        int             `json:"tls_connect,omitempty"`        // Connections to host (1 - No encryption; 2 - PSK; 4 - certificate)
-	TlsAccept         int             `json:"tls_accept,omitempty"`         // Connections from host (bitmask: 1 - No encryption; 2 - PSK; 4 - certificate)
+	TlsConnect        FlexInt         `json:"tls_connect,omitempty"`        // Connections to host (1 - No encryption; 2 - PSK; 4 - certificate)
+	TlsAccept         FlexInt         `json:"tls_accept,omitempty"`         // Connections from host (bitmask: 1 - No encryption; 2 - PSK; 4 - certificate)
 	TlsIssuer         string          `json:"tls_issuer,omitempty"`         // Certificate issuer
 	TlsSubject        string          `json:"tls_subject,omitempty"`        // Certificate subject
 	TlsPSKIdentity    string          `json:"tls_psk_identity,omitempty"`   // PSK identity (write-only; required if 'tls_connect' is PSK or 'tls_accept' includes PSK)
 	TlsPSK            string          `json:"tls_psk,omitempty"`            // Pre-shared key (PSK) (write-only; required if 'tls_connect' is PSK or 'tls_accept' includes PSK)
-	ActiveAvailable   int             `json:"active_available,omitempty"`   // Host active interface availability status (0 - unknown; 1 - available; 2 - not available) (read-only)
+	ActiveAvailable   FlexInt         `json:"active_available,omitempty"`   // Host active interface availability status (0 - unknown; 1 - available; 2 - not available) (read-only)
 	AssignedProxyID   string          `json:"assigned_proxyid,omitempty"`   // ID of the proxy assigned by Zabbix server if monitored by a proxy group (read-only)
 	Interfaces        []HostInterface `json:"interfaces,omitempty"`         // Interfaces associated with the host
 	Groups            []HostGroup     `json:"groups,omitempty"`             // Host groups to which the host belongs
@@ -36,6 +39,29 @@ type Host struct {
 	Templates         []Template      `json:"templates,omitempty"`          // Templates linked to the host
 	Macros            []Macro         `json:"macros,omitempty"`             // User macros created for the host
 	Inventory         *Inventory      `json:"inventory,omitempty"`          // Inventory properties of the host
+	HostGroups        []HostGroup     `json:"hostgroups,omitempty"`         // Host groups of the host, returned by selectHostGroups (read-only)
+	ParentTemplates   []Template      `json:"parentTemplates,omitempty"`    // Templates linked to the host, returned by selectParentTemplates (read-only)
+}
+
+// UnmarshalJSON decodes the string-encoded status returned by host.get into
+// Status, which stays an *int so it can be set to 0 on update.
+func (h *Host) UnmarshalJSON(data []byte) error {
+	type host Host
+
+	aux := struct {
+		*host
+		Status *FlexInt `json:"status,omitempty"`
+	}{host: (*host)(h)}
+
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+
+	if aux.Status != nil {
+		status := int(*aux.Status)
+		h.Status = &status
+	}
+	return nil
 }
 
 type HostGetParameters struct {
@@ -66,6 +92,7 @@ type HostGetParameters struct {
 	WithSimpleGraphItems   bool              `json:"with_simple_graph_items,omitempty"`
 	WithTriggers           bool              `json:"with_triggers,omitempty"`
 	SelectGroups           any               `json:"selectGroups,omitempty"`
+	SelectHostGroups       any               `json:"selectHostGroups,omitempty"`
 	SelectApplications     any               `json:"selectApplications,omitempty"`
 	SelectDiscoveries      any               `json:"selectDiscoveries,omitempty"`
 	SelectDiscoveryRule    any               `json:"selectDiscoveryRule,omitempty"`
@@ -77,6 +104,7 @@ type HostGetParameters struct {
 	SelectMacros           any               `json:"selectMacros,omitempty"`
 	SelectParentTemplates  any               `json:"selectParentTemplates,omitempty"`
 	SelectScreens          any               `json:"selectScreens,omitempty"`
+	SelectTags             any               `json:"selectTags,omitempty"`
 	LimitSelects           int               `json:"limitSelects,omitempty"`
 	SearchInventory        map[string]string `json:"searchInventory,omitempty"`
 }
@@ -107,6 +135,12 @@ type HostMassAddResponse struct {
 
 func (z *zabbixClient) HostCreate(ctx context.Context, params []Host) (*HostCreateResponse, error) {
 
+	if z.validation {
+		if err := validateHosts(params); err != nil {
+			return nil, err
+		}
+	}
+
 	var result HostCreateResponse
 
 	err := z.makeRequest(ctx, "host.create", params, &result)
@@ -129,11 +163,22 @@ func (z *zabbixClient) HostDelete(ctx context.Context, params []string) (*HostDe
 	return &result, nil
 }
 
-func (z *zabbixClient) HostUpdate(ctx context.Context, params Host) (*HostUpdateResponse, error) {
+func (z *zabbixClient) HostUpdate(ctx context.Context, params Host, fields ...string) (*HostUpdateResponse, error) {
+
+	if z.validation {
+		if err := params.ValidateUpdate(); err != nil {
+			return nil, err
+		}
+	}
 
 	var result HostUpdateResponse
 
-	err := z.makeRequest(ctx, "host.update", params, &result)
+	update, err := withFields(params, fields)
+	if err != nil {
+		return nil, err
+	}
+
+	err = z.makeRequest(ctx, "host.update", update, &result)
 	if err != nil {
 		return nil, err
 	}
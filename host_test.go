@@ -479,3 +479,100 @@ func TestHostCreateAndMassAdd(t *testing.T) {
 		t.Fail()
 	}
 }
+
+func TestHostUpdateFields(t *testing.T) {
+	ctx := context.Background()
+
+	client, err := zabbix.NewClient(url, zabbix.WithUserPass(user, passwd))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Authenticate
+	if err := client.Authenticate(); err != nil {
+		t.Fatal("Initial auth failed:", err)
+	}
+
+	createResp, err := client.HostCreate(ctx, []zabbix.Host{{
+		Host:          "test-host-fields",
+		Description:   "Test host",
+		InventoryMode: zabbix.InventoryAuto,
+		Groups:        []zabbix.HostGroup{{GroupID: "2"}},
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	hostID := createResp.HostIDs[0]
+	defer client.HostDelete(ctx, []string{hostID})
+
+	// Without fields, the empty description and manual inventory mode are not sent
+	update := zabbix.Host{HostID: hostID, InventoryMode: zabbix.InventoryManual}
+	if _, err := client.HostUpdate(ctx, update, "description", "inventory_mode"); err != nil {
+		t.Fatal(err)
+	}
+
+	hosts, err := client.HostGet(ctx, zabbix.HostGetParameters{HostIDs: []string{hostID}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(hosts) != 1 || hosts[0].Description != "" || hosts[0].InventoryMode != zabbix.InventoryManual {
+		t.Errorf("host not updated: %+v", hosts)
+	}
+
+	if _, err := client.HostUpdate(ctx, update, "inventorymode"); err == nil || err.Error() != `unknown field "inventorymode" of Host` {
+		t.Errorf("got error %v for an unknown field", err)
+	}
+}
+
+func TestHostUpdateClearInventory(t *testing.T) {
+	ctx := context.Background()
+
+	client, err := zabbix.NewClient(url, zabbix.WithUserPass(user, passwd))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Authenticate
+	if err := client.Authenticate(); err != nil {
+		t.Fatal("Initial auth failed:", err)
+	}
+
+	createResp, err := client.HostCreate(ctx, []zabbix.Host{{
+		Host:          "test-host-clear-inventory",
+		InventoryMode: zabbix.InventoryAuto,
+		Inventory:     &zabbix.Inventory{OS: "Linux", Notes: "Decommission in Q3"},
+		Groups:        []zabbix.HostGroup{{GroupID: "2"}},
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	hostID := createResp.HostIDs[0]
+	defer client.HostDelete(ctx, []string{hostID})
+
+	// The empty notes are sent through the pointer to the inventory
+	update := zabbix.Host{HostID: hostID, Inventory: &zabbix.Inventory{}}
+	if _, err := client.HostUpdate(ctx, update, "inventory.notes"); err != nil {
+		t.Fatal(err)
+	}
+
+	hosts, err := client.HostGet(ctx, zabbix.HostGetParameters{HostIDs: []string{hostID}, SelectInventory: "extend"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(hosts) != 1 || hosts[0].Inventory == nil || hosts[0].Inventory.Notes != "" || hosts[0].Inventory.OS != "Linux" {
+		t.Errorf("inventory not updated: %+v", hosts)
+	}
+
+	search := map[string]string{zabbix.InventoryFieldOS: "Linux"}
+	hosts, err = client.HostGet(ctx, zabbix.HostGetParameters{HostIDs: []string{hostID}, SearchInventory: search})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(hosts) != 1 {
+		t.Errorf("host not found by inventory: %+v", hosts)
+	}
+
+	if _, err := client.HostUpdate(ctx, zabbix.Host{HostID: hostID}, "inventory.notes"); err == nil || err.Error() != `field "inventory" of Host is nil` {
+		t.Errorf("got error %v for a path through a nil inventory", err)
+	}
+}
@@ -4,10 +4,10 @@ import "context"
 
 // Hostgroup represents a host group in Zabbix.
 type HostGroup struct {
-	GroupID string `json:"groupid,omitempty"` // ID of the host group; read-only, required for update operations
-	Name    string `json:"name,omitempty"`    // Name of the host group; required for create operations
-	Flags   int    `json:"flags,omitempty"`   // Origin of the host group; read-only
-	UUID    string `json:"uuid,omitempty"`    // Universal unique identifier; auto-generated if not provided
+	GroupID string  `json:"groupid,omitempty"` // ID of the host group; read-only, required for update operations
+	Name    string  `json:"name,omitempty"`    // Name of the host group; required for create operations
+	Flags   FlexInt `json:"flags,omitempty"`   // Origin of the host group; read-only
+	UUID    string  `json:"uuid,omitempty"`    // Universal unique identifier; auto-generated if not provided
 }
 
 type HostGroupGetParameters struct {
@@ -50,3 +50,35 @@ func (z *zabbixClient) HostgroupGet(ctx context.Context, params HostGroupGetPara
 
 	return result, nil
 }
+
+type HostGroupCreateResponse struct {
+	GroupIDs []string `json:"groupids"` // IDs of the created host groups
+}
+
+func (z *zabbixClient) HostgroupCreate(ctx context.Context, params []HostGroup) (*HostGroupCreateResponse, error) {
+
+	var result HostGroupCreateResponse
+
+	err := z.makeRequest(ctx, "hostgroup.create", params, &result)
+	if err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+type HostGroupDeleteResponse struct {
+	GroupIDs []string `json:"groupids"` // IDs of the deleted host groups
+}
+
+func (z *zabbixClient) HostgroupDelete(ctx context.Context, params []string) (*HostGroupDeleteResponse, error) {
+
+	var result HostGroupDeleteResponse
+
+	err := z.makeRequest(ctx, "hostgroup.delete", params, &result)
+	if err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
@@ -42,3 +42,32 @@ func TestHostgroupGet(t *testing.T) {
 	}
 
 }
+
+func TestHostgroupCreateAndDelete(t *testing.T) {
+	ctx := context.Background()
+
+	client, err := zabbix.NewClient(url, zabbix.WithUserPass(user, passwd))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Authenticate
+	if err := client.Authenticate(); err != nil {
+		t.Fatal("Initial auth failed:", err)
+	}
+
+	createResp, err := client.HostgroupCreate(ctx, []zabbix.HostGroup{{Name: "my-hostgroup"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	deleteResp, err := client.HostgroupDelete(ctx, createResp.GroupIDs)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if deleteResp.GroupIDs[0] != createResp.GroupIDs[0] {
+		t.Fatal("hostgroup id mismatch")
+	}
+
+}
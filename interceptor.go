@@ -0,0 +1,58 @@
+package zabbix
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+)
+
+// Call is an API call as seen by interceptors. Interceptors may change the
+// request fields before calling the next Invoker; the response fields are
+// set once it returns. The result is decoded as the response is read, so
+// changing Response after next returns doesn't change it.
+type Call struct {
+	Method string      // JSON-RPC method, e.g. "host.get"
+	Params any         // Params of the method; may be replaced
+	Header http.Header // Extra HTTP headers sent with the request
+
+	// KeepResponse is set by interceptors that read Response, before calling
+	// next. Responses are otherwise decoded without being buffered.
+	KeepResponse bool
+
+	Response json.RawMessage // Raw JSON-RPC response body; only set if KeepResponse is
+	Duration time.Duration   // Time spent sending the request and reading the response, excluding limit waits
+
+	result  any  // Where the result of the response is decoded
+	decoded bool // Whether send decoded the response into result
+}
+
+// Invoker sends a call and sets its response fields. It returns the
+// transport error or the *APIError of the response, if any.
+type Invoker func(ctx context.Context, call *Call) error
+
+// Interceptor wraps every API call of a client, including the logins of
+// Authenticate. It calls next to send the call, possibly after changing it,
+// and sees the response and error next returns. An interceptor may instead
+// short-circuit the call by setting call.Response to a JSON-RPC response
+// body and returning without calling next.
+type Interceptor func(ctx context.Context, call *Call, next Invoker) error
+
+// WithInterceptors adds interceptors around every API call. The first
+// interceptor is the outermost: it sees the call first and its result last.
+func WithInterceptors(interceptors ...Interceptor) ClientOption {
+	return func(c *zabbixClient) {
+		c.interceptors = append(c.interceptors, interceptors...)
+	}
+}
+
+// chain returns invoker wrapped in interceptors, the first one outermost.
+func chain(interceptors []Interceptor, invoker Invoker) Invoker {
+	for i := len(interceptors) - 1; i >= 0; i-- {
+		interceptor, next := interceptors[i], invoker
+		invoker = func(ctx context.Context, call *Call) error {
+			return interceptor(ctx, call, next)
+		}
+	}
+	return invoker
+}
@@ -0,0 +1,141 @@
+package zabbix_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+
+	zabbix "github.com/nimok/nim-go-zabbix"
+)
+
+// headerTransport records the headers of the last request.
+type headerTransport struct {
+	header http.Header
+}
+
+func (h *headerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	h.header = req.Header.Clone()
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+func TestInterceptors(t *testing.T) {
+	ctx := context.Background()
+	transport := &headerTransport{}
+
+	var order []string
+	var seen []zabbix.Call
+	client, err := zabbix.NewClient(url, zabbix.WithUserPass(user, passwd),
+		zabbix.WithHTTPClient(&http.Client{Transport: transport}),
+		zabbix.WithInterceptors(
+			func(ctx context.Context, call *zabbix.Call, next zabbix.Invoker) error {
+				order = append(order, "outer")
+				call.KeepResponse = true
+				err := next(ctx, call)
+				seen = append(seen, *call)
+				return err
+			},
+			func(ctx context.Context, call *zabbix.Call, next zabbix.Invoker) error {
+				order = append(order, "inner")
+				call.Header.Set("X-Request-Source", "test")
+				if params, ok := call.Params.(zabbix.HostGroupGetParameters); ok {
+					params.Filter = map[string]any{"name": "Zabbix servers"}
+					call.Params = params
+				}
+				return next(ctx, call)
+			},
+		))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Authenticate
+	if err := client.Authenticate(); err != nil {
+		t.Fatal("Initial auth failed:", err)
+	}
+
+	groups, err := client.HostgroupGet(ctx, zabbix.HostGroupGetParameters{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(groups) != 1 || groups[0].Name != "Zabbix servers" {
+		t.Errorf("params were not replaced: got %+v", groups)
+	}
+	if transport.header.Get("X-Request-Source") != "test" {
+		t.Error("header was not sent")
+	}
+	if len(order) != 4 || order[0] != "outer" || order[1] != "inner" {
+		t.Errorf("unexpected interceptor order %v", order)
+	}
+	if len(seen) != 2 || seen[0].Method != "user.login" || seen[1].Method != "hostgroup.get" {
+		t.Fatalf("unexpected calls %+v", seen)
+	}
+	if len(seen[1].Response) == 0 || seen[1].Duration <= 0 {
+		t.Errorf("response fields not set: %+v", seen[1])
+	}
+}
+
+func TestInterceptorShortCircuit(t *testing.T) {
+	ctx := context.Background()
+	transport := &countingTransport{}
+
+	client, err := zabbix.NewClient(url, zabbix.WithUserPass(user, passwd),
+		zabbix.WithHTTPClient(&http.Client{Transport: transport}),
+		zabbix.WithInterceptors(func(ctx context.Context, call *zabbix.Call, next zabbix.Invoker) error {
+			if call.Method != "hostgroup.get" {
+				return next(ctx, call)
+			}
+			call.Response = json.RawMessage(`{"jsonrpc":"2.0","id":1,"result":[{"groupid":"42","name":"Canned"}]}`)
+			return nil
+		}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Authenticate
+	if err := client.Authenticate(); err != nil {
+		t.Fatal("Initial auth failed:", err)
+	}
+
+	groups, err := client.HostgroupGet(ctx, zabbix.HostGroupGetParameters{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(groups) != 1 || groups[0].GroupID != "42" {
+		t.Errorf("unexpected groups %+v", groups)
+	}
+	if n := transport.count.Load(); n != 1 {
+		t.Errorf("got %d requests, want only the login", n)
+	}
+}
+
+func TestInterceptorSeesAPIError(t *testing.T) {
+	ctx := context.Background()
+
+	var seen error
+	client, err := zabbix.NewClient(url, zabbix.WithUserPass(user, passwd),
+		zabbix.WithInterceptors(func(ctx context.Context, call *zabbix.Call, next zabbix.Invoker) error {
+			seen = next(ctx, call)
+			return seen
+		}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Authenticate
+	if err := client.Authenticate(); err != nil {
+		t.Fatal("Initial auth failed:", err)
+	}
+
+	_, err = client.HostDelete(ctx, []string{"999999999"})
+
+	var apiErr *zabbix.APIError
+	if !errors.As(err, &apiErr) || !errors.As(seen, &apiErr) {
+		t.Fatalf("got %v, and %v in the interceptor; want an APIError", err, seen)
+	}
+	if apiErr.Code != -32602 {
+		t.Errorf("unexpected API error %+v", apiErr)
+	}
+}
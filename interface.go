@@ -1,6 +1,9 @@
 package zabbix
 
-import "context"
+import (
+	"context"
+	"encoding/json"
+)
 
 // InterfaceType represents the type of interface.
 type InterfaceType int
@@ -87,6 +90,19 @@ const (
 	PrivProtocolAES256C PrivProtocol = 5
 )
 
+// Zabbix returns the enum values below as strings, so they all accept
+// string-encoded numbers when decoding.
+
+func (t *InterfaceType) UnmarshalJSON(data []byte) error  { return unmarshalInt(data, t) }
+func (o *UseIPOption) UnmarshalJSON(data []byte) error    { return unmarshalInt(data, o) }
+func (m *MainInterface) UnmarshalJSON(data []byte) error  { return unmarshalInt(data, m) }
+func (s *InterfaceState) UnmarshalJSON(data []byte) error { return unmarshalInt(data, s) }
+func (v *SNMPVersion) UnmarshalJSON(data []byte) error    { return unmarshalInt(data, v) }
+func (b *BulkSetting) UnmarshalJSON(data []byte) error    { return unmarshalInt(data, b) }
+func (l *SecurityLevel) UnmarshalJSON(data []byte) error  { return unmarshalInt(data, l) }
+func (p *AuthProtocol) UnmarshalJSON(data []byte) error   { return unmarshalInt(data, p) }
+func (p *PrivProtocol) UnmarshalJSON(data []byte) error   { return unmarshalInt(data, p) }
+
 // HostInterface represents a host interface in Zabbix.
 type HostInterface struct {
 	InterfaceID  string           `json:"interfaceid,omitempty"`   // Read-only; required for update operations
@@ -98,9 +114,9 @@ type HostInterface struct {
 	UseIP        UseIPOption      `json:"useip"`                   // Required for create operations
 	Main         MainInterface    `json:"main"`                    // Required for create operations
 	Available    InterfaceState   `json:"available,omitempty"`     // Read-only
-	DisableUntil int64            `json:"disable_until,omitempty"` // Read-only; timestamp
+	DisableUntil FlexInt64        `json:"disable_until,omitempty"` // Read-only; timestamp
 	Error        string           `json:"error,omitempty"`         // Read-only
-	ErrorsFrom   int64            `json:"errors_from,omitempty"`   // Read-only; timestamp
+	ErrorsFrom   FlexInt64        `json:"errors_from,omitempty"`   // Read-only; timestamp
 	Details      InterfaceDetails `json:"details,omitempty"`       // Required if Type is SNMP
 }
 
@@ -109,7 +125,7 @@ type InterfaceDetails struct {
 	Version        SNMPVersion   `json:"version"`                   // Required
 	Bulk           BulkSetting   `json:"bulk,omitempty"`            // Optional
 	Community      string        `json:"community,omitempty"`       // Required if Version is SNMPv1 or SNMPv2c
-	MaxRepetitions int           `json:"max_repetitions,omitempty"` // Default: 10
+	MaxRepetitions FlexInt       `json:"max_repetitions,omitempty"` // Default: 10
 	SecurityName   string        `json:"securityname,omitempty"`    // SNMPv3 only
 	SecurityLevel  SecurityLevel `json:"securitylevel,omitempty"`   // SNMPv3 only
 	AuthPassphrase string        `json:"authpassphrase,omitempty"`  // SNMPv3 only
@@ -119,6 +135,18 @@ type InterfaceDetails struct {
 	ContextName    string        `json:"contextname,omitempty"`     // SNMPv3 only
 }
 
+// UnmarshalJSON accepts the empty array Zabbix returns as details of
+// non-SNMP interfaces.
+func (d *InterfaceDetails) UnmarshalJSON(data []byte) error {
+	if isEmptyArray(data) {
+		*d = InterfaceDetails{}
+		return nil
+	}
+
+	type interfaceDetails InterfaceDetails
+	return json.Unmarshal(data, (*interfaceDetails)(d))
+}
+
 type HostInterfaceGetParams struct {
 	GetParameters
 
@@ -154,6 +182,12 @@ func (z *zabbixClient) HostInterfaceGet(ctx context.Context, params HostInterfac
 
 func (z *zabbixClient) HostInterfaceCreate(ctx context.Context, params HostInterface) (*HostInterfaceCreateResponse, error) {
 
+	if z.validation {
+		if err := params.Validate(); err != nil {
+			return nil, err
+		}
+	}
+
 	var result HostInterfaceCreateResponse
 
 	err := z.makeRequest(ctx, "hostinterface.create", params, &result)
@@ -164,11 +198,16 @@ func (z *zabbixClient) HostInterfaceCreate(ctx context.Context, params HostInter
 	return &result, nil
 }
 
-func (z *zabbixClient) HostInterfaceUpdate(ctx context.Context, params HostInterface) (*HostInterfaceUpdateResponse, error) {
+func (z *zabbixClient) HostInterfaceUpdate(ctx context.Context, params HostInterface, fields ...string) (*HostInterfaceUpdateResponse, error) {
 
 	var result HostInterfaceUpdateResponse
 
-	err := z.makeRequest(ctx, "hostinterface.update", params, &result)
+	update, err := withFields(params, fields)
+	if err != nil {
+		return nil, err
+	}
+
+	err = z.makeRequest(ctx, "hostinterface.update", update, &result)
 	if err != nil {
 		return nil, err
 	}
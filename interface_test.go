@@ -91,3 +91,83 @@ func TestHostInterfaceCreateAndDelete(t *testing.T) {
 	}
 
 }
+
+func TestHostInterfaceUpdateDetailsFields(t *testing.T) {
+	ctx := context.Background()
+
+	client, err := zabbix.NewClient(url, zabbix.WithUserPass(user, passwd))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Authenticate
+	if err := client.Authenticate(); err != nil {
+		t.Fatal("Initial auth failed:", err)
+	}
+
+	hostResp, err := client.HostCreate(ctx, []zabbix.Host{{
+		Host:   "test-host-snmpv3",
+		Groups: []zabbix.HostGroup{{GroupID: "2"}},
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.HostDelete(ctx, hostResp.HostIDs)
+
+	createResp, err := client.HostInterfaceCreate(ctx, zabbix.HostInterface{
+		HostID: hostResp.HostIDs[0],
+		Type:   zabbix.InterfaceTypeSNMP,
+		Main:   zabbix.MainInterfaceYes,
+		UseIP:  zabbix.UseIPOptionIP,
+		IP:     "127.0.0.1",
+		Port:   "161",
+		Details: zabbix.InterfaceDetails{
+			Version:        zabbix.SNMPv3,
+			Bulk:           zabbix.BulkEnabled,
+			SecurityName:   "monitoring",
+			SecurityLevel:  zabbix.SecurityLevelAuthPriv,
+			AuthProtocol:   zabbix.AuthProtocolSHA256,
+			AuthPassphrase: "authsecret",
+			PrivProtocol:   zabbix.PrivProtocolAES256,
+			PrivPassphrase: "privsecret",
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	getParams := zabbix.HostInterfaceGetParams{InterfaceIDs: createResp.HostInterfaceIDs}
+	ifaces, err := client.HostInterfaceGet(ctx, getParams)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ifaces) != 1 {
+		t.Fatalf("got %d interfaces, want 1", len(ifaces))
+	}
+
+	// Back to no bulk requests and no security, the zero values
+	update := ifaces[0]
+	update.Details = zabbix.InterfaceDetails{
+		Version:      zabbix.SNMPv3,
+		SecurityName: "monitoring",
+	}
+	if _, err := client.HostInterfaceUpdate(ctx, update, "details.bulk", "details.securitylevel", "details.authprotocol", "details.privprotocol"); err != nil {
+		t.Fatal(err)
+	}
+
+	if ifaces, err = client.HostInterfaceGet(ctx, getParams); err != nil {
+		t.Fatal(err)
+	}
+	details := ifaces[0].Details
+	if details.Bulk != zabbix.BulkDisabled || details.SecurityLevel != zabbix.SecurityLevelNoAuthNoPriv ||
+		details.AuthProtocol != zabbix.AuthProtocolMD5 || details.PrivProtocol != zabbix.PrivProtocolDES {
+		t.Errorf("details not updated: %+v", details)
+	}
+
+	if _, err := client.HostInterfaceUpdate(ctx, update, "details.bulkmode"); err == nil || err.Error() != `unknown field "bulkmode" of InterfaceDetails` {
+		t.Errorf("got error %v for an unknown field", err)
+	}
+	if _, err := client.HostInterfaceUpdate(ctx, update, "port.number"); err == nil || err.Error() != `unknown field "port.number" of HostInterface` {
+		t.Errorf("got error %v for a path through a non-object field", err)
+	}
+}
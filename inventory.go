@@ -1,8 +1,249 @@
 package zabbix
 
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+)
+
 // Inventory represents the host inventory properties in Zabbix.
 type Inventory struct {
-	MacAddressA string `json:"macaddress_a,omitempty"`
-	MacAddressB string `json:"macaddress_b,omitempty"`
-	// Add other inventory fields as needed
+	Type             string `json:"type,omitempty"`              // Type
+	TypeFull         string `json:"type_full,omitempty"`         // Type (Full details)
+	Name             string `json:"name,omitempty"`              // Name
+	Alias            string `json:"alias,omitempty"`             // Alias
+	OS               string `json:"os,omitempty"`                // OS
+	OSFull           string `json:"os_full,omitempty"`           // OS (Full details)
+	OSShort          string `json:"os_short,omitempty"`          // OS (Short)
+	SerialNoA        string `json:"serialno_a,omitempty"`        // Serial number A
+	SerialNoB        string `json:"serialno_b,omitempty"`        // Serial number B
+	Tag              string `json:"tag,omitempty"`               // Tag
+	AssetTag         string `json:"asset_tag,omitempty"`         // Asset tag
+	MacAddressA      string `json:"macaddress_a,omitempty"`      // MAC address A
+	MacAddressB      string `json:"macaddress_b,omitempty"`      // MAC address B
+	Hardware         string `json:"hardware,omitempty"`          // Hardware
+	HardwareFull     string `json:"hardware_full,omitempty"`     // Hardware (Full details)
+	Software         string `json:"software,omitempty"`          // Software
+	SoftwareFull     string `json:"software_full,omitempty"`     // Software (Full details)
+	SoftwareAppA     string `json:"software_app_a,omitempty"`    // Software application A
+	SoftwareAppB     string `json:"software_app_b,omitempty"`    // Software application B
+	SoftwareAppC     string `json:"software_app_c,omitempty"`    // Software application C
+	SoftwareAppD     string `json:"software_app_d,omitempty"`    // Software application D
+	SoftwareAppE     string `json:"software_app_e,omitempty"`    // Software application E
+	Contact          string `json:"contact,omitempty"`           // Contact
+	Location         string `json:"location,omitempty"`          // Location
+	LocationLat      string `json:"location_lat,omitempty"`      // Location latitude
+	LocationLon      string `json:"location_lon,omitempty"`      // Location longitude
+	Notes            string `json:"notes,omitempty"`             // Notes
+	Chassis          string `json:"chassis,omitempty"`           // Chassis
+	Model            string `json:"model,omitempty"`             // Model
+	HWArch           string `json:"hw_arch,omitempty"`           // HW architecture
+	Vendor           string `json:"vendor,omitempty"`            // Vendor
+	ContractNumber   string `json:"contract_number,omitempty"`   // Contract number
+	InstallerName    string `json:"installer_name,omitempty"`    // Installer name
+	DeploymentStatus string `json:"deployment_status,omitempty"` // Deployment status
+	URLA             string `json:"url_a,omitempty"`             // URL A
+	URLB             string `json:"url_b,omitempty"`             // URL B
+	URLC             string `json:"url_c,omitempty"`             // URL C
+	HostNetworks     string `json:"host_networks,omitempty"`     // Host networks
+	HostNetmask      string `json:"host_netmask,omitempty"`      // Host subnet mask
+	HostRouter       string `json:"host_router,omitempty"`       // Host router
+	OOBIP            string `json:"oob_ip,omitempty"`            // OOB IP address
+	OOBNetmask       string `json:"oob_netmask,omitempty"`       // OOB subnet mask
+	OOBRouter        string `json:"oob_router,omitempty"`        // OOB router
+	DateHWPurchase   string `json:"date_hw_purchase,omitempty"`  // Date HW purchased
+	DateHWInstall    string `json:"date_hw_install,omitempty"`   // Date HW installed
+	DateHWExpiry     string `json:"date_hw_expiry,omitempty"`    // Date HW maintenance expires
+	DateHWDecomm     string `json:"date_hw_decomm,omitempty"`    // Date HW decommissioned
+	SiteAddressA     string `json:"site_address_a,omitempty"`    // Site address A
+	SiteAddressB     string `json:"site_address_b,omitempty"`    // Site address B
+	SiteAddressC     string `json:"site_address_c,omitempty"`    // Site address C
+	SiteCity         string `json:"site_city,omitempty"`         // Site city
+	SiteState        string `json:"site_state,omitempty"`        // Site state / province
+	SiteCountry      string `json:"site_country,omitempty"`      // Site country
+	SiteZip          string `json:"site_zip,omitempty"`          // Site ZIP / postal
+	SiteRack         string `json:"site_rack,omitempty"`         // Site rack location
+	SiteNotes        string `json:"site_notes,omitempty"`        // Site notes
+	POC1Name         string `json:"poc_1_name,omitempty"`        // Primary POC name
+	POC1Email        string `json:"poc_1_email,omitempty"`       // Primary POC email
+	POC1PhoneA       string `json:"poc_1_phone_a,omitempty"`     // Primary POC phone A
+	POC1PhoneB       string `json:"poc_1_phone_b,omitempty"`     // Primary POC phone B
+	POC1Cell         string `json:"poc_1_cell,omitempty"`        // Primary POC cell
+	POC1Screen       string `json:"poc_1_screen,omitempty"`      // Primary POC screen name
+	POC1Notes        string `json:"poc_1_notes,omitempty"`       // Primary POC notes
+	POC2Name         string `json:"poc_2_name,omitempty"`        // Secondary POC name
+	POC2Email        string `json:"poc_2_email,omitempty"`       // Secondary POC email
+	POC2PhoneA       string `json:"poc_2_phone_a,omitempty"`     // Secondary POC phone A
+	POC2PhoneB       string `json:"poc_2_phone_b,omitempty"`     // Secondary POC phone B
+	POC2Cell         string `json:"poc_2_cell,omitempty"`        // Secondary POC cell
+	POC2Screen       string `json:"poc_2_screen,omitempty"`      // Secondary POC screen name
+	POC2Notes        string `json:"poc_2_notes,omitempty"`       // Secondary POC notes
+}
+
+// InventoryField is the name of a host inventory property, as used in the
+// keys of HostGetParameters.SearchInventory and Inventory.ToMap.
+type InventoryField string
+
+// The inventory fields. They are untyped so that they can also be used as
+// string keys, e.g. in HostGetParameters.SearchInventory.
+const (
+	InventoryFieldType             = "type"
+	InventoryFieldTypeFull         = "type_full"
+	InventoryFieldName             = "name"
+	InventoryFieldAlias            = "alias"
+	InventoryFieldOS               = "os"
+	InventoryFieldOSFull           = "os_full"
+	InventoryFieldOSShort          = "os_short"
+	InventoryFieldSerialNoA        = "serialno_a"
+	InventoryFieldSerialNoB        = "serialno_b"
+	InventoryFieldTag              = "tag"
+	InventoryFieldAssetTag         = "asset_tag"
+	InventoryFieldMacAddressA      = "macaddress_a"
+	InventoryFieldMacAddressB      = "macaddress_b"
+	InventoryFieldHardware         = "hardware"
+	InventoryFieldHardwareFull     = "hardware_full"
+	InventoryFieldSoftware         = "software"
+	InventoryFieldSoftwareFull     = "software_full"
+	InventoryFieldSoftwareAppA     = "software_app_a"
+	InventoryFieldSoftwareAppB     = "software_app_b"
+	InventoryFieldSoftwareAppC     = "software_app_c"
+	InventoryFieldSoftwareAppD     = "software_app_d"
+	InventoryFieldSoftwareAppE     = "software_app_e"
+	InventoryFieldContact          = "contact"
+	InventoryFieldLocation         = "location"
+	InventoryFieldLocationLat      = "location_lat"
+	InventoryFieldLocationLon      = "location_lon"
+	InventoryFieldNotes            = "notes"
+	InventoryFieldChassis          = "chassis"
+	InventoryFieldModel            = "model"
+	InventoryFieldHWArch           = "hw_arch"
+	InventoryFieldVendor           = "vendor"
+	InventoryFieldContractNumber   = "contract_number"
+	InventoryFieldInstallerName    = "installer_name"
+	InventoryFieldDeploymentStatus = "deployment_status"
+	InventoryFieldURLA             = "url_a"
+	InventoryFieldURLB             = "url_b"
+	InventoryFieldURLC             = "url_c"
+	InventoryFieldHostNetworks     = "host_networks"
+	InventoryFieldHostNetmask      = "host_netmask"
+	InventoryFieldHostRouter       = "host_router"
+	InventoryFieldOOBIP            = "oob_ip"
+	InventoryFieldOOBNetmask       = "oob_netmask"
+	InventoryFieldOOBRouter        = "oob_router"
+	InventoryFieldDateHWPurchase   = "date_hw_purchase"
+	InventoryFieldDateHWInstall    = "date_hw_install"
+	InventoryFieldDateHWExpiry     = "date_hw_expiry"
+	InventoryFieldDateHWDecomm     = "date_hw_decomm"
+	InventoryFieldSiteAddressA     = "site_address_a"
+	InventoryFieldSiteAddressB     = "site_address_b"
+	InventoryFieldSiteAddressC     = "site_address_c"
+	InventoryFieldSiteCity         = "site_city"
+	InventoryFieldSiteState        = "site_state"
+	InventoryFieldSiteCountry      = "site_country"
+	InventoryFieldSiteZip          = "site_zip"
+	InventoryFieldSiteRack         = "site_rack"
+	InventoryFieldSiteNotes        = "site_notes"
+	InventoryFieldPOC1Name         = "poc_1_name"
+	InventoryFieldPOC1Email        = "poc_1_email"
+	InventoryFieldPOC1PhoneA       = "poc_1_phone_a"
+	InventoryFieldPOC1PhoneB       = "poc_1_phone_b"
+	InventoryFieldPOC1Cell         = "poc_1_cell"
+	InventoryFieldPOC1Screen       = "poc_1_screen"
+	InventoryFieldPOC1Notes        = "poc_1_notes"
+	InventoryFieldPOC2Name         = "poc_2_name"
+	InventoryFieldPOC2Email        = "poc_2_email"
+	InventoryFieldPOC2PhoneA       = "poc_2_phone_a"
+	InventoryFieldPOC2PhoneB       = "poc_2_phone_b"
+	InventoryFieldPOC2Cell         = "poc_2_cell"
+	InventoryFieldPOC2Screen       = "poc_2_screen"
+	InventoryFieldPOC2Notes        = "poc_2_notes"
+)
+
+// inventoryFields lists the inventory properties in declaration order and
+// inventoryFieldIndex maps them to the matching Inventory struct field.
+var inventoryFields, inventoryFieldIndex = func() ([]InventoryField, map[InventoryField]int) {
+	t := reflect.TypeOf(Inventory{})
+	fields := make([]InventoryField, t.NumField())
+	index := make(map[InventoryField]int, t.NumField())
+	for i := range fields {
+		name, _, _ := strings.Cut(t.Field(i).Tag.Get("json"), ",")
+		fields[i] = InventoryField(name)
+		index[fields[i]] = i
+	}
+	return fields, index
+}()
+
+// InventoryFields returns the names of all host inventory properties.
+func InventoryFields() []InventoryField {
+	return slices.Clone(inventoryFields)
+}
+
+// Valid reports whether f is the name of a host inventory property.
+func (f InventoryField) Valid() bool {
+	_, ok := inventoryFieldIndex[f]
+	return ok
+}
+
+// Get returns the value of the given inventory property.
+func (i *Inventory) Get(field InventoryField) string {
+	index, ok := inventoryFieldIndex[field]
+	if !ok {
+		return ""
+	}
+	return reflect.ValueOf(i).Elem().Field(index).String()
+}
+
+// Set sets the value of the given inventory property.
+func (i *Inventory) Set(field InventoryField, value string) error {
+	index, ok := inventoryFieldIndex[field]
+	if !ok {
+		return fmt.Errorf("unknown inventory field %q", field)
+	}
+	reflect.ValueOf(i).Elem().Field(index).SetString(value)
+	return nil
+}
+
+// ToMap returns the non-empty inventory properties keyed by field name.
+func (i Inventory) ToMap() map[string]string {
+	v := reflect.ValueOf(i)
+	m := make(map[string]string)
+	for n, field := range inventoryFields {
+		if value := v.Field(n).String(); value != "" {
+			m[string(field)] = value
+		}
+	}
+	return m
+}
+
+// InventoryFromMap builds an Inventory from properties keyed by field name,
+// as returned by ToMap. Unknown field names are reported in the error; all
+// known fields are set regardless.
+func InventoryFromMap(m map[string]string) (Inventory, error) {
+	var inventory Inventory
+	var unknown []string
+	for name, value := range m {
+		if err := inventory.Set(InventoryField(name), value); err != nil {
+			unknown = append(unknown, name)
+		}
+	}
+
+	if len(unknown) > 0 {
+		slices.Sort(unknown)
+		return inventory, fmt.Errorf("unknown inventory fields: %s", strings.Join(unknown, ", "))
+	}
+	return inventory, nil
+}
+
+// UnmarshalJSON accepts the empty array Zabbix returns for hosts with
+// inventory disabled.
+func (i *Inventory) UnmarshalJSON(data []byte) error {
+	if isEmptyArray(data) {
+		*i = Inventory{}
+		return nil
+	}
+
+	type inventory Inventory
+	return json.Unmarshal(data, (*inventory)(i))
 }
@@ -0,0 +1,40 @@
+package zabbix_test
+
+import (
+	"maps"
+	"testing"
+
+	zabbix "github.com/nimok/nim-go-zabbix"
+)
+
+func TestInventoryMapRoundTrip(t *testing.T) {
+	if n := len(zabbix.InventoryFields()); n != 70 {
+		t.Fatalf("expected 70 inventory fields, got %d", n)
+	}
+
+	cmdb := map[string]string{
+		zabbix.InventoryFieldSerialNoA:   "SN-1234",
+		zabbix.InventoryFieldSiteRack:    "R12",
+		zabbix.InventoryFieldPOC1Email:   "oncall@example.com",
+		zabbix.InventoryFieldMacAddressA: "00:11:22:33:44:55",
+	}
+
+	inventory, err := zabbix.InventoryFromMap(cmdb)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if inventory.SerialNoA != "SN-1234" || inventory.Get(zabbix.InventoryFieldSiteRack) != "R12" {
+		t.Fatalf("unexpected inventory %+v", inventory)
+	}
+	if !maps.Equal(inventory.ToMap(), cmdb) {
+		t.Fatalf("round trip mismatch: %v != %v", inventory.ToMap(), cmdb)
+	}
+
+	_, err = zabbix.InventoryFromMap(map[string]string{"serial": "SN-1234"})
+	if err == nil {
+		t.Fatal("expected an error for an unknown field")
+	}
+	if zabbix.InventoryField("serial").Valid() {
+		t.Fatal("serial should not be a valid inventory field")
+	}
+}
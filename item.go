@@ -0,0 +1,68 @@
+package zabbix
+
+import "context"
+
+// Item represents a Zabbix item object.
+type Item struct {
+	ItemID      string    `json:"itemid,omitempty"`      // ID of the item (read-only)
+	Type        FlexInt   `json:"type,omitempty"`        // Type of the item (0 - Zabbix agent; 2 - trapper; 3 - simple check; 7 - agent (active); 18 - dependent; 19 - HTTP agent; 20 - SNMP agent; ...)
+	HostID      string    `json:"hostid,omitempty"`      // ID of the host or template the item belongs to
+	InterfaceID string    `json:"interfaceid,omitempty"` // ID of the host interface used by the item
+	Name        string    `json:"name,omitempty"`        // Name of the item
+	Key         string    `json:"key_,omitempty"`        // Item key
+	Delay       string    `json:"delay,omitempty"`       // Update interval
+	History     string    `json:"history,omitempty"`     // How long history data should be stored
+	Trends      string    `json:"trends,omitempty"`      // How long trends data should be stored
+	ValueType   FlexInt   `json:"value_type,omitempty"`  // Type of information (0 - float; 1 - character; 2 - log; 3 - unsigned; 4 - text; 5 - binary)
+	Units       string    `json:"units,omitempty"`       // Value units
+	Status      FlexInt   `json:"status,omitempty"`      // Status of the item (0 - enabled; 1 - disabled)
+	State       FlexInt   `json:"state,omitempty"`       // State of the item (0 - normal; 1 - not supported) (read-only)
+	Error       string    `json:"error,omitempty"`       // Error text if there are problems updating the item (read-only)
+	Flags       FlexInt   `json:"flags,omitempty"`       // Origin of the item (0 - plain item; 4 - discovered item) (read-only)
+	TemplateID  string    `json:"templateid,omitempty"`  // ID of the parent template item (read-only)
+	SNMPOID     string    `json:"snmp_oid,omitempty"`    // SNMP OID
+	Description string    `json:"description,omitempty"` // Description of the item
+	LastClock   FlexInt64 `json:"lastclock,omitempty"`   // Time when the item was last updated (read-only)
+	LastValue   string    `json:"lastvalue,omitempty"`   // Last value of the item (read-only)
+	PrevValue   string    `json:"prevvalue,omitempty"`   // Previous value of the item (read-only)
+	Tags        []Tag     `json:"tags,omitempty"`        // Item tags
+}
+
+type ItemGetParameters struct {
+	GetParameters
+
+	ItemIDs             []string `json:"itemids,omitempty"`
+	GroupIDs            []string `json:"groupids,omitempty"`
+	TemplateIDs         []string `json:"templateids,omitempty"`
+	HostIDs             []string `json:"hostids,omitempty"`
+	ProxyIDs            []string `json:"proxyids,omitempty"`
+	InterfaceIDs        []string `json:"interfaceids,omitempty"`
+	GraphIDs            []string `json:"graphids,omitempty"`
+	TriggerIDs          []string `json:"triggerids,omitempty"`
+	WebItems            bool     `json:"webitems,omitempty"`
+	Inherited           *bool    `json:"inherited,omitempty"`
+	Templated           *bool    `json:"templated,omitempty"`
+	Monitored           bool     `json:"monitored,omitempty"`
+	Group               string   `json:"group,omitempty"`
+	Host                string   `json:"host,omitempty"`
+	WithTriggers        *bool    `json:"with_triggers,omitempty"`
+	EvalType            int      `json:"evaltype,omitempty"`
+	Tags                []Tag    `json:"tags,omitempty"`
+	SelectInterfaces    any      `json:"selectInterfaces,omitempty"`
+	SelectTags          any      `json:"selectTags,omitempty"`
+	SelectValueMap      any      `json:"selectValueMap,omitempty"`
+	SelectDiscoveryRule any      `json:"selectDiscoveryRule,omitempty"`
+	LimitSelects        int      `json:"limitSelects,omitempty"`
+}
+
+func (z *zabbixClient) ItemGet(ctx context.Context, params ItemGetParameters) ([]Item, error) {
+
+	var result []Item
+
+	err := z.makeRequest(ctx, "item.get", params, &result)
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
@@ -0,0 +1,236 @@
+package zabbix
+
+import (
+	"context"
+	"iter"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// DefaultPageSize is the number of objects requested per call by the *All
+// iterators when the Limit of the given parameters is 0.
+const DefaultPageSize = 1000
+
+// The *All iterators below fetch large result sets page by page instead of in
+// one giant response. The Limit of the given parameters is used as the page
+// size; every other parameter is passed through unchanged.
+//
+// Zabbix get methods have no cursor and their filters only match exact
+// values, so hosts, templates and items are paged by keyset in two steps:
+// the IDs of all matching objects are listed first, sorted by ID and without
+// any select, then the objects themselves are fetched Limit IDs at a time.
+// Problems and events support an eventid_from filter and are paged directly
+// by requesting Limit events sorted by eventid, starting after the last one
+// seen.
+//
+// Iteration stops at the first error, which is yielded with a zero value.
+
+// HostsAll iterates over all hosts matching params.
+func HostsAll(ctx context.Context, client Client, params HostGetParameters) iter.Seq2[Host, error] {
+	return pageByIDs(ctx, params.Limit,
+		func(ctx context.Context) ([]string, error) {
+			list := withoutSelects(params)
+			list.GetParameters = listParameters(params.GetParameters, "hostid")
+			hosts, err := client.HostGet(ctx, list)
+			return collectIDs(hosts, func(h Host) string { return h.HostID }), err
+		},
+		func(ctx context.Context, ids []string) ([]Host, error) {
+			page := params
+			page.GetParameters = pageParameters(params.GetParameters, "hostid")
+			page.HostIDs = ids
+			return client.HostGet(ctx, page)
+		},
+	)
+}
+
+// TemplatesAll iterates over all templates matching params.
+func TemplatesAll(ctx context.Context, client Client, params TemplateGetParameters) iter.Seq2[Template, error] {
+	// TemplateGetParameters shadows the sortfield of GetParameters.
+	params.SortField = []string{"templateid"}
+
+	return pageByIDs(ctx, params.Limit,
+		func(ctx context.Context) ([]string, error) {
+			list := withoutSelects(params)
+			list.GetParameters = listParameters(params.GetParameters, "templateid")
+			templates, err := client.TemplateGet(ctx, list)
+			return collectIDs(templates, func(t Template) string { return t.TemplateID }), err
+		},
+		func(ctx context.Context, ids []string) ([]Template, error) {
+			page := params
+			page.GetParameters = pageParameters(params.GetParameters, "templateid")
+			page.TemplateIDs = ids
+			return client.TemplateGet(ctx, page)
+		},
+	)
+}
+
+// ItemsAll iterates over all items matching params.
+func ItemsAll(ctx context.Context, client Client, params ItemGetParameters) iter.Seq2[Item, error] {
+	return pageByIDs(ctx, params.Limit,
+		func(ctx context.Context) ([]string, error) {
+			list := withoutSelects(params)
+			list.GetParameters = listParameters(params.GetParameters, "itemid")
+			items, err := client.ItemGet(ctx, list)
+			return collectIDs(items, func(i Item) string { return i.ItemID }), err
+		},
+		func(ctx context.Context, ids []string) ([]Item, error) {
+			page := params
+			page.GetParameters = pageParameters(params.GetParameters, "itemid")
+			page.ItemIDs = ids
+			return client.ItemGet(ctx, page)
+		},
+	)
+}
+
+// ProblemsAll iterates over all problems matching params, oldest first.
+func ProblemsAll(ctx context.Context, client Client, params ProblemGetParams) iter.Seq2[Problem, error] {
+	return pageByEventID(ctx, params.Limit, params.EventIDFrom,
+		func(ctx context.Context, from string, limit int) ([]Problem, error) {
+			page := params
+			page.GetParameters = pageParameters(params.GetParameters, "eventid")
+			page.Limit = limit
+			page.EventIDFrom = from
+			problems, err := client.ProblemGet(ctx, page)
+			if err != nil {
+				return nil, err
+			}
+			return *problems, nil
+		},
+		func(p Problem) string { return p.EventID },
+	)
+}
+
+// EventsAll iterates over all events matching params, oldest first.
+func EventsAll(ctx context.Context, client Client, params EventGetParams) iter.Seq2[Event, error] {
+	return pageByEventID(ctx, params.Limit, params.EventIDFrom,
+		func(ctx context.Context, from string, limit int) ([]Event, error) {
+			page := params
+			page.GetParameters = pageParameters(params.GetParameters, "eventid")
+			page.Limit = limit
+			page.EventIDFrom = from
+			return client.EventGet(ctx, page)
+		},
+		func(e Event) string { return e.EventID },
+	)
+}
+
+// pageByIDs lists the IDs of all matching objects, then fetches the objects
+// pageSize IDs at a time.
+func pageByIDs[T any](ctx context.Context, pageSize int,
+	list func(ctx context.Context) ([]string, error),
+	fetch func(ctx context.Context, ids []string) ([]T, error),
+) iter.Seq2[T, error] {
+	if pageSize <= 0 {
+		pageSize = DefaultPageSize
+	}
+
+	return func(yield func(T, error) bool) {
+		var zero T
+
+		ids, err := list(ctx)
+		if err != nil {
+			yield(zero, err)
+			return
+		}
+
+		for start := 0; start < len(ids); start += pageSize {
+			end := min(start+pageSize, len(ids))
+
+			objects, err := fetch(ctx, ids[start:end])
+			if err != nil {
+				yield(zero, err)
+				return
+			}
+			for _, object := range objects {
+				if !yield(object, nil) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// pageByEventID fetches pageSize events at a time, sorted by eventid and
+// starting after the last eventid of the previous page.
+func pageByEventID[T any](ctx context.Context, pageSize int, from string,
+	fetch func(ctx context.Context, from string, limit int) ([]T, error),
+	eventID func(T) string,
+) iter.Seq2[T, error] {
+	if pageSize <= 0 {
+		pageSize = DefaultPageSize
+	}
+
+	return func(yield func(T, error) bool) {
+		var zero T
+
+		for {
+			objects, err := fetch(ctx, from, pageSize)
+			if err != nil {
+				yield(zero, err)
+				return
+			}
+			for _, object := range objects {
+				if !yield(object, nil) {
+					return
+				}
+			}
+			if len(objects) < pageSize {
+				return
+			}
+
+			last, err := strconv.ParseUint(eventID(objects[len(objects)-1]), 10, 64)
+			if err != nil {
+				yield(zero, err)
+				return
+			}
+			from = strconv.FormatUint(last+1, 10)
+		}
+	}
+}
+
+// listParameters returns the parameters used to list the IDs of all objects
+// matching params.
+func listParameters(params GetParameters, idField string) GetParameters {
+	params = pageParameters(params, idField)
+	params.Output = []string{idField}
+	params.SelectHosts = nil
+	params.SelectItems = nil
+	params.SelectTriggers = nil
+	return params
+}
+
+// pageParameters returns params sorted by idField, without limit.
+func pageParameters(params GetParameters, idField string) GetParameters {
+	if output, ok := params.Output.([]string); ok && !slices.Contains(output, idField) {
+		params.Output = append(slices.Clip(output), idField)
+	}
+	params.Sortfield = []string{idField}
+	params.Sortorder = GetParametersSortOrderASC
+	params.Limit = 0
+	params.CountOutput = false
+	params.PreserveKeys = false
+	return params
+}
+
+// withoutSelects returns a copy of params with all select* parameters
+// cleared, so that listing IDs does not pull related objects.
+func withoutSelects[T any](params T) T {
+	v := reflect.ValueOf(&params).Elem()
+	for i := 0; i < v.NumField(); i++ {
+		name, _, _ := strings.Cut(v.Type().Field(i).Tag.Get("json"), ",")
+		if strings.HasPrefix(name, "select") || name == "limitSelects" {
+			v.Field(i).SetZero()
+		}
+	}
+	return params
+}
+
+func collectIDs[T any](objects []T, id func(T) string) []string {
+	ids := make([]string, 0, len(objects))
+	for _, object := range objects {
+		ids = append(ids, id(object))
+	}
+	return ids
+}
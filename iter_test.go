@@ -0,0 +1,118 @@
+package zabbix_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strconv"
+	"testing"
+
+	zabbix "github.com/nimok/nim-go-zabbix"
+)
+
+// pagingServer serves host.get and problem.get over n hosts and problems,
+// honouring the parameters used by the iterators.
+func pagingServer(t *testing.T, n int, calls *[]string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Method string `json:"method"`
+			Params struct {
+				Output      any      `json:"output"`
+				HostIDs     []string `json:"hostids"`
+				Limit       int      `json:"limit"`
+				EventIDFrom string   `json:"eventid_from"`
+			} `json:"params"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Error(err)
+			return
+		}
+		*calls = append(*calls, req.Method)
+
+		var result []map[string]string
+		switch req.Method {
+		case "host.get":
+			for i := 1; i <= n; i++ {
+				id := strconv.Itoa(i)
+				if req.Params.HostIDs != nil && !slices.Contains(req.Params.HostIDs, id) {
+					continue
+				}
+				host := map[string]string{"hostid": id}
+				if req.Params.Output == "extend" {
+					host["host"] = fmt.Sprintf("host-%d", i)
+				}
+				result = append(result, host)
+			}
+		case "problem.get":
+			from, _ := strconv.Atoi(req.Params.EventIDFrom)
+			for i := max(from, 1); i <= n && len(result) < req.Params.Limit; i++ {
+				result = append(result, map[string]string{"eventid": strconv.Itoa(i), "clock": "1700000000", "severity": "3"})
+			}
+		}
+
+		json.NewEncoder(w).Encode(map[string]any{"jsonrpc": "2.0", "id": 1, "result": result})
+	}))
+}
+
+func TestHostsAll(t *testing.T) {
+	var calls []string
+	server := pagingServer(t, 25, &calls)
+	defer server.Close()
+
+	client, err := zabbix.NewClient(server.URL, zabbix.WithAPIToken("token"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var hosts []string
+	for host, err := range zabbix.HostsAll(context.Background(), client, zabbix.HostGetParameters{
+		GetParameters: zabbix.GetParameters{Output: "extend", Limit: 10},
+	}) {
+		if err != nil {
+			t.Fatal(err)
+		}
+		hosts = append(hosts, host.Host)
+	}
+
+	if len(hosts) != 25 || hosts[0] != "host-1" || hosts[24] != "host-25" {
+		t.Fatalf("unexpected hosts %v", hosts)
+	}
+	// One call listing the IDs, then three pages.
+	if len(calls) != 4 {
+		t.Fatalf("expected 4 calls, got %v", calls)
+	}
+}
+
+func TestProblemsAll(t *testing.T) {
+	var calls []string
+	server := pagingServer(t, 25, &calls)
+	defer server.Close()
+
+	client, err := zabbix.NewClient(server.URL, zabbix.WithAPIToken("token"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var ids []string
+	for problem, err := range zabbix.ProblemsAll(context.Background(), client, zabbix.ProblemGetParams{
+		GetParameters: zabbix.GetParameters{Limit: 10},
+	}) {
+		if err != nil {
+			t.Fatal(err)
+		}
+		ids = append(ids, problem.EventID)
+		if len(ids) == 22 {
+			break
+		}
+	}
+
+	if len(ids) != 22 || ids[0] != "1" || ids[21] != "22" {
+		t.Fatalf("unexpected problems %v", ids)
+	}
+	if len(calls) != 3 {
+		t.Fatalf("expected 3 calls, got %v", calls)
+	}
+}
@@ -0,0 +1,123 @@
+package zabbix
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Hooks are functions called by a client at points of its activity, e.g. to
+// feed metrics. Nil hooks are skipped. Hooks must be safe for concurrent use.
+type Hooks struct {
+	// LimitWait is called once per request when WithRateLimit or
+	// WithMaxConcurrentRequests is set, with the time the request waited for
+	// them, including when ctx ended the wait.
+	LimitWait func(ctx context.Context, method string, wait time.Duration)
+
+	// TokenRefresh is called after each refresh of the bearer token by
+	// StartTokenRefresher, with the error of the refresh, if any.
+	TokenRefresh func(err error)
+}
+
+// WithRateLimit limits the client to rps requests per second on average,
+// with bursts of up to burst requests. It applies to every request,
+// including the logins of Authenticate; requests wait for their turn or
+// until their context ends.
+func WithRateLimit(rps float64, burst int) ClientOption {
+	return func(c *zabbixClient) {
+		c.rateLimiter = &rateLimiter{rate: rps, burst: float64(burst), tokens: float64(burst)}
+	}
+}
+
+// WithMaxConcurrentRequests limits the number of requests in flight at the
+// same time to n. It applies to every request, including the logins of
+// Authenticate; requests wait for a free slot or until their context ends.
+func WithMaxConcurrentRequests(n int) ClientOption {
+	return func(c *zabbixClient) {
+		c.maxConcurrent = n
+	}
+}
+
+// WithHooks sets the hooks called during requests.
+func WithHooks(hooks Hooks) ClientOption {
+	return func(c *zabbixClient) {
+		c.hooks = hooks
+	}
+}
+
+// rateLimiter is a token bucket.
+type rateLimiter struct {
+	rate  float64 // Tokens added per second
+	burst float64 // Capacity of the bucket
+
+	mu     sync.Mutex
+	tokens float64 // Negative when requests are waiting
+	last   time.Time
+}
+
+// wait takes a token, waiting for it to be added if the bucket is empty.
+func (l *rateLimiter) wait(ctx context.Context) error {
+	l.mu.Lock()
+	now := time.Now()
+	if !l.last.IsZero() {
+		l.tokens = min(l.burst, l.tokens+now.Sub(l.last).Seconds()*l.rate)
+	}
+	l.last = now
+	l.tokens--
+	delay := time.Duration(-l.tokens / l.rate * float64(time.Second))
+	l.mu.Unlock()
+
+	if delay <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		// Give the token back to the requests waiting after this one
+		l.mu.Lock()
+		l.tokens++
+		l.mu.Unlock()
+		return ctx.Err()
+	}
+}
+
+// acquire waits for the limits of the client before a request of method.
+// The returned function releases the concurrency slot taken, if any.
+func (c *zabbixClient) acquire(ctx context.Context, method string) (func(), error) {
+	release := func() {}
+	if c.rateLimiter == nil && c.slots == nil {
+		return release, nil
+	}
+
+	start := time.Now()
+	err := c.waitLimits(ctx, &release)
+	if c.hooks.LimitWait != nil {
+		c.hooks.LimitWait(ctx, method, time.Since(start))
+	}
+	if err != nil {
+		release()
+		return nil, err
+	}
+	return release, nil
+}
+
+func (c *zabbixClient) waitLimits(ctx context.Context, release *func()) error {
+	if c.slots != nil {
+		select {
+		case c.slots <- struct{}{}:
+			*release = func() { <-c.slots }
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	if c.rateLimiter != nil {
+		return c.rateLimiter.wait(ctx)
+	}
+	return nil
+}
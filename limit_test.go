@@ -0,0 +1,140 @@
+package zabbix_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	zabbix "github.com/nimok/nim-go-zabbix"
+)
+
+// concurrencyTransport records the highest number of requests in flight at
+// once. Each request is held for delay.
+type concurrencyTransport struct {
+	delay    time.Duration
+	inFlight atomic.Int32
+	max      atomic.Int32
+}
+
+func (c *concurrencyTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	n := c.inFlight.Add(1)
+	defer c.inFlight.Add(-1)
+	for {
+		m := c.max.Load()
+		if n <= m || c.max.CompareAndSwap(m, n) {
+			break
+		}
+	}
+	time.Sleep(c.delay)
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+func TestMaxConcurrentRequests(t *testing.T) {
+	ctx := context.Background()
+	transport := &concurrencyTransport{delay: 20 * time.Millisecond}
+
+	client, err := zabbix.NewClient(url, zabbix.WithUserPass(user, passwd),
+		zabbix.WithHTTPClient(&http.Client{Transport: transport}),
+		zabbix.WithMaxConcurrentRequests(2))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Authenticate
+	if err := client.Authenticate(); err != nil {
+		t.Fatal("Initial auth failed:", err)
+	}
+
+	var wg sync.WaitGroup
+	for range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := client.HostgroupGet(ctx, zabbix.HostGroupGetParameters{}); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if max := transport.max.Load(); max != 2 {
+		t.Fatalf("got at most %d requests in flight, want 2", max)
+	}
+}
+
+func TestRateLimit(t *testing.T) {
+	ctx := context.Background()
+
+	var mu sync.Mutex
+	var waits []string
+	client, err := zabbix.NewClient(url, zabbix.WithUserPass(user, passwd),
+		zabbix.WithRateLimit(20, 2),
+		zabbix.WithHooks(zabbix.Hooks{
+			LimitWait: func(ctx context.Context, method string, wait time.Duration) {
+				mu.Lock()
+				defer mu.Unlock()
+				waits = append(waits, method)
+			},
+		}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	start := time.Now()
+
+	// Authenticate
+	if err := client.Authenticate(); err != nil {
+		t.Fatal("Initial auth failed:", err)
+	}
+
+	// 1 login and 5 calls with a burst of 2 take at least 4 intervals of 50ms
+	for range 5 {
+		if _, err := client.HostgroupGet(ctx, zabbix.HostGroupGetParameters{}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if elapsed := time.Since(start); elapsed < 190*time.Millisecond {
+		t.Fatalf("6 requests took %v, want at least 200ms", elapsed)
+	}
+	if len(waits) != 6 || waits[0] != "user.login" || waits[1] != "hostgroup.get" {
+		t.Fatalf("unexpected LimitWait calls: %v", waits)
+	}
+}
+
+func TestRateLimitContextCanceled(t *testing.T) {
+	client, err := zabbix.NewClient(url, zabbix.WithUserPass(user, passwd),
+		zabbix.WithRateLimit(0.01, 1))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Authenticate
+	if err := client.Authenticate(); err != nil {
+		t.Fatal("Initial auth failed:", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	_, err = client.HostgroupGet(ctx, zabbix.HostGroupGetParameters{})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("got %v, want context.DeadlineExceeded", err)
+	}
+}
+
+func TestInvalidLimits(t *testing.T) {
+	for name, opt := range map[string]zabbix.ClientOption{
+		"zero rate":                zabbix.WithRateLimit(0, 1),
+		"zero burst":               zabbix.WithRateLimit(10, 0),
+		"negative max concurrency": zabbix.WithMaxConcurrentRequests(-1),
+	} {
+		if _, err := zabbix.NewClient(url, zabbix.WithUserPass(user, passwd), opt); err == nil {
+			t.Errorf("%s: want an error", name)
+		}
+	}
+}
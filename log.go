@@ -0,0 +1,135 @@
+package zabbix
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// redacted replaces the values of secret params in logs.
+const redacted = "REDACTED"
+
+// SecretFields are the properties of params and results holding secrets,
+// such as passwords, tokens and PSKs. The logger of the client never logs
+// their values, and zabbixvcr scrubs them from cassettes by default.
+var SecretFields = []string{
+	"password",
+	"passwd",
+	"token",
+	"sessionid",
+	"tls_psk",
+	"tls_psk_identity",
+	"ipmi_password",
+	"authpassphrase",
+	"privpassphrase",
+}
+
+// WithLogger logs the activity of the client to logger: every API call at
+// debug level with its redacted params, duration and result count, logins
+// and token refreshes at info level, API errors at warn level and failed
+// requests at error level. Passwords, PSKs, SNMP passphrases and tokens are
+// always redacted.
+func WithLogger(logger *slog.Logger) ClientOption {
+	return func(c *zabbixClient) {
+		c.logger = logger
+	}
+}
+
+// logCall is the innermost interceptor when a logger is set.
+func (c *zabbixClient) logCall(ctx context.Context, call *Call, next Invoker) error {
+	err := next(ctx, call)
+
+	level := slog.LevelDebug
+	msg := "zabbix API call"
+	attrs := []slog.Attr{
+		slog.String("method", call.Method),
+		slog.Duration("duration", call.Duration),
+	}
+
+	var apiErr *APIError
+	switch {
+	case errors.As(err, &apiErr):
+		level, msg = slog.LevelWarn, "zabbix API call failed"
+	case err != nil:
+		level, msg = slog.LevelError, "zabbix API request failed"
+	}
+	if !c.logger.Enabled(ctx, level) {
+		return err
+	}
+
+	switch {
+	case apiErr != nil:
+		attrs = append(attrs, slog.Int("error_code", apiErr.Code), slog.String("error", strings.TrimSpace(apiErr.Message+" "+apiErr.Data)))
+	case err != nil:
+		attrs = append(attrs, slog.String("error", err.Error()))
+	default:
+		if n, ok := resultCount(call); ok {
+			attrs = append(attrs, slog.Int("count", n))
+		}
+	}
+	attrs = append(attrs, slog.String("params", redactParams(call.Params)))
+	c.logger.LogAttrs(ctx, level, msg, attrs...)
+	return err
+}
+
+// log logs msg if the client has a logger.
+func (c *zabbixClient) log(ctx context.Context, level slog.Level, msg string, attrs ...slog.Attr) {
+	if c.logger != nil {
+		c.logger.LogAttrs(ctx, level, msg, attrs...)
+	}
+}
+
+// resultCount returns the number of objects in the decoded result of call,
+// if the result is an array.
+func resultCount(call *Call) (int, bool) {
+	v := reflect.ValueOf(call.result)
+	for v.Kind() == reflect.Pointer && !v.IsNil() {
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Slice {
+		return 0, false
+	}
+	return v.Len(), true
+}
+
+// redactParams returns params encoded as JSON, with the values of the secret
+// params replaced.
+func redactParams(params any) string {
+	data, err := json.Marshal(params)
+	if err != nil {
+		return ""
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	var v any
+	if err := decoder.Decode(&v); err != nil {
+		return ""
+	}
+	data, _ = json.Marshal(redact(v))
+	return string(data)
+}
+
+func redact(v any) any {
+	switch v := v.(type) {
+	case map[string]any:
+		for k, e := range v {
+			if slices.Contains(SecretFields, strings.ToLower(k)) {
+				v[k] = redacted
+			} else {
+				v[k] = redact(e)
+			}
+		}
+	case []any:
+		for i, e := range v {
+			v[i] = redact(e)
+		}
+	}
+	return v
+}
@@ -0,0 +1,82 @@
+package zabbix_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"testing"
+
+	zabbix "github.com/nimok/nim-go-zabbix"
+)
+
+func TestLogger(t *testing.T) {
+	ctx := context.Background()
+
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+	client, err := zabbix.NewClient(url, zabbix.WithUserPass(user, passwd), zabbix.WithLogger(logger))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Authenticate
+	if err := client.Authenticate(); err != nil {
+		t.Fatal("Initial auth failed:", err)
+	}
+
+	groups, err := client.HostgroupGet(ctx, zabbix.HostGroupGetParameters{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Fails on the unknown group, after the PSK params were logged
+	_, err = client.HostCreate(ctx, []zabbix.Host{{
+		Host:           "logged-host",
+		Groups:         []zabbix.HostGroup{{GroupID: "999999999"}},
+		TlsConnect:     2,
+		TlsPSKIdentity: "psk-identity",
+		TlsPSK:         "1f87b595725ac58dd977beef14b97461a7c1045b9a1c963065002c5473194952",
+	}})
+	if err == nil {
+		t.Fatal("want an error")
+	}
+
+	var records []map[string]any
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		var record map[string]any
+		if err := json.Unmarshal([]byte(line), &record); err != nil {
+			t.Fatal(err)
+		}
+		records = append(records, record)
+	}
+
+	if len(records) != 4 {
+		t.Fatalf("got %d records, want 4:\n%s", len(records), buf.String())
+	}
+
+	login, info, get, create := records[0], records[1], records[2], records[3]
+	if login["level"] != "DEBUG" || login["method"] != "user.login" {
+		t.Errorf("unexpected login call record %v", login)
+	}
+	if info["level"] != "INFO" || info["msg"] != "zabbix login" {
+		t.Errorf("unexpected login record %v", info)
+	}
+	if get["method"] != "hostgroup.get" || get["count"] != float64(len(groups)) {
+		t.Errorf("unexpected get record %v", get)
+	}
+	if create["level"] != "WARN" || create["method"] != "host.create" || create["error_code"] != float64(-32602) {
+		t.Errorf("unexpected create record %v", create)
+	}
+
+	if params, _ := login["params"].(string); !strings.Contains(params, `"password":"REDACTED"`) || strings.Contains(params, passwd) {
+		t.Errorf("password not redacted: %s", params)
+	}
+	for _, secret := range []string{"psk-identity", "1f87b595725ac58dd977beef14b97461a7c1045b9a1c963065002c5473194952"} {
+		if strings.Contains(buf.String(), secret) {
+			t.Errorf("secret %q was logged", secret)
+		}
+	}
+}
@@ -1,8 +1,16 @@
 package zabbix
 
+// Types of user macros.
+const (
+	MacroTypeText   = 0
+	MacroTypeSecret = 1 // The value is write-only: the API never returns it
+	MacroTypeVault  = 2 // The value is a path to a secret in a vault
+)
+
 // Macro represents a user macro in Zabbix.
 type Macro struct {
-	Macro       string `json:"macro"`
-	Value       string `json:"value"`
-	Description string `json:"description,omitempty"`
+	Macro       string  `json:"macro"`
+	Value       string  `json:"value"`
+	Description string  `json:"description,omitempty"`
+	Type        FlexInt `json:"type,omitempty"` // Type of the macro, one of the MacroType constants; default is MacroTypeText
 }
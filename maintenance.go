@@ -0,0 +1,60 @@
+package zabbix
+
+import "context"
+
+// Maintenance types.
+const (
+	MaintenanceTypeWithData = 0 // Data is collected during the maintenance
+	MaintenanceTypeNoData   = 1 // No data is collected during the maintenance
+)
+
+// Time period types of a maintenance.
+const (
+	TimePeriodTypeOnce    = 0
+	TimePeriodTypeDaily   = 2
+	TimePeriodTypeWeekly  = 3
+	TimePeriodTypeMonthly = 4
+)
+
+// Maintenance represents a maintenance period in Zabbix.
+type Maintenance struct {
+	MaintenanceID   string       `json:"maintenanceid,omitempty"`    // ID of the maintenance; read-only, required for update operations
+	Name            string       `json:"name,omitempty"`             // Name of the maintenance; required for create operations
+	ActiveSince     FlexInt64    `json:"active_since,omitempty"`     // Time when the maintenance becomes active; required for create operations
+	ActiveTill      FlexInt64    `json:"active_till,omitempty"`      // Time when the maintenance stops being active; required for create operations
+	Description     string       `json:"description,omitempty"`      // Description of the maintenance
+	MaintenanceType FlexInt      `json:"maintenance_type,omitempty"` // Type of maintenance (0 - with data collection; 1 - without data collection)
+	TagsEvalType    FlexInt      `json:"tags_evaltype,omitempty"`    // Problem tag evaluation method (0 - And/Or; 2 - Or)
+	Groups          []HostGroup  `json:"groups,omitempty"`           // Host groups under maintenance; groups or hosts are required for create operations
+	Hosts           []Host       `json:"hosts,omitempty"`            // Hosts under maintenance; groups or hosts are required for create operations
+	TimePeriods     []TimePeriod `json:"timeperiods,omitempty"`      // Time periods of the maintenance; required for create operations
+	Tags            []Tag        `json:"tags,omitempty"`             // Problem tags for which the maintenance applies
+}
+
+// TimePeriod is a time period of a maintenance.
+type TimePeriod struct {
+	TimePeriodType FlexInt   `json:"timeperiod_type"`      // Type of the time period (0 - one time only; 2 - daily; 3 - weekly; 4 - monthly)
+	Period         FlexInt64 `json:"period,omitempty"`     // Duration of the time period in seconds; default is 3600
+	StartDate      FlexInt64 `json:"start_date,omitempty"` // Date when the maintenance starts, for one time periods
+	StartTime      FlexInt64 `json:"start_time,omitempty"` // Time of day in seconds when the maintenance starts, for repeated periods
+	Every          FlexInt   `json:"every,omitempty"`      // Every how many days, weeks or months the period repeats
+	DayOfWeek      FlexInt   `json:"dayofweek,omitempty"`  // Days of the week when the maintenance starts, as a bitmask
+	Day            FlexInt   `json:"day,omitempty"`        // Day of the month when the maintenance starts, for monthly periods
+	Month          FlexInt   `json:"month,omitempty"`      // Months when the maintenance starts, as a bitmask, for monthly periods
+}
+
+type MaintenanceCreateResponse struct {
+	MaintenanceIDs []string `json:"maintenanceids"` // IDs of the created maintenances
+}
+
+func (z *zabbixClient) MaintenanceCreate(ctx context.Context, params []Maintenance) (*MaintenanceCreateResponse, error) {
+
+	var result MaintenanceCreateResponse
+
+	err := z.makeRequest(ctx, "maintenance.create", params, &result)
+	if err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
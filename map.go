@@ -0,0 +1,182 @@
+package zabbix
+
+import "context"
+
+// Types of map elements.
+const (
+	MapElementTypeHost      = 0
+	MapElementTypeMap       = 1
+	MapElementTypeTrigger   = 2
+	MapElementTypeHostGroup = 3
+	MapElementTypeImage     = 4
+)
+
+// Types of map shapes.
+const (
+	MapShapeRectangle = 0
+	MapShapeEllipse   = 1
+)
+
+// Map represents a network map in Zabbix. On create, the links of the map
+// refer to its elements by the SelementID given to them, which is replaced
+// by the ID Zabbix assigns.
+type Map struct {
+	SysmapID      string            `json:"sysmapid,omitempty"`       // ID of the map; read-only, required for update operations
+	Name          string            `json:"name,omitempty"`           // Name of the map; required for create operations
+	Width         FlexInt           `json:"width,omitempty"`          // Width of the map in pixels; required for create operations
+	Height        FlexInt           `json:"height,omitempty"`         // Height of the map in pixels; required for create operations
+	BackgroundID  string            `json:"backgroundid,omitempty"`   // ID of the background image
+	ExpandMacros  FlexInt           `json:"expand_macros,omitempty"`  // Whether to expand macros in labels; 0 (default) no, 1 yes
+	GridShow      FlexInt           `json:"grid_show,omitempty"`      // Whether to show the grid; 0 no, 1 (default) yes
+	GridSize      FlexInt           `json:"grid_size,omitempty"`      // Size of the grid cells in pixels; default is 50
+	GridAlign     FlexInt           `json:"grid_align,omitempty"`     // Whether to align elements to the grid; 0 no, 1 (default) yes
+	Highlight     FlexInt           `json:"highlight,omitempty"`      // Whether to highlight elements with problems; 0 no, 1 (default) yes
+	LabelType     FlexInt           `json:"label_type,omitempty"`     // Label of the elements; 0 IP address, 1 host name, 2 (default) status only, 3 nothing, 4 element label
+	LabelLocation FlexInt           `json:"label_location,omitempty"` // Location of the labels; 0 (default) bottom, 1 left, 2 right, 3 top
+	SeverityMin   FlexInt           `json:"severity_min,omitempty"`   // Minimum severity of the problems shown
+	ShowUnack     FlexInt           `json:"show_unack,omitempty"`     // Problems shown; 0 (default) all, 1 unacknowledged count, 2 separate counts
+	UserID        string            `json:"userid,omitempty"`         // ID of the owner; default is the current user
+	Private       *FlexInt          `json:"private,omitempty"`        // Sharing type; 0 public, 1 private; nil for the default, private
+	Selements     []MapElement      `json:"selements,omitempty"`      // Elements of the map
+	Links         []MapLink         `json:"links,omitempty"`          // Links between the elements
+	Shapes        []MapShape        `json:"shapes,omitempty"`         // Shapes drawn on the map
+	Users         []SharedUser      `json:"users,omitempty"`          // Users the map is shared with
+	UserGroups    []SharedUserGroup `json:"userGroups,omitempty"`     // User groups the map is shared with
+}
+
+// MapElement is an element of a map, such as a host.
+type MapElement struct {
+	SelementID    string          `json:"selementid,omitempty"`     // ID of the element; set on create for links to refer to it
+	ElementType   FlexInt         `json:"elementtype"`              // Type of the element, one of the MapElementType constants; required
+	Elements      []MapElementRef `json:"elements,omitempty"`       // Objects the element stands for; required unless an image
+	IconIDOff     string          `json:"iconid_off"`               // ID of the image shown by default; required
+	IconIDOn      string          `json:"iconid_on,omitempty"`      // ID of the image shown when the element has problems
+	Label         string          `json:"label,omitempty"`          // Label of the element
+	LabelLocation FlexInt         `json:"label_location,omitempty"` // Location of the label; -1 (default) map default, 0 bottom, 1 left, 2 right, 3 top
+	X             FlexInt         `json:"x,omitempty"`              // X coordinate in pixels
+	Y             FlexInt         `json:"y,omitempty"`              // Y coordinate in pixels
+	Permission    FlexInt         `json:"permission,omitempty"`     // Permission of the current user on the element; read-only
+}
+
+// MapElementRef is an object a map element stands for. Only the ID matching
+// the type of the element is set.
+type MapElementRef struct {
+	HostID    string `json:"hostid,omitempty"`    // For MapElementTypeHost
+	SysmapID  string `json:"sysmapid,omitempty"`  // For MapElementTypeMap
+	TriggerID string `json:"triggerid,omitempty"` // For MapElementTypeTrigger
+	GroupID   string `json:"groupid,omitempty"`   // For MapElementTypeHostGroup
+}
+
+// MapLink is a link between two elements of a map.
+type MapLink struct {
+	LinkID       string           `json:"linkid,omitempty"`       // ID of the link; read-only
+	SelementID1  string           `json:"selementid1"`            // ID of the first element; required
+	SelementID2  string           `json:"selementid2"`            // ID of the second element; required
+	DrawType     FlexInt          `json:"drawtype,omitempty"`     // Line style; 0 (default) line, 2 bold, 3 dotted, 4 dashed
+	Color        string           `json:"color,omitempty"`        // Line color as a hex code; default is "000000"
+	Label        string           `json:"label,omitempty"`        // Label of the link
+	LinkTriggers []MapLinkTrigger `json:"linktriggers,omitempty"` // Line style and color while a trigger is in problem state
+}
+
+// MapLinkTrigger changes the style of a link while its trigger is in problem
+// state.
+type MapLinkTrigger struct {
+	TriggerID string  `json:"triggerid"`          // ID of the trigger; required
+	DrawType  FlexInt `json:"drawtype,omitempty"` // Line style, as MapLink.DrawType
+	Color     string  `json:"color,omitempty"`    // Line color as a hex code; default is "DD0000"
+}
+
+// MapShape is a rectangle or ellipse drawn on a map, e.g. to group
+// elements or to show a title.
+type MapShape struct {
+	SysmapShapeID   string  `json:"sysmap_shapeid,omitempty"`   // ID of the shape; read-only
+	Type            FlexInt `json:"type"`                       // MapShapeRectangle or MapShapeEllipse; required
+	X               FlexInt `json:"x,omitempty"`                // X coordinate in pixels
+	Y               FlexInt `json:"y,omitempty"`                // Y coordinate in pixels
+	Width           FlexInt `json:"width,omitempty"`            // Width in pixels; default is 200
+	Height          FlexInt `json:"height,omitempty"`           // Height in pixels; default is 200
+	Text            string  `json:"text,omitempty"`             // Text shown in the shape
+	FontSize        FlexInt `json:"font_size,omitempty"`        // Font size in points; default is 11
+	FontColor       string  `json:"font_color,omitempty"`       // Font color as a hex code; default is "000000"
+	BorderType      FlexInt `json:"border_type,omitempty"`      // Border style; 0 none, 1 (default) line, 2 dotted, 3 dashed
+	BorderColor     string  `json:"border_color,omitempty"`     // Border color as a hex code; default is "000000"
+	BackgroundColor string  `json:"background_color,omitempty"` // Background color as a hex code; transparent if empty
+	ZIndex          FlexInt `json:"zindex,omitempty"`           // Order in which shapes are drawn
+}
+
+type MapGetParameters struct {
+	GetParameters
+
+	SysmapIDs        []string `json:"sysmapids,omitempty"`
+	UserIDs          []string `json:"userids,omitempty"`
+	SelectSelements  any      `json:"selectSelements,omitempty"`
+	SelectLinks      any      `json:"selectLinks,omitempty"`
+	SelectShapes     any      `json:"selectShapes,omitempty"`
+	SelectUsers      any      `json:"selectUsers,omitempty"`
+	SelectUserGroups any      `json:"selectUserGroups,omitempty"`
+}
+
+type MapCreateResponse struct {
+	SysmapIDs []string `json:"sysmapids"` // IDs of the created maps
+}
+
+type MapUpdateResponse struct {
+	SysmapIDs []string `json:"sysmapids"` // IDs of the updated maps
+}
+
+type MapDeleteResponse struct {
+	SysmapIDs []string `json:"sysmapids"` // IDs of the deleted maps
+}
+
+func (z *zabbixClient) MapGet(ctx context.Context, params MapGetParameters) ([]Map, error) {
+
+	var result []Map
+
+	err := z.makeRequest(ctx, "map.get", params, &result)
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (z *zabbixClient) MapCreate(ctx context.Context, params []Map) (*MapCreateResponse, error) {
+
+	var result MapCreateResponse
+
+	err := z.makeRequest(ctx, "map.create", params, &result)
+	if err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+func (z *zabbixClient) MapUpdate(ctx context.Context, params Map, fields ...string) (*MapUpdateResponse, error) {
+
+	var result MapUpdateResponse
+
+	update, err := withFields(params, fields)
+	if err != nil {
+		return nil, err
+	}
+
+	err = z.makeRequest(ctx, "map.update", update, &result)
+	if err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+func (z *zabbixClient) MapDelete(ctx context.Context, params []string) (*MapDeleteResponse, error) {
+
+	var result MapDeleteResponse
+
+	err := z.makeRequest(ctx, "map.delete", params, &result)
+	if err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
@@ -0,0 +1,146 @@
+package zabbix_test
+
+import (
+	"context"
+	"testing"
+
+	zabbix "github.com/nimok/nim-go-zabbix"
+)
+
+func TestMapCreateAndUpdate(t *testing.T) {
+	ctx := context.Background()
+
+	client, err := zabbix.NewClient(url, zabbix.WithUserPass(user, passwd))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Authenticate
+	if err := client.Authenticate(); err != nil {
+		t.Fatal("Initial auth failed:", err)
+	}
+
+	hosts, err := client.HostGet(ctx, zabbix.HostGetParameters{
+		GetParameters: zabbix.GetParameters{Filter: map[string]any{"host": "Zabbix server"}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(hosts) == 0 {
+		t.Fatal("No hosts found")
+	}
+	hostID := hosts[0].HostID
+
+	groups, err := client.HostgroupGet(ctx, zabbix.HostGroupGetParameters{HostIDs: []string{hostID}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(groups) == 0 {
+		t.Fatal("No host groups found")
+	}
+
+	// The link refers to the elements by the IDs given to them
+	public := zabbix.FlexInt(0)
+	resp, err := client.MapCreate(ctx, []zabbix.Map{{
+		Name:    "testing-map",
+		Width:   800,
+		Height:  600,
+		Private: &public,
+		Selements: []zabbix.MapElement{
+			{
+				SelementID:  "1",
+				ElementType: zabbix.MapElementTypeHost,
+				Elements:    []zabbix.MapElementRef{{HostID: hostID}},
+				IconIDOff:   "2",
+				X:           100,
+				Y:           100,
+			},
+			{
+				SelementID:  "2",
+				ElementType: zabbix.MapElementTypeHostGroup,
+				Elements:    []zabbix.MapElementRef{{GroupID: groups[0].GroupID}},
+				IconIDOff:   "2",
+				X:           300,
+				Y:           100,
+			},
+		},
+		Links:  []zabbix.MapLink{{SelementID1: "1", SelementID2: "2", Color: "00AA00"}},
+		Shapes: []zabbix.MapShape{{Type: zabbix.MapShapeRectangle, Width: 800, Height: 40, Text: "Team"}},
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	sysmapID := resp.SysmapIDs[0]
+	defer client.MapDelete(ctx, []string{sysmapID})
+
+	get := func() zabbix.Map {
+		t.Helper()
+		maps, err := client.MapGet(ctx, zabbix.MapGetParameters{
+			SysmapIDs:       []string{sysmapID},
+			SelectSelements: "extend",
+			SelectLinks:     "extend",
+			SelectShapes:    "extend",
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(maps) != 1 {
+			t.Fatalf("expected 1 map, got %d", len(maps))
+		}
+		return maps[0]
+	}
+
+	m := get()
+	// Private 0 is sent even though it is empty
+	if m.Private == nil || *m.Private != 0 {
+		t.Errorf("expected a public map, got private %v", m.Private)
+	}
+	if len(m.Selements) != 2 || len(m.Links) != 1 || len(m.Shapes) != 1 {
+		t.Fatalf("unexpected map %+v", m)
+	}
+	ids := map[string]bool{m.Selements[0].SelementID: true, m.Selements[1].SelementID: true}
+	link := m.Links[0]
+	if !ids[link.SelementID1] || !ids[link.SelementID2] || link.SelementID1 == link.SelementID2 {
+		t.Errorf("expected the link to join both elements %v, got %+v", ids, link)
+	}
+	if m.Shapes[0].Text != "Team" {
+		t.Errorf("unexpected shape %+v", m.Shapes[0])
+	}
+
+	// Removing an element removes its links
+	if _, err := client.MapUpdate(ctx, zabbix.Map{
+		SysmapID:  sysmapID,
+		Selements: m.Selements[:1],
+	}); err != nil {
+		t.Fatal(err)
+	}
+	m = get()
+	if len(m.Selements) != 1 || len(m.Links) != 0 {
+		t.Errorf("expected 1 element and no links, got %+v", m)
+	}
+}
+
+func TestMapCreateFailUnknownLinkElement(t *testing.T) {
+	ctx := context.Background()
+
+	client, err := zabbix.NewClient(url, zabbix.WithUserPass(user, passwd))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Authenticate
+	if err := client.Authenticate(); err != nil {
+		t.Fatal("Initial auth failed:", err)
+	}
+
+	resp, err := client.MapCreate(ctx, []zabbix.Map{{
+		Name:   "testing-map-invalid",
+		Width:  800,
+		Height: 600,
+		Links:  []zabbix.MapLink{{SelementID1: "1", SelementID2: "2"}},
+	}})
+	if err == nil {
+		client.MapDelete(ctx, resp.SysmapIDs)
+		t.Fatal("expected a link between unknown elements to fail")
+	}
+}
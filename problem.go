@@ -1,6 +1,49 @@
 package zabbix
 
-import "context"
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// Severity is the severity of a trigger or problem.
+type Severity int
+
+const (
+	SeverityNotClassified Severity = 0
+	SeverityInformation   Severity = 1
+	SeverityWarning       Severity = 2
+	SeverityAverage       Severity = 3
+	SeverityHigh          Severity = 4
+	SeverityDisaster      Severity = 5
+)
+
+var severityNames = [...]string{
+	SeverityNotClassified: "Not classified",
+	SeverityInformation:   "Information",
+	SeverityWarning:       "Warning",
+	SeverityAverage:       "Average",
+	SeverityHigh:          "High",
+	SeverityDisaster:      "Disaster",
+}
+
+// String returns the name of the severity as shown in the Zabbix frontend.
+func (s Severity) String() string {
+	if s >= 0 && int(s) < len(severityNames) {
+		return severityNames[s]
+	}
+	return fmt.Sprintf("Severity(%d)", int(s))
+}
+
+func (s *Severity) UnmarshalJSON(data []byte) error {
+	n, err := parseInt(data)
+	if err != nil {
+		return fmt.Errorf("invalid severity: %v", err)
+	}
+	*s = Severity(n)
+	return nil
+}
 
 type ProblemGetTag struct {
 	Tag      string `json:"tag"`                // tag name (exact match)
@@ -46,24 +89,24 @@ type ProblemGetParams struct {
 
 // Problem represents one entry returned by problem.get.
 type Problem struct {
-	// Core problem fields (IDs and timestamps are strings in Zabbix JSON)
-	EventID       string `json:"eventid"`       // ID
-	Source        string `json:"source"`        // "0" trigger, "3" internal, "4" service-status update
-	Object        string `json:"object"`        // depends on source
-	ObjectID      string `json:"objectid"`      // related object ID
-	Clock         string `json:"clock"`         // timestamp (Unix seconds, as string)
-	Ns            string `json:"ns"`            // creation nanoseconds (as string)
-	REventID      string `json:"r_eventid"`     // recovery event ID
-	RClock        string `json:"r_clock"`       // recovery time (Unix seconds, as string)
-	RNs           string `json:"r_ns"`          // recovery nanoseconds (as string)
-	CauseEventID  string `json:"cause_eventid"` // ID of the cause event
-	CorrelationID string `json:"correlationid"` // correlation rule ID (if recovered by rule)
-	UserID        string `json:"userid"`        // user who manually closed the problem (if any)
-	Name          string `json:"name"`          // resolved problem name (may be empty for unresolved)
-	Acknowledged  string `json:"acknowledged"`  // "0" or "1"
-	Severity      string `json:"severity"`      // "0".."5"
-	Suppressed    string `json:"suppressed"`    // "0" or "1"
-	OpData        string `json:"opdata"`        // operational data with expanded macros
+	// Core problem fields (IDs are strings in Zabbix JSON)
+	EventID       string    `json:"eventid"`       // ID
+	Source        string    `json:"source"`        // "0" trigger, "3" internal, "4" service-status update
+	Object        string    `json:"object"`        // depends on source
+	ObjectID      string    `json:"objectid"`      // related object ID
+	Clock         time.Time `json:"clock"`         // creation time (clock and ns)
+	Ns            string    `json:"ns"`            // creation nanoseconds (as string)
+	REventID      string    `json:"r_eventid"`     // recovery event ID ("0" if not resolved)
+	RClock        time.Time `json:"r_clock"`       // recovery time (r_clock and r_ns); zero if not resolved
+	RNs           string    `json:"r_ns"`          // recovery nanoseconds (as string)
+	CauseEventID  string    `json:"cause_eventid"` // ID of the cause event
+	CorrelationID string    `json:"correlationid"` // correlation rule ID (if recovered by rule)
+	UserID        string    `json:"userid"`        // user who manually closed the problem (if any)
+	Name          string    `json:"name"`          // resolved problem name (may be empty for unresolved)
+	Acknowledged  bool      `json:"acknowledged"`  // whether the problem is acknowledged
+	Severity      Severity  `json:"severity"`      // current severity
+	Suppressed    bool      `json:"suppressed"`    // whether the problem is suppressed
+	OpData        string    `json:"opdata"`        // operational data with expanded macros
 
 	// Added when requested:
 	URLs            []ProblemMediaURL       `json:"urls,omitempty"`             // media-type URLs (active only)
@@ -72,6 +115,75 @@ type Problem struct {
 	SuppressionData []ProblemSuppressionRef `json:"suppression_data,omitempty"` // selectSuppressionData
 }
 
+// Resolved reports whether the problem has a recovery event.
+func (p Problem) Resolved() bool {
+	return !p.RClock.IsZero()
+}
+
+// Duration returns how long the problem lasted if it is resolved, or how long
+// it has been open so far otherwise.
+func (p Problem) Duration() time.Duration {
+	if p.Clock.IsZero() {
+		return 0
+	}
+	if p.Resolved() {
+		return p.RClock.Sub(p.Clock)
+	}
+	return time.Since(p.Clock)
+}
+
+func (p *Problem) UnmarshalJSON(data []byte) error {
+	type problem Problem
+
+	aux := struct {
+		*problem
+		Clock        json.RawMessage `json:"clock"`
+		RClock       json.RawMessage `json:"r_clock"`
+		Acknowledged json.RawMessage `json:"acknowledged"`
+		Suppressed   json.RawMessage `json:"suppressed"`
+	}{problem: (*problem)(p)}
+
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+
+	var err error
+	if p.Clock, err = parseUnixTime(aux.Clock, []byte(p.Ns)); err != nil {
+		return fmt.Errorf("invalid problem clock: %v", err)
+	}
+	if p.RClock, err = parseUnixTime(aux.RClock, []byte(p.RNs)); err != nil {
+		return fmt.Errorf("invalid problem r_clock: %v", err)
+	}
+	if p.Acknowledged, err = parseBool(aux.Acknowledged); err != nil {
+		return fmt.Errorf("invalid problem acknowledged: %v", err)
+	}
+	if p.Suppressed, err = parseBool(aux.Suppressed); err != nil {
+		return fmt.Errorf("invalid problem suppressed: %v", err)
+	}
+	return nil
+}
+
+// MarshalJSON encodes the problem the way problem.get returns it.
+func (p Problem) MarshalJSON() ([]byte, error) {
+	type problem Problem
+
+	return json.Marshal(struct {
+		problem
+		Clock        string `json:"clock"`
+		RClock       string `json:"r_clock"`
+		Acknowledged string `json:"acknowledged"`
+		Severity     string `json:"severity"`
+		Suppressed   string `json:"suppressed"`
+	}{
+		problem:      problem(p),
+		Clock:        formatUnixTime(p.Clock),
+		RClock:       formatUnixTime(p.RClock),
+		Acknowledged: formatBool(p.Acknowledged),
+		Severity:     fmt.Sprint(int(p.Severity)),
+		Suppressed:   formatBool(p.Suppressed),
+	})
+}
+
 // ProblemMediaURL corresponds to entries in Problem.URLs.
 type ProblemMediaURL struct {
 	Name string `json:"name"` // defined URL name
@@ -2,7 +2,10 @@ package zabbix_test
 
 import (
 	"context"
+	"net/http"
+	"net/http/httptest"
 	"testing"
+	"time"
 
 	zabbix "github.com/nimok/nim-go-zabbix"
 )
@@ -41,3 +44,44 @@ func TestGetProblems(t *testing.T) {
 		t.FailNow()
 	}
 }
+
+func TestProblemTypedFields(t *testing.T) {
+	ctx := context.Background()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"jsonrpc":"2.0","id":1,"result":[
+			{"eventid":"10","clock":"1700000000","ns":"500","r_eventid":"0","r_clock":"0","r_ns":"0",
+			 "acknowledged":"1","severity":"5","suppressed":"0","tags":[{"tag":"service","value":"db"}]},
+			{"eventid":"11","clock":"1700000000","ns":"0","r_eventid":"12","r_clock":"1700000600","r_ns":"0",
+			 "acknowledged":"0","severity":"2","suppressed":"1"}
+		]}`))
+	}))
+	defer server.Close()
+
+	client, err := zabbix.NewClient(server.URL, zabbix.WithAPIToken("token"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	problems, err := client.ProblemGet(ctx, zabbix.ProblemGetParams{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(*problems) != 2 {
+		t.Fatalf("expected 2 problems, got %d", len(*problems))
+	}
+
+	open, resolved := (*problems)[0], (*problems)[1]
+	if open.Clock.Unix() != 1700000000 || open.Clock.Nanosecond() != 500 {
+		t.Errorf("unexpected clock %v", open.Clock)
+	}
+	if open.Resolved() || !open.Acknowledged || open.Suppressed || open.Severity != zabbix.SeverityDisaster {
+		t.Errorf("unexpected open problem %+v", open)
+	}
+	if open.Severity.String() != "Disaster" || len(open.Tags) != 1 {
+		t.Errorf("unexpected open problem %+v", open)
+	}
+	if !resolved.Resolved() || resolved.Duration() != 10*time.Minute || !resolved.Suppressed {
+		t.Errorf("unexpected resolved problem %+v", resolved)
+	}
+}
@@ -124,3 +124,46 @@ func deleteProxy(ctx context.Context, client zabbix.Client, proxyId string) erro
 
 	return nil
 }
+
+func TestProxyUpdate(t *testing.T) {
+	ctx := context.Background()
+
+	client, err := zabbix.NewClient(url, zabbix.WithUserPass(user, passwd))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Authenticate
+	if err := client.Authenticate(); err != nil {
+		t.Fatal("Initial auth failed:", err)
+	}
+
+	createResp, err := client.ProxyCreate(ctx, zabbix.ProxyCreateParameters{
+		Proxy: zabbix.Proxy{
+			Name:          "my-proxy",
+			OperatingMode: 0,
+			Description:   "Test proxy",
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	proxyID := createResp.ProxyIDs[0]
+	defer deleteProxy(ctx, client, proxyID)
+
+	updateResp, err := client.ProxyUpdate(ctx, zabbix.Proxy{ProxyID: proxyID, Name: "my-renamed-proxy"}, "description")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(updateResp.ProxyIDs) != 1 || updateResp.ProxyIDs[0] != proxyID {
+		t.Fatal("proxy id mismatch")
+	}
+
+	proxies, err := client.ProxyGet(ctx, zabbix.ProxyGetParameters{ProxyIDs: []string{proxyID}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(proxies) != 1 || proxies[0].Name != "my-renamed-proxy" || proxies[0].Description != "" {
+		t.Errorf("proxy not updated: %+v", proxies)
+	}
+}
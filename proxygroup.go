@@ -0,0 +1,34 @@
+package zabbix
+
+import "context"
+
+// ProxyGroup represents a proxy group in Zabbix.
+type ProxyGroup struct {
+	ProxyGroupID  string  `json:"proxy_groupid,omitempty"`  // ID of the proxy group; read-only, required for update operations
+	Name          string  `json:"name,omitempty"`           // Name of the proxy group; required for create operations
+	FailoverDelay string  `json:"failover_delay,omitempty"` // Time after which an offline proxy is considered unavailable; default is 1m
+	MinOnline     string  `json:"min_online,omitempty"`     // Minimum number of online proxies for the group to be online; default is 1
+	Description   string  `json:"description,omitempty"`    // Description of the proxy group
+	State         FlexInt `json:"state,omitempty"`          // State of the proxy group; read-only
+}
+
+type ProxyGroupGetParameters struct {
+	GetParameters
+
+	ProxyGroupIDs []string `json:"proxy_groupids,omitempty"`
+	ProxyIDs      []string `json:"proxyids,omitempty"`
+	SelectProxies any      `json:"selectProxies,omitempty"`
+	SortField     any      `json:"sortfield,omitempty"`
+}
+
+func (z *zabbixClient) ProxyGroupGet(ctx context.Context, params ProxyGroupGetParameters) ([]ProxyGroup, error) {
+
+	var result []ProxyGroup
+
+	err := z.makeRequest(ctx, "proxygroup.get", params, &result)
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
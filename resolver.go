@@ -0,0 +1,271 @@
+package zabbix
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultResolverTTL is how long a Resolver caches the IDs it looked up when
+// no WithResolverTTL option is given.
+const DefaultResolverTTL = 5 * time.Minute
+
+// Resolver maps the names of host groups, templates, proxies, proxy groups
+// and hosts to their IDs, e.g. to fill in the references of a Host to
+// create. Lookups are batched into one get call per kind of object and their
+// results are cached. A Resolver is safe for concurrent use.
+type Resolver struct {
+	client           Client
+	ttl              time.Duration
+	createHostGroups bool
+
+	mu    sync.Mutex
+	cache map[string]map[string]resolved // By kind, then by name
+}
+
+type resolved struct {
+	id      string
+	expires time.Time
+}
+
+type ResolverOption func(*Resolver)
+
+// WithResolverTTL sets how long looked up IDs are cached. A TTL of 0
+// disables caching.
+func WithResolverTTL(ttl time.Duration) ResolverOption {
+	return func(r *Resolver) {
+		r.ttl = ttl
+	}
+}
+
+// WithCreateMissingHostGroups makes the Resolver create the host groups it
+// does not find instead of failing.
+func WithCreateMissingHostGroups() ResolverOption {
+	return func(r *Resolver) {
+		r.createHostGroups = true
+	}
+}
+
+func NewResolver(client Client, opts ...ResolverOption) *Resolver {
+	r := &Resolver{
+		client: client,
+		ttl:    DefaultResolverTTL,
+		cache:  make(map[string]map[string]resolved),
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r
+}
+
+// NotFoundError is returned by a Resolver when some names do not exist.
+type NotFoundError struct {
+	Kind  string   // Kind of object, e.g. "host group"
+	Names []string // Names that were not found, sorted
+}
+
+func (e *NotFoundError) Error() string {
+	kind := e.Kind
+	if len(e.Names) > 1 {
+		kind += "s"
+	}
+	return fmt.Sprintf("%s not found: %s", kind, strings.Join(e.Names, ", "))
+}
+
+// lookup fetches the IDs of the objects of a kind with the given names.
+type lookup func(ctx context.Context, client Client, names []string) (map[string]string, error)
+
+// HostGroupIDs returns the IDs of the host groups with the given names, by
+// name. With WithCreateMissingHostGroups the missing groups are created.
+func (r *Resolver) HostGroupIDs(ctx context.Context, names ...string) (map[string]string, error) {
+	ids, err := r.resolve(ctx, "host group", names, func(ctx context.Context, client Client, names []string) (map[string]string, error) {
+		groups, err := client.HostgroupGet(ctx, HostGroupGetParameters{
+			GetParameters: GetParameters{Output: []string{"groupid", "name"}, Filter: map[string]any{"name": names}},
+		})
+		return collectNames(groups, func(g HostGroup) (string, string) { return g.Name, g.GroupID }), err
+	})
+
+	var notFound *NotFoundError
+	if !r.createHostGroups || !errors.As(err, &notFound) {
+		return ids, err
+	}
+
+	groups := make([]HostGroup, 0, len(notFound.Names))
+	for _, name := range notFound.Names {
+		groups = append(groups, HostGroup{Name: name})
+	}
+	resp, err := r.client.HostgroupCreate(ctx, groups)
+	if err != nil {
+		return nil, fmt.Errorf("creating host groups: %w", err)
+	}
+	if len(resp.GroupIDs) != len(groups) {
+		return nil, fmt.Errorf("creating host groups: got %d IDs for %d groups", len(resp.GroupIDs), len(groups))
+	}
+
+	created := make(map[string]string, len(groups))
+	for i, name := range notFound.Names {
+		created[name] = resp.GroupIDs[i]
+		ids[name] = resp.GroupIDs[i]
+	}
+	r.store("host group", created)
+
+	return ids, nil
+}
+
+// TemplateIDs returns the IDs of the templates with the given technical
+// names, by name.
+func (r *Resolver) TemplateIDs(ctx context.Context, names ...string) (map[string]string, error) {
+	return r.resolve(ctx, "template", names, func(ctx context.Context, client Client, names []string) (map[string]string, error) {
+		templates, err := client.TemplateGet(ctx, TemplateGetParameters{
+			GetParameters: GetParameters{Output: []string{"templateid", "host"}, Filter: map[string]any{"host": names}},
+		})
+		return collectNames(templates, func(t Template) (string, string) { return t.Host, t.TemplateID }), err
+	})
+}
+
+// ProxyIDs returns the IDs of the proxies with the given names, by name.
+func (r *Resolver) ProxyIDs(ctx context.Context, names ...string) (map[string]string, error) {
+	return r.resolve(ctx, "proxy", names, func(ctx context.Context, client Client, names []string) (map[string]string, error) {
+		proxies, err := client.ProxyGet(ctx, ProxyGetParameters{
+			GetParameters: GetParameters{Output: []string{"proxyid", "name"}, Filter: map[string]any{"name": names}},
+		})
+		return collectNames(proxies, func(p Proxy) (string, string) { return p.Name, p.ProxyID }), err
+	})
+}
+
+// ProxyGroupIDs returns the IDs of the proxy groups with the given names, by
+// name.
+func (r *Resolver) ProxyGroupIDs(ctx context.Context, names ...string) (map[string]string, error) {
+	return r.resolve(ctx, "proxy group", names, func(ctx context.Context, client Client, names []string) (map[string]string, error) {
+		groups, err := client.ProxyGroupGet(ctx, ProxyGroupGetParameters{
+			GetParameters: GetParameters{Output: []string{"proxy_groupid", "name"}, Filter: map[string]any{"name": names}},
+		})
+		return collectNames(groups, func(g ProxyGroup) (string, string) { return g.Name, g.ProxyGroupID }), err
+	})
+}
+
+// HostIDs returns the IDs of the hosts with the given technical names, by
+// name.
+func (r *Resolver) HostIDs(ctx context.Context, names ...string) (map[string]string, error) {
+	return r.resolve(ctx, "host", names, func(ctx context.Context, client Client, names []string) (map[string]string, error) {
+		hosts, err := client.HostGet(ctx, HostGetParameters{
+			GetParameters: GetParameters{Output: []string{"hostid", "host"}, Filter: map[string]any{"host": names}},
+		})
+		return collectNames(hosts, func(h Host) (string, string) { return h.Host, h.HostID }), err
+	})
+}
+
+// HostGroupID returns the ID of the host group with the given name.
+func (r *Resolver) HostGroupID(ctx context.Context, name string) (string, error) {
+	return single(r.HostGroupIDs(ctx, name))
+}
+
+// TemplateID returns the ID of the template with the given technical name.
+func (r *Resolver) TemplateID(ctx context.Context, name string) (string, error) {
+	return single(r.TemplateIDs(ctx, name))
+}
+
+// ProxyID returns the ID of the proxy with the given name.
+func (r *Resolver) ProxyID(ctx context.Context, name string) (string, error) {
+	return single(r.ProxyIDs(ctx, name))
+}
+
+// ProxyGroupID returns the ID of the proxy group with the given name.
+func (r *Resolver) ProxyGroupID(ctx context.Context, name string) (string, error) {
+	return single(r.ProxyGroupIDs(ctx, name))
+}
+
+// HostID returns the ID of the host with the given technical name.
+func (r *Resolver) HostID(ctx context.Context, name string) (string, error) {
+	return single(r.HostIDs(ctx, name))
+}
+
+// Invalidate empties the cache, e.g. after objects were renamed or deleted.
+func (r *Resolver) Invalidate() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	clear(r.cache)
+}
+
+// resolve returns the IDs of names from the cache, and looks up the others
+// in a single call. If some names are still missing, the IDs found are
+// returned along with a *NotFoundError.
+func (r *Resolver) resolve(ctx context.Context, kind string, names []string, fetch lookup) (map[string]string, error) {
+	ids := make(map[string]string, len(names))
+	var missing []string
+
+	r.mu.Lock()
+	now := time.Now()
+	for _, name := range names {
+		if entry, ok := r.cache[kind][name]; ok && now.Before(entry.expires) {
+			ids[name] = entry.id
+		} else if !slices.Contains(missing, name) {
+			missing = append(missing, name)
+		}
+	}
+	r.mu.Unlock()
+
+	if len(missing) > 0 {
+		found, err := fetch(ctx, r.client, missing)
+		if err != nil {
+			return nil, fmt.Errorf("resolving %s names: %w", kind, err)
+		}
+		r.store(kind, found)
+
+		missing = slices.DeleteFunc(missing, func(name string) bool {
+			id, ok := found[name]
+			if ok {
+				ids[name] = id
+			}
+			return ok
+		})
+	}
+
+	if len(missing) > 0 {
+		slices.Sort(missing)
+		return ids, &NotFoundError{Kind: kind, Names: missing}
+	}
+	return ids, nil
+}
+
+func (r *Resolver) store(kind string, ids map[string]string) {
+	if r.ttl <= 0 {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.cache[kind] == nil {
+		r.cache[kind] = make(map[string]resolved)
+	}
+	expires := time.Now().Add(r.ttl)
+	for name, id := range ids {
+		r.cache[kind][name] = resolved{id: id, expires: expires}
+	}
+}
+
+// collectNames maps the names of objects to their IDs.
+func collectNames[T any](objects []T, nameAndID func(T) (string, string)) map[string]string {
+	ids := make(map[string]string, len(objects))
+	for _, o := range objects {
+		name, id := nameAndID(o)
+		ids[name] = id
+	}
+	return ids
+}
+
+func single(ids map[string]string, err error) (string, error) {
+	if err != nil {
+		return "", err
+	}
+	for _, id := range ids {
+		return id, nil
+	}
+	return "", nil
+}
@@ -0,0 +1,141 @@
+package zabbix_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"slices"
+	"sync/atomic"
+	"testing"
+
+	zabbix "github.com/nimok/nim-go-zabbix"
+)
+
+// countingTransport counts the requests sent through it.
+type countingTransport struct {
+	count atomic.Int32
+}
+
+func (c *countingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	c.count.Add(1)
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+func TestResolver(t *testing.T) {
+	ctx := context.Background()
+	transport := &countingTransport{}
+
+	client, err := zabbix.NewClient(url, zabbix.WithUserPass(user, passwd),
+		zabbix.WithHTTPClient(&http.Client{Transport: transport}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Authenticate
+	if err := client.Authenticate(); err != nil {
+		t.Fatal("Initial auth failed:", err)
+	}
+
+	resolver := zabbix.NewResolver(client)
+
+	groups, err := resolver.HostGroupIDs(ctx, "Zabbix servers", "Linux servers", "Zabbix servers")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(groups) != 2 || groups["Zabbix servers"] == "" || groups["Linux servers"] == "" {
+		t.Fatalf("unexpected host group IDs: %v", groups)
+	}
+
+	hostID, err := resolver.HostID(ctx, "Zabbix server")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if hostID == "" {
+		t.Fatal("no host ID")
+	}
+
+	// Cached names are not looked up again
+	calls := transport.count.Load()
+	if id, err := resolver.HostGroupID(ctx, "Zabbix servers"); err != nil || id != groups["Zabbix servers"] {
+		t.Fatalf("got %q, %v; want %q", id, err, groups["Zabbix servers"])
+	}
+	if transport.count.Load() != calls {
+		t.Fatal("cached host group was looked up again")
+	}
+
+	resolver.Invalidate()
+	if _, err := resolver.HostGroupID(ctx, "Zabbix servers"); err != nil {
+		t.Fatal(err)
+	}
+	if transport.count.Load() != calls+1 {
+		t.Fatal("host group was not looked up after Invalidate")
+	}
+}
+
+func TestResolverNotFound(t *testing.T) {
+	ctx := context.Background()
+
+	client, err := zabbix.NewClient(url, zabbix.WithUserPass(user, passwd))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Authenticate
+	if err := client.Authenticate(); err != nil {
+		t.Fatal("Initial auth failed:", err)
+	}
+
+	resolver := zabbix.NewResolver(client)
+
+	ids, err := resolver.TemplateIDs(ctx, "no-such-template-b", "ICMP Ping", "no-such-template-a")
+
+	var notFound *zabbix.NotFoundError
+	if !errors.As(err, &notFound) {
+		t.Fatalf("got %v, want a NotFoundError", err)
+	}
+	if notFound.Kind != "template" || !slices.Equal(notFound.Names, []string{"no-such-template-a", "no-such-template-b"}) {
+		t.Fatalf("unexpected error: %v", notFound)
+	}
+	if err.Error() != "templates not found: no-such-template-a, no-such-template-b" {
+		t.Fatalf("unexpected error message: %v", err)
+	}
+	if ids["ICMP Ping"] == "" {
+		t.Fatal("found template missing from partial result")
+	}
+
+	if _, err := resolver.ProxyGroupID(ctx, "no-such-proxy-group"); !errors.As(err, &notFound) {
+		t.Fatalf("got %v, want a NotFoundError", err)
+	}
+	if _, err := resolver.ProxyID(ctx, "no-such-proxy"); !errors.As(err, &notFound) {
+		t.Fatalf("got %v, want a NotFoundError", err)
+	}
+}
+
+func TestResolverCreateMissingHostGroups(t *testing.T) {
+	ctx := context.Background()
+
+	client, err := zabbix.NewClient(url, zabbix.WithUserPass(user, passwd))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Authenticate
+	if err := client.Authenticate(); err != nil {
+		t.Fatal("Initial auth failed:", err)
+	}
+
+	resolver := zabbix.NewResolver(client, zabbix.WithCreateMissingHostGroups())
+
+	groups, err := resolver.HostGroupIDs(ctx, "Linux servers", "resolver-created-group")
+	if err != nil {
+		t.Fatal(err)
+	}
+	created := groups["resolver-created-group"]
+	if created == "" || groups["Linux servers"] == "" {
+		t.Fatalf("unexpected host group IDs: %v", groups)
+	}
+
+	if _, err := client.HostgroupDelete(ctx, []string{created}); err != nil {
+		t.Fatal(err)
+	}
+}
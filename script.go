@@ -0,0 +1,223 @@
+package zabbix
+
+import "context"
+
+// Types of scripts.
+const (
+	ScriptTypeScript  = 0
+	ScriptTypeIPMI    = 1
+	ScriptTypeSSH     = 2
+	ScriptTypeTelnet  = 3
+	ScriptTypeWebhook = 5
+	ScriptTypeURL     = 6
+)
+
+// Scopes of scripts, which tell where they can be run from.
+const (
+	ScriptScopeAction = 1 // Action operation
+	ScriptScopeHost   = 2 // Manual host action
+	ScriptScopeEvent  = 4 // Manual event action
+)
+
+// Where scripts of type ScriptTypeScript run.
+const (
+	ScriptExecuteOnAgent       = 0
+	ScriptExecuteOnServer      = 1
+	ScriptExecuteOnServerProxy = 2
+)
+
+// Script represents a global script in Zabbix.
+type Script struct {
+	ScriptID          string            `json:"scriptid,omitempty"`           // ID of the script; read-only, required for update operations
+	Name              string            `json:"name,omitempty"`               // Name of the script; required for create operations
+	Type              FlexInt           `json:"type,omitempty"`               // Type of the script, one of the ScriptType constants; required for create operations, sent on create even if 0
+	Scope             FlexInt           `json:"scope,omitempty"`              // Scope of the script, one of the ScriptScope constants; default is ScriptScopeAction
+	Command           string            `json:"command,omitempty"`            // Command to run, or JavaScript code of webhooks; required unless a URL script
+	ExecuteOn         FlexInt           `json:"execute_on,omitempty"`         // Where scripts of type ScriptTypeScript run; sent on create even if 0
+	MenuPath          string            `json:"menu_path,omitempty"`          // Folders of the script in the frontend menus, e.g. "Diagnostics/"
+	Description       string            `json:"description,omitempty"`        // Description of the script
+	GroupID           string            `json:"groupid,omitempty"`            // ID of the host group the script is limited to; 0 for all
+	UserGroupID       string            `json:"usrgrpid,omitempty"`           // ID of the user group allowed to run the script; 0 for all
+	HostAccess        FlexInt           `json:"host_access,omitempty"`        // Permission needed on the host; 2 (default) read, 3 write
+	Confirmation      string            `json:"confirmation,omitempty"`       // Confirmation asked in the frontend before running the script
+	Timeout           string            `json:"timeout,omitempty"`            // Timeout of webhooks, e.g. "30s"
+	Port              string            `json:"port,omitempty"`               // Port of SSH and Telnet scripts
+	AuthType          FlexInt           `json:"authtype,omitempty"`           // Authentication of SSH scripts; 0 password, 1 public key
+	Username          string            `json:"username,omitempty"`           // User name of SSH and Telnet scripts
+	Password          string            `json:"password,omitempty"`           // Password of SSH and Telnet scripts
+	PublicKey         string            `json:"publickey,omitempty"`          // Public key file of SSH scripts
+	PrivateKey        string            `json:"privatekey,omitempty"`         // Private key file of SSH scripts
+	Parameters        []ScriptParameter `json:"parameters,omitempty"`         // Parameters of webhooks
+	URL               string            `json:"url,omitempty"`                // URL opened by URL scripts; Zabbix 7.0 and later
+	NewWindow         FlexInt           `json:"new_window,omitempty"`         // Whether URL scripts open in a new window; 0 no, 1 (default) yes
+	ManualInput       FlexInt           `json:"manualinput,omitempty"`        // Whether the script asks for input; 0 (default) no, 1 yes; Zabbix 7.0 and later
+	ManualInputPrompt string            `json:"manualinput_prompt,omitempty"` // Prompt of the input, for ManualInput
+}
+
+// ScriptParameter is a parameter passed to a webhook.
+type ScriptParameter struct {
+	Name  string `json:"name"`            // Name of the parameter
+	Value string `json:"value,omitempty"` // Value of the parameter, which may contain macros
+}
+
+type ScriptGetParameters struct {
+	GetParameters
+
+	ScriptIDs        []string `json:"scriptids,omitempty"`
+	HostIDs          []string `json:"hostids,omitempty"`
+	GroupIDs         []string `json:"groupids,omitempty"`
+	UserGroupIDs     []string `json:"usrgrpids,omitempty"`
+	SelectHostGroups any      `json:"selectHostGroups,omitempty"`
+	SelectHosts      any      `json:"selectHosts,omitempty"`
+	SelectActions    any      `json:"selectActions,omitempty"`
+}
+
+// ScriptExecuteParameters runs a script on a host, for ScriptScopeHost
+// scripts, or for an event, for ScriptScopeEvent scripts.
+type ScriptExecuteParameters struct {
+	ScriptID    string `json:"scriptid"`              // ID of the script
+	HostID      string `json:"hostid,omitempty"`      // ID of the host to run the script on
+	EventID     string `json:"eventid,omitempty"`     // ID of the event to run the script for
+	ManualInput string `json:"manualinput,omitempty"` // Input of scripts asking for it; Zabbix 7.0 and later
+}
+
+// ScriptExecuteResponse is the output of a script. Scripts that fail return
+// an error instead.
+type ScriptExecuteResponse struct {
+	Response string       `json:"response"`        // Always "success"
+	Value    string       `json:"value"`           // Output of the script
+	Debug    *ScriptDebug `json:"debug,omitempty"` // Log of webhooks
+}
+
+// ScriptDebug is the log of a webhook run.
+type ScriptDebug struct {
+	Logs []ScriptLog `json:"logs"` // Messages logged by the webhook
+	MS   FlexInt     `json:"ms"`   // Time the webhook took in milliseconds
+}
+
+// ScriptLog is a message logged by a webhook.
+type ScriptLog struct {
+	Level   FlexInt `json:"level"`   // Log level, from 1 critical to 5 trace
+	MS      FlexInt `json:"ms"`      // Time since the start of the webhook in milliseconds
+	Message string  `json:"message"` // Logged message
+}
+
+type ScriptCreateResponse struct {
+	ScriptIDs []string `json:"scriptids"` // IDs of the created scripts
+}
+
+type ScriptUpdateResponse struct {
+	ScriptIDs []string `json:"scriptids"` // IDs of the updated scripts
+}
+
+type ScriptDeleteResponse struct {
+	ScriptIDs []string `json:"scriptids"` // IDs of the deleted scripts
+}
+
+func (z *zabbixClient) ScriptGet(ctx context.Context, params ScriptGetParameters) ([]Script, error) {
+
+	var result []Script
+
+	err := z.makeRequest(ctx, "script.get", params, &result)
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// ScriptCreate always sends the type of the scripts, and where scripts of
+// type ScriptTypeScript run, so that ScriptTypeScript and
+// ScriptExecuteOnAgent aren't dropped as empty.
+func (z *zabbixClient) ScriptCreate(ctx context.Context, params []Script) (*ScriptCreateResponse, error) {
+
+	var result ScriptCreateResponse
+
+	scripts := make([]any, len(params))
+	for i, script := range params {
+		fields := []string{"type"}
+		if script.Type == ScriptTypeScript {
+			fields = append(fields, "execute_on")
+		}
+		var err error
+		if scripts[i], err = withFields(script, fields); err != nil {
+			return nil, err
+		}
+	}
+
+	err := z.makeRequest(ctx, "script.create", scripts, &result)
+	if err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+func (z *zabbixClient) ScriptUpdate(ctx context.Context, params Script, fields ...string) (*ScriptUpdateResponse, error) {
+
+	var result ScriptUpdateResponse
+
+	update, err := withFields(params, fields)
+	if err != nil {
+		return nil, err
+	}
+
+	err = z.makeRequest(ctx, "script.update", update, &result)
+	if err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+func (z *zabbixClient) ScriptDelete(ctx context.Context, params []string) (*ScriptDeleteResponse, error) {
+
+	var result ScriptDeleteResponse
+
+	err := z.makeRequest(ctx, "script.delete", params, &result)
+	if err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+func (z *zabbixClient) ScriptExecute(ctx context.Context, params ScriptExecuteParameters) (*ScriptExecuteResponse, error) {
+
+	var result ScriptExecuteResponse
+
+	err := z.makeRequest(ctx, "script.execute", params, &result)
+	if err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// ScriptGetScriptsByHosts returns the scripts the current user can run on
+// each of the given hosts, by host ID.
+func (z *zabbixClient) ScriptGetScriptsByHosts(ctx context.Context, hostIDs []string) (map[string][]Script, error) {
+
+	var result map[string][]Script
+
+	err := z.makeRequest(ctx, "script.getscriptsbyhosts", hostIDs, &result)
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// ScriptGetScriptsByEvents returns the scripts the current user can run for
+// each of the given events, by event ID.
+func (z *zabbixClient) ScriptGetScriptsByEvents(ctx context.Context, eventIDs []string) (map[string][]Script, error) {
+
+	var result map[string][]Script
+
+	err := z.makeRequest(ctx, "script.getscriptsbyevents", eventIDs, &result)
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
@@ -0,0 +1,84 @@
+package zabbix_test
+
+import (
+	"context"
+	"testing"
+
+	zabbix "github.com/nimok/nim-go-zabbix"
+)
+
+func TestScriptCreateAndExecute(t *testing.T) {
+	ctx := context.Background()
+
+	client, err := zabbix.NewClient(url, zabbix.WithUserPass(user, passwd))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Authenticate
+	if err := client.Authenticate(); err != nil {
+		t.Fatal("Initial auth failed:", err)
+	}
+
+	hosts, err := client.HostGet(ctx, zabbix.HostGetParameters{
+		GetParameters: zabbix.GetParameters{Filter: map[string]any{"host": "Zabbix server"}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(hosts) == 0 {
+		t.Fatal("No hosts found")
+	}
+	hostID := hosts[0].HostID
+
+	// Type 0 is sent even though it is empty
+	resp, err := client.ScriptCreate(ctx, []zabbix.Script{{
+		Name:      "testing-script-uptime",
+		Type:      zabbix.ScriptTypeScript,
+		Scope:     zabbix.ScriptScopeHost,
+		ExecuteOn: zabbix.ScriptExecuteOnServer,
+		Command:   "uptime",
+		MenuPath:  "Diagnostics/",
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	scriptID := resp.ScriptIDs[0]
+	defer client.ScriptDelete(ctx, []string{scriptID})
+
+	scripts, err := client.ScriptGet(ctx, zabbix.ScriptGetParameters{ScriptIDs: []string{scriptID}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(scripts) != 1 || scripts[0].Type != zabbix.ScriptTypeScript || scripts[0].ExecuteOn != zabbix.ScriptExecuteOnServer {
+		t.Fatalf("unexpected scripts %+v", scripts)
+	}
+
+	byHost, err := client.ScriptGetScriptsByHosts(ctx, []string{hostID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	found := false
+	for _, script := range byHost[hostID] {
+		found = found || script.ScriptID == scriptID
+	}
+	if !found {
+		t.Errorf("expected script %s to be available on host %s, got %+v", scriptID, hostID, byHost)
+	}
+
+	result, err := client.ScriptExecute(ctx, zabbix.ScriptExecuteParameters{ScriptID: scriptID, HostID: hostID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Response != "success" {
+		t.Errorf("unexpected response %+v", result)
+	}
+
+	// Host scripts can't be run for events
+	if _, err := client.ScriptUpdate(ctx, zabbix.Script{ScriptID: scriptID, Scope: zabbix.ScriptScopeEvent}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.ScriptExecute(ctx, zabbix.ScriptExecuteParameters{ScriptID: scriptID, HostID: hostID}); err == nil {
+		t.Error("expected an event script run on a host to fail")
+	}
+}
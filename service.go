@@ -0,0 +1,177 @@
+package zabbix
+
+import "context"
+
+// ServiceStatusOK is the status of a service without problems. Otherwise the
+// status of a service is the Severity of its most critical problem.
+const ServiceStatusOK = -1
+
+// Status calculation algorithms of a service, from the statuses of its
+// children.
+const (
+	ServiceAlgorithmOK          = 0 // Always OK
+	ServiceAlgorithmAllChildren = 1 // Most critical if all children have problems
+	ServiceAlgorithmOneChild    = 2 // Most critical of the children
+)
+
+// Propagation rules of the status of a service to its parents.
+const (
+	ServicePropagationAsIs     = 0 // Propagate the status as is
+	ServicePropagationIncrease = 1 // Increase the status by PropagationValue
+	ServicePropagationDecrease = 2 // Decrease the status by PropagationValue
+	ServicePropagationIgnore   = 3 // Ignore the service
+	ServicePropagationFixed    = 4 // Propagate PropagationValue as the status
+)
+
+// Condition types of the status rules of a service. N children or N% of the
+// children have the status LimitStatus or above (0-3), or their weight or
+// percentage of the weight is at least N (4-7).
+const (
+	StatusRuleAtLeastNChildren      = 0
+	StatusRuleAtLeastNPercent       = 1
+	StatusRuleLessThanNChildren     = 2
+	StatusRuleLessThanNPercent      = 3
+	StatusRuleAtLeastWeight         = 4
+	StatusRuleAtLeastWeightPercent  = 5
+	StatusRuleLessThanWeight        = 6
+	StatusRuleLessThanWeightPercent = 7
+)
+
+// Operators of problem tags and SLA service tags.
+const (
+	TagOperatorEquals = 0
+	TagOperatorLike   = 2
+)
+
+// Service represents a business service in Zabbix 6.0 and later.
+type Service struct {
+	ServiceID        string              `json:"serviceid,omitempty"`         // ID of the service; read-only, required for update operations
+	Name             string              `json:"name,omitempty"`              // Name of the service; required for create operations
+	Algorithm        FlexInt             `json:"algorithm,omitempty"`         // Status calculation algorithm; required for create operations
+	SortOrder        FlexInt             `json:"sortorder,omitempty"`         // Position of the service used for sorting, 0 to 999; required for create operations
+	Weight           FlexInt             `json:"weight,omitempty"`            // Weight of the service, 0 to 1000000
+	PropagationRule  FlexInt             `json:"propagation_rule,omitempty"`  // Propagation rule of the status to the parents
+	PropagationValue FlexInt             `json:"propagation_value,omitempty"` // Status or number of levels for the propagation rule
+	Status           FlexInt             `json:"status,omitempty"`            // ServiceStatusOK or the severity of the service; read-only
+	Description      string              `json:"description,omitempty"`       // Description of the service
+	UUID             string              `json:"uuid,omitempty"`              // Universal unique identifier; read-only
+	CreatedAt        FlexInt64           `json:"created_at,omitempty"`        // Unix timestamp when the service was created; read-only
+	Readonly         FlexInt             `json:"readonly,omitempty"`          // Whether the user can't edit the service; read-only
+	Parents          []Service           `json:"parents,omitempty"`           // Parent services, by ServiceID
+	Children         []Service           `json:"children,omitempty"`          // Child services, by ServiceID
+	Tags             []Tag               `json:"tags,omitempty"`              // Tags of the service, matched by the service tags of SLAs
+	ProblemTags      []ServiceProblemTag `json:"problem_tags,omitempty"`      // Tags of the problems affecting the service
+	StatusRules      []ServiceStatusRule `json:"status_rules,omitempty"`      // Additional rules computing the status from the children
+}
+
+// ServiceProblemTag matches the problems affecting a service by tag.
+type ServiceProblemTag struct {
+	Tag      string  `json:"tag"`             // Problem tag name
+	Operator FlexInt `json:"operator"`        // TagOperatorEquals or TagOperatorLike
+	Value    string  `json:"value,omitempty"` // Problem tag value
+}
+
+// ServiceStatusRule sets the status of a service to NewStatus when its
+// children match the condition.
+type ServiceStatusRule struct {
+	Type        FlexInt `json:"type"`         // Condition type, one of the StatusRule constants
+	LimitValue  FlexInt `json:"limit_value"`  // N of the condition: a number, a percentage or a weight
+	LimitStatus FlexInt `json:"limit_status"` // Status of the children counted, ServiceStatusOK or a severity
+	NewStatus   FlexInt `json:"new_status"`   // Severity of the service if the condition is met
+}
+
+type ServiceGetParameters struct {
+	GetParameters
+
+	ServiceIDs           []string        `json:"serviceids,omitempty"`
+	ParentIDs            []string        `json:"parentids,omitempty"`
+	DeepParentIDs        bool            `json:"deep_parentids,omitempty"` // Include all the descendants of ParentIDs
+	ChildIDs             []string        `json:"childids,omitempty"`
+	SLAIDs               []string        `json:"slaids,omitempty"`
+	EvalType             FlexInt         `json:"evaltype,omitempty"`
+	Tags                 []ProblemGetTag `json:"tags,omitempty"`
+	ProblemTags          []ProblemGetTag `json:"problem_tags,omitempty"`
+	WithoutProblemTags   bool            `json:"without_problem_tags,omitempty"`
+	SelectChildren       any             `json:"selectChildren,omitempty"`
+	SelectParents        any             `json:"selectParents,omitempty"`
+	SelectTags           any             `json:"selectTags,omitempty"`
+	SelectProblemTags    any             `json:"selectProblemTags,omitempty"`
+	SelectStatusRules    any             `json:"selectStatusRules,omitempty"`
+	SelectProblemEvents  any             `json:"selectProblemEvents,omitempty"`
+	SelectStatusTimeline any             `json:"selectStatusTimeline,omitempty"`
+}
+
+type ServiceCreateResponse struct {
+	ServiceIDs []string `json:"serviceids"` // IDs of the created services
+}
+
+type ServiceUpdateResponse struct {
+	ServiceIDs []string `json:"serviceids"` // IDs of the updated services
+}
+
+type ServiceDeleteResponse struct {
+	ServiceIDs []string `json:"serviceids"` // IDs of the deleted services
+}
+
+func (z *zabbixClient) ServiceGet(ctx context.Context, params ServiceGetParameters) ([]Service, error) {
+
+	var result []Service
+
+	err := z.makeRequest(ctx, "service.get", params, &result)
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// ServiceCreate always sends the algorithm and sort order of the services,
+// which are required even if 0.
+func (z *zabbixClient) ServiceCreate(ctx context.Context, params []Service) (*ServiceCreateResponse, error) {
+
+	var result ServiceCreateResponse
+
+	services := make([]any, len(params))
+	for i, service := range params {
+		var err error
+		if services[i], err = withFields(service, []string{"algorithm", "sortorder"}); err != nil {
+			return nil, err
+		}
+	}
+
+	err := z.makeRequest(ctx, "service.create", services, &result)
+	if err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+func (z *zabbixClient) ServiceUpdate(ctx context.Context, params Service, fields ...string) (*ServiceUpdateResponse, error) {
+
+	var result ServiceUpdateResponse
+
+	update, err := withFields(params, fields)
+	if err != nil {
+		return nil, err
+	}
+
+	err = z.makeRequest(ctx, "service.update", update, &result)
+	if err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+func (z *zabbixClient) ServiceDelete(ctx context.Context, params []string) (*ServiceDeleteResponse, error) {
+
+	var result ServiceDeleteResponse
+
+	err := z.makeRequest(ctx, "service.delete", params, &result)
+	if err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
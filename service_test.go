@@ -0,0 +1,118 @@
+package zabbix_test
+
+import (
+	"context"
+	"testing"
+
+	zabbix "github.com/nimok/nim-go-zabbix"
+)
+
+func TestServiceTree(t *testing.T) {
+	ctx := context.Background()
+
+	client, err := zabbix.NewClient(url, zabbix.WithUserPass(user, passwd))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Authenticate
+	if err := client.Authenticate(); err != nil {
+		t.Fatal("Initial auth failed:", err)
+	}
+
+	parentResp, err := client.ServiceCreate(ctx, []zabbix.Service{{
+		Name:      "testing-service-shop",
+		Algorithm: zabbix.ServiceAlgorithmOneChild,
+		Tags:      []zabbix.Tag{{Tag: "sla", Value: "shop"}},
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	parentID := parentResp.ServiceIDs[0]
+	defer client.ServiceDelete(ctx, []string{parentID})
+
+	// Algorithm and sort order 0 are sent even though they are empty
+	childResp, err := client.ServiceCreate(ctx, []zabbix.Service{{
+		Name:             "testing-service-checkout",
+		Algorithm:        zabbix.ServiceAlgorithmOK,
+		Weight:           10,
+		PropagationRule:  zabbix.ServicePropagationIncrease,
+		PropagationValue: 1,
+		Parents:          []zabbix.Service{{ServiceID: parentID}},
+		ProblemTags:      []zabbix.ServiceProblemTag{{Tag: "service", Operator: zabbix.TagOperatorEquals, Value: "checkout"}},
+		StatusRules: []zabbix.ServiceStatusRule{{
+			Type:        zabbix.StatusRuleAtLeastNChildren,
+			LimitValue:  1,
+			LimitStatus: zabbix.FlexInt(zabbix.SeverityHigh),
+			NewStatus:   zabbix.FlexInt(zabbix.SeverityDisaster),
+		}},
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	childID := childResp.ServiceIDs[0]
+	defer client.ServiceDelete(ctx, []string{childID})
+
+	services, err := client.ServiceGet(ctx, zabbix.ServiceGetParameters{
+		ParentIDs:         []string{parentID},
+		SelectParents:     []string{"serviceid"},
+		SelectProblemTags: "extend",
+		SelectStatusRules: "extend",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(services) != 1 || services[0].ServiceID != childID {
+		t.Fatalf("expected the child service, got %+v", services)
+	}
+	child := services[0]
+	if child.Status != zabbix.ServiceStatusOK || child.Weight != 10 || child.PropagationRule != zabbix.ServicePropagationIncrease {
+		t.Errorf("unexpected service %+v", child)
+	}
+	if len(child.Parents) != 1 || child.Parents[0].ServiceID != parentID {
+		t.Errorf("unexpected parents %+v", child.Parents)
+	}
+	if len(child.ProblemTags) != 1 || child.ProblemTags[0].Value != "checkout" {
+		t.Errorf("unexpected problem tags %+v", child.ProblemTags)
+	}
+	if len(child.StatusRules) != 1 || child.StatusRules[0].NewStatus != zabbix.FlexInt(zabbix.SeverityDisaster) {
+		t.Errorf("unexpected status rules %+v", child.StatusRules)
+	}
+
+	// Clear the weight and check the children of the parent
+	if _, err := client.ServiceUpdate(ctx, zabbix.Service{ServiceID: childID}, "weight"); err != nil {
+		t.Fatal(err)
+	}
+	services, err = client.ServiceGet(ctx, zabbix.ServiceGetParameters{
+		GetParameters:  zabbix.GetParameters{Sortfield: []string{"name"}},
+		ServiceIDs:     []string{parentID, childID},
+		SelectChildren: []string{"serviceid", "weight"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(services) != 2 || services[0].ServiceID != childID || services[0].Weight != 0 {
+		t.Fatalf("unexpected services %+v", services)
+	}
+	if children := services[1].Children; len(children) != 1 || children[0].ServiceID != childID {
+		t.Errorf("unexpected children %+v", children)
+	}
+}
+
+func TestServiceUpdateUnknownField(t *testing.T) {
+	ctx := context.Background()
+
+	client, err := zabbix.NewClient(url, zabbix.WithUserPass(user, passwd))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Authenticate
+	if err := client.Authenticate(); err != nil {
+		t.Fatal("Initial auth failed:", err)
+	}
+
+	if _, err := client.ServiceUpdate(ctx, zabbix.Service{ServiceID: "1"}, "wieght"); err == nil {
+		t.Fatal("expected an unknown field to fail")
+	}
+}
@@ -0,0 +1,280 @@
+package zabbix
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// Reporting periods of an SLA.
+const (
+	SLAPeriodDaily     = 0
+	SLAPeriodWeekly    = 1
+	SLAPeriodMonthly   = 2
+	SLAPeriodQuarterly = 3
+	SLAPeriodAnnually  = 4
+)
+
+// Statuses of an SLA.
+const (
+	SLAStatusDisabled = 0
+	SLAStatusEnabled  = 1
+)
+
+// SLA represents a service level agreement in Zabbix 6.0 and later.
+type SLA struct {
+	SLAID             string                `json:"slaid,omitempty"`              // ID of the SLA; read-only, required for update operations
+	Name              string                `json:"name,omitempty"`               // Name of the SLA; required for create operations
+	Period            FlexInt               `json:"period,omitempty"`             // Reporting period, one of the SLAPeriod constants; required for create operations
+	SLO               FlexFloat             `json:"slo,omitempty"`                // Service level objective in percent, e.g. 99.9; required for create operations
+	EffectiveDate     FlexInt64             `json:"effective_date,omitempty"`     // Unix timestamp from which the SLA is calculated
+	Timezone          string                `json:"timezone,omitempty"`           // Time zone of the reporting periods, e.g. "Europe/Riga"
+	Status            FlexInt               `json:"status,omitempty"`             // SLAStatusDisabled or SLAStatusEnabled
+	Description       string                `json:"description,omitempty"`        // Description of the SLA
+	ServiceTags       []SLAServiceTag       `json:"service_tags,omitempty"`       // Tags of the services the SLA applies to; required for create operations
+	Schedule          []SLASchedule         `json:"schedule,omitempty"`           // Weekly uptime schedule; 24x7 if empty
+	ExcludedDowntimes []SLAExcludedDowntime `json:"excluded_downtimes,omitempty"` // Downtimes not counted against the SLA
+}
+
+// SLAServiceTag matches the services an SLA applies to by tag.
+type SLAServiceTag struct {
+	Tag      string  `json:"tag"`             // Service tag name
+	Operator FlexInt `json:"operator"`        // TagOperatorEquals or TagOperatorLike
+	Value    string  `json:"value,omitempty"` // Service tag value
+}
+
+// SLASchedule is a weekly period of uptime, in seconds since Sunday 00:00,
+// e.g. 86400 to 118800 for Monday 00:00 to 09:00.
+type SLASchedule struct {
+	PeriodFrom FlexInt `json:"period_from"` // Start of the period, 0 to 604800
+	PeriodTo   FlexInt `json:"period_to"`   // End of the period, 0 to 604800
+}
+
+// SLAExcludedDowntime is a one-time downtime not counted against an SLA,
+// e.g. planned maintenance.
+type SLAExcludedDowntime struct {
+	Name       string    `json:"name"`        // Name of the downtime
+	PeriodFrom FlexInt64 `json:"period_from"` // Unix timestamp when the downtime starts
+	PeriodTo   FlexInt64 `json:"period_to"`   // Unix timestamp when the downtime ends
+}
+
+type SLAGetParameters struct {
+	GetParameters
+
+	SLAIDs                  []string `json:"slaids,omitempty"`
+	ServiceIDs              []string `json:"serviceids,omitempty"`
+	SelectSchedule          any      `json:"selectSchedule,omitempty"`
+	SelectExcludedDowntimes any      `json:"selectExcludedDowntimes,omitempty"`
+	SelectServiceTags       any      `json:"selectServiceTags,omitempty"`
+}
+
+type SLACreateResponse struct {
+	SLAIDs []string `json:"slaids"` // IDs of the created SLAs
+}
+
+type SLAUpdateResponse struct {
+	SLAIDs []string `json:"slaids"` // IDs of the updated SLAs
+}
+
+type SLADeleteResponse struct {
+	SLAIDs []string `json:"slaids"` // IDs of the deleted SLAs
+}
+
+// SLAGetSLIParameters selects the periods and services of sla.getsli. Without
+// PeriodFrom and PeriodTo, the last Periods periods up to now are returned.
+type SLAGetSLIParameters struct {
+	SLAID      string   `json:"slaid"`                 // ID of the SLA; required
+	PeriodFrom int64    `json:"period_from,omitempty"` // Unix timestamp of the first period
+	PeriodTo   int64    `json:"period_to,omitempty"`   // Unix timestamp of the last period
+	Periods    int      `json:"periods,omitempty"`     // Number of periods, 1 to 100; default is 20
+	ServiceIDs []string `json:"serviceids,omitempty"`  // Services to return; all those of the SLA if empty
+}
+
+// SLIResponse is the result of sla.getsli: SLI[p][s] is the SLI of service
+// ServiceIDs[s] during Periods[p].
+type SLIResponse struct {
+	Periods    []SLIPeriod `json:"periods"`
+	ServiceIDs []string    `json:"serviceids"`
+	SLI        [][]SLI     `json:"sli"`
+}
+
+// SLIPeriod is a reporting period of an SLA.
+type SLIPeriod struct {
+	From time.Time // Start of the period
+	To   time.Time // End of the period
+}
+
+// SLI is the service level indicator of a service during a period.
+type SLI struct {
+	Uptime            time.Duration         // Time the service was up during the scheduled uptime
+	Downtime          time.Duration         // Time the service was down during the scheduled uptime
+	SLI               float64               // Uptime in percent of the scheduled uptime, excluding the excluded downtimes
+	ErrorBudget       time.Duration         // Downtime left before breaching the SLO; negative once breached
+	ExcludedDowntimes []SLAExcludedDowntime // Excluded downtimes that occurred during the period
+}
+
+// SLIResult is the SLI of a service during a period, as listed by Results.
+type SLIResult struct {
+	ServiceID string
+	Period    SLIPeriod
+	SLI       SLI
+}
+
+// Results returns the SLIs of the response by period, then by service, e.g.
+// to write them as rows of a report.
+func (r *SLIResponse) Results() []SLIResult {
+	var results []SLIResult
+	for p, period := range r.Periods {
+		if p >= len(r.SLI) {
+			break
+		}
+		for s, serviceID := range r.ServiceIDs {
+			if s >= len(r.SLI[p]) {
+				break
+			}
+			results = append(results, SLIResult{ServiceID: serviceID, Period: period, SLI: r.SLI[p][s]})
+		}
+	}
+	return results
+}
+
+// UnmarshalJSON accepts the service IDs sla.getsli returns as numbers.
+func (r *SLIResponse) UnmarshalJSON(data []byte) error {
+	type sliResponse SLIResponse
+
+	aux := struct {
+		*sliResponse
+		ServiceIDs []json.RawMessage `json:"serviceids"`
+	}{sliResponse: (*sliResponse)(r)}
+
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+
+	r.ServiceIDs = make([]string, len(aux.ServiceIDs))
+	for i, id := range aux.ServiceIDs {
+		n, err := parseInt(id)
+		if err != nil {
+			return fmt.Errorf("invalid SLI service ID: %v", err)
+		}
+		r.ServiceIDs[i] = fmt.Sprint(n)
+	}
+	return nil
+}
+
+func (p *SLIPeriod) UnmarshalJSON(data []byte) error {
+	var aux struct {
+		PeriodFrom json.RawMessage `json:"period_from"`
+		PeriodTo   json.RawMessage `json:"period_to"`
+	}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+
+	var err error
+	if p.From, err = parseUnixTime(aux.PeriodFrom, nil); err != nil {
+		return fmt.Errorf("invalid SLI period_from: %v", err)
+	}
+	if p.To, err = parseUnixTime(aux.PeriodTo, nil); err != nil {
+		return fmt.Errorf("invalid SLI period_to: %v", err)
+	}
+	return nil
+}
+
+func (s *SLI) UnmarshalJSON(data []byte) error {
+	var aux struct {
+		Uptime            FlexInt64             `json:"uptime"`
+		Downtime          FlexInt64             `json:"downtime"`
+		SLI               FlexFloat             `json:"sli"`
+		ErrorBudget       FlexInt64             `json:"error_budget"`
+		ExcludedDowntimes []SLAExcludedDowntime `json:"excluded_downtimes"`
+	}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+
+	*s = SLI{
+		Uptime:            time.Duration(aux.Uptime) * time.Second,
+		Downtime:          time.Duration(aux.Downtime) * time.Second,
+		SLI:               float64(aux.SLI),
+		ErrorBudget:       time.Duration(aux.ErrorBudget) * time.Second,
+		ExcludedDowntimes: aux.ExcludedDowntimes,
+	}
+	return nil
+}
+
+func (z *zabbixClient) SLAGet(ctx context.Context, params SLAGetParameters) ([]SLA, error) {
+
+	var result []SLA
+
+	err := z.makeRequest(ctx, "sla.get", params, &result)
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// SLACreate always sends the period and SLO of the SLAs, which are required
+// even if 0.
+func (z *zabbixClient) SLACreate(ctx context.Context, params []SLA) (*SLACreateResponse, error) {
+
+	var result SLACreateResponse
+
+	slas := make([]any, len(params))
+	for i, sla := range params {
+		var err error
+		if slas[i], err = withFields(sla, []string{"period", "slo"}); err != nil {
+			return nil, err
+		}
+	}
+
+	err := z.makeRequest(ctx, "sla.create", slas, &result)
+	if err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+func (z *zabbixClient) SLAUpdate(ctx context.Context, params SLA, fields ...string) (*SLAUpdateResponse, error) {
+
+	var result SLAUpdateResponse
+
+	update, err := withFields(params, fields)
+	if err != nil {
+		return nil, err
+	}
+
+	err = z.makeRequest(ctx, "sla.update", update, &result)
+	if err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+func (z *zabbixClient) SLADelete(ctx context.Context, params []string) (*SLADeleteResponse, error) {
+
+	var result SLADeleteResponse
+
+	err := z.makeRequest(ctx, "sla.delete", params, &result)
+	if err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+func (z *zabbixClient) SLAGetSLI(ctx context.Context, params SLAGetSLIParameters) (*SLIResponse, error) {
+
+	var result SLIResponse
+
+	err := z.makeRequest(ctx, "sla.getsli", params, &result)
+	if err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
@@ -0,0 +1,132 @@
+package zabbix_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	zabbix "github.com/nimok/nim-go-zabbix"
+)
+
+func TestSLACreateAndGetSLI(t *testing.T) {
+	ctx := context.Background()
+
+	client, err := zabbix.NewClient(url, zabbix.WithUserPass(user, passwd))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Authenticate
+	if err := client.Authenticate(); err != nil {
+		t.Fatal("Initial auth failed:", err)
+	}
+
+	serviceResp, err := client.ServiceCreate(ctx, []zabbix.Service{{
+		Name: "testing-sla-service",
+		Tags: []zabbix.Tag{{Tag: "sla", Value: "testing"}},
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.ServiceDelete(ctx, serviceResp.ServiceIDs)
+
+	slaResp, err := client.SLACreate(ctx, []zabbix.SLA{{
+		Name:        "testing-sla",
+		Period:      zabbix.SLAPeriodMonthly,
+		SLO:         99.9,
+		Timezone:    "UTC",
+		Status:      zabbix.SLAStatusEnabled,
+		ServiceTags: []zabbix.SLAServiceTag{{Tag: "sla", Operator: zabbix.TagOperatorEquals, Value: "testing"}},
+		ExcludedDowntimes: []zabbix.SLAExcludedDowntime{{
+			Name:       "testing-maintenance",
+			PeriodFrom: zabbix.FlexInt64(time.Now().Add(-time.Hour).Unix()),
+			PeriodTo:   zabbix.FlexInt64(time.Now().Add(time.Hour).Unix()),
+		}},
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	slaID := slaResp.SLAIDs[0]
+	defer client.SLADelete(ctx, slaResp.SLAIDs)
+
+	slas, err := client.SLAGet(ctx, zabbix.SLAGetParameters{
+		ServiceIDs:              serviceResp.ServiceIDs,
+		SelectServiceTags:       "extend",
+		SelectExcludedDowntimes: "extend",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(slas) != 1 || slas[0].SLAID != slaID || slas[0].SLO != 99.9 {
+		t.Fatalf("unexpected SLAs %+v", slas)
+	}
+	if len(slas[0].ServiceTags) != 1 || len(slas[0].ExcludedDowntimes) != 1 {
+		t.Errorf("unexpected SLA %+v", slas[0])
+	}
+
+	// Clear the description, then disable the SLA
+	if _, err := client.SLAUpdate(ctx, zabbix.SLA{SLAID: slaID, Status: zabbix.SLAStatusDisabled}, "description", "status"); err != nil {
+		t.Fatal(err)
+	}
+
+	sli, err := client.SLAGetSLI(ctx, zabbix.SLAGetSLIParameters{SLAID: slaID, Periods: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(sli.Periods) != 1 || len(sli.ServiceIDs) != 1 || sli.ServiceIDs[0] != serviceResp.ServiceIDs[0] {
+		t.Fatalf("unexpected SLI %+v", sli)
+	}
+	results := sli.Results()
+	if len(results) != 1 || !results[0].Period.From.Before(results[0].Period.To) {
+		t.Fatalf("unexpected results %+v", results)
+	}
+}
+
+func TestSLIResponseUnmarshal(t *testing.T) {
+	data := `{
+		"periods": [
+			{"period_from": 1759276800, "period_to": 1761955200},
+			{"period_from": 1761955200, "period_to": 1764547200}
+		],
+		"serviceids": [3, "4"],
+		"sli": [
+			[
+				{"uptime": 2674800, "downtime": 3600, "sli": 99.86, "error_budget": -924, "excluded_downtimes": []},
+				{"uptime": 2678400, "downtime": 0, "sli": 100, "error_budget": 2678, "excluded_downtimes": []}
+			],
+			[
+				{"uptime": 2588400, "downtime": 0, "sli": 100, "error_budget": 2592, "excluded_downtimes": [
+					{"name": "Upgrade", "period_from": 1762000000, "period_to": 1762003600}
+				]},
+				{"uptime": 2592000, "downtime": 0, "sli": "100.0000", "error_budget": "2592", "excluded_downtimes": []}
+			]
+		]
+	}`
+
+	var sli zabbix.SLIResponse
+	if err := json.Unmarshal([]byte(data), &sli); err != nil {
+		t.Fatal(err)
+	}
+	if len(sli.ServiceIDs) != 2 || sli.ServiceIDs[0] != "3" || sli.ServiceIDs[1] != "4" {
+		t.Errorf("unexpected service IDs %v", sli.ServiceIDs)
+	}
+
+	results := sli.Results()
+	if len(results) != 4 {
+		t.Fatalf("expected 4 results, got %d", len(results))
+	}
+	first := results[0]
+	if first.ServiceID != "3" || !first.Period.From.Equal(time.Unix(1759276800, 0)) || !first.Period.To.Equal(time.Unix(1761955200, 0)) {
+		t.Errorf("unexpected result %+v", first)
+	}
+	if first.SLI.Downtime != time.Hour || first.SLI.SLI != 99.86 || first.SLI.ErrorBudget != -924*time.Second {
+		t.Errorf("unexpected SLI %+v", first.SLI)
+	}
+	if third := results[2]; third.ServiceID != "3" || len(third.SLI.ExcludedDowntimes) != 1 || third.SLI.ExcludedDowntimes[0].Name != "Upgrade" {
+		t.Errorf("unexpected result %+v", third)
+	}
+	if last := results[3]; last.SLI.SLI != 100 || last.SLI.ErrorBudget != 2592*time.Second {
+		t.Errorf("unexpected result %+v", last)
+	}
+}
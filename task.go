@@ -0,0 +1,158 @@
+package zabbix
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Types of tasks.
+const (
+	TaskTypeDiagnosticInfo     = 1
+	TaskTypeRefreshProxyConfig = 2
+	TaskTypeCheckNow           = 6
+)
+
+// Statuses of tasks.
+const (
+	TaskStatusNew        = 1
+	TaskStatusInProgress = 2
+	TaskStatusCompleted  = 3
+	TaskStatusExpired    = 4
+)
+
+// Task represents a task for the Zabbix server, such as checking an item
+// now.
+type Task struct {
+	TaskID  string      `json:"taskid,omitempty"`  // ID of the task; read-only
+	Type    FlexInt     `json:"type"`              // Type of the task, one of the TaskType constants; required
+	Status  FlexInt     `json:"status,omitempty"`  // Status of the task, one of the TaskStatus constants; read-only
+	Clock   FlexInt64   `json:"clock,omitempty"`   // Time when the task was created; read-only
+	TTL     FlexInt64   `json:"ttl,omitempty"`     // Time in seconds after which the task expires; read-only
+	ProxyID string      `json:"proxyid,omitempty"` // ID of the proxy the task is run on; read-only
+	Request TaskRequest `json:"request"`           // What the task is to do; required
+	Result  *TaskResult `json:"result,omitempty"`  // Result of the task once completed; read-only
+}
+
+// TaskRequest is what a task is to do. Only the fields of its type are set.
+type TaskRequest struct {
+	ItemID   string   `json:"itemid,omitempty"`   // ID of the item or LLD rule to check, for TaskTypeCheckNow
+	ProxyIDs []string `json:"proxyids,omitempty"` // IDs of the proxies to refresh, for TaskTypeRefreshProxyConfig
+}
+
+// TaskResult is the result of a completed task.
+type TaskResult struct {
+	Data   json.RawMessage `json:"data,omitempty"` // Data returned by the task, e.g. the diagnostic information
+	Status FlexInt         `json:"status"`         // 0 success, -1 failure
+}
+
+type TaskGetParameters struct {
+	GetParameters
+
+	TaskIDs []string `json:"taskids,omitempty"`
+}
+
+type TaskCreateResponse struct {
+	TaskIDs []string `json:"taskids"` // IDs of the created tasks
+}
+
+// NewCheckNowTasks returns the tasks checking the given items and LLD rules
+// now, for TaskCreate.
+//
+// The API doesn't tell when check now tasks are processed: task.get only
+// returns diagnostic information tasks, and the server deletes the others
+// once processed. WaitForChecks waits for the new values of the items
+// instead.
+func NewCheckNowTasks(itemIDs ...string) []Task {
+	tasks := make([]Task, len(itemIDs))
+	for i, id := range itemIDs {
+		tasks[i] = Task{Type: TaskTypeCheckNow, Request: TaskRequest{ItemID: id}}
+	}
+	return tasks
+}
+
+// TaskGet returns diagnostic information tasks, the only tasks task.get
+// returns.
+func (z *zabbixClient) TaskGet(ctx context.Context, params TaskGetParameters) ([]Task, error) {
+
+	var result []Task
+
+	err := z.makeRequest(ctx, "task.get", params, &result)
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (z *zabbixClient) TaskCreate(ctx context.Context, params []Task) (*TaskCreateResponse, error) {
+
+	var result TaskCreateResponse
+
+	err := z.makeRequest(ctx, "task.create", params, &result)
+	if err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// DefaultCheckPollInterval is the time between the item.get calls of
+// WaitForChecks when the given interval is 0.
+const DefaultCheckPollInterval = time.Second
+
+// WaitForChecks polls item.get every interval until each of the given items
+// has a value from since or later, e.g. after check now tasks were created
+// for them. Values are timed to the second, so since is best taken right
+// before TaskCreate.
+//
+// Items whose check fails keep their last value, so ctx should have a
+// deadline; WaitForChecks returns the error of ctx once it is done. IDs that
+// item.get doesn't return, such as those of LLD rules, fail at once: their
+// checks can't be observed. client must not cache item.get.
+func WaitForChecks(ctx context.Context, client Client, itemIDs []string, since time.Time, interval time.Duration) error {
+	if interval == 0 {
+		interval = DefaultCheckPollInterval
+	}
+
+	pending := itemIDs
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for len(pending) > 0 {
+		items, err := client.ItemGet(ctx, ItemGetParameters{
+			GetParameters: GetParameters{Output: []string{"itemid", "lastclock"}},
+			ItemIDs:       pending,
+		})
+		if err != nil {
+			return err
+		}
+
+		var unknown, waiting []string
+		for _, id := range pending {
+			i := slices.IndexFunc(items, func(item Item) bool { return item.ItemID == id })
+			switch {
+			case i < 0:
+				unknown = append(unknown, id)
+			case int64(items[i].LastClock) < since.Unix():
+				waiting = append(waiting, id)
+			}
+		}
+		if len(unknown) > 0 {
+			return fmt.Errorf("items not found: %s", strings.Join(unknown, ", "))
+		}
+		if pending = waiting; len(pending) == 0 {
+			break
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+	return nil
+}
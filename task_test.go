@@ -0,0 +1,102 @@
+package zabbix_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	zabbix "github.com/nimok/nim-go-zabbix"
+	"github.com/nimok/nim-go-zabbix/zabbixfake"
+)
+
+func TestCheckNow(t *testing.T) {
+	ctx := context.Background()
+
+	client, err := zabbix.NewClient(url, zabbix.WithUserPass(user, passwd))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Authenticate
+	if err := client.Authenticate(); err != nil {
+		t.Fatal("Initial auth failed:", err)
+	}
+
+	hosts, err := client.HostGet(ctx, zabbix.HostGetParameters{
+		GetParameters: zabbix.GetParameters{Filter: map[string]any{"host": "Zabbix server"}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(hosts) == 0 {
+		t.Fatal("No hosts found")
+	}
+
+	items, err := client.ItemGet(ctx, zabbix.ItemGetParameters{
+		GetParameters: zabbix.GetParameters{Filter: map[string]any{"key_": "agent.ping"}},
+		HostIDs:       []string{hosts[0].HostID},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(items) == 0 {
+		t.Fatal("No items found")
+	}
+
+	since := time.Now()
+	resp, err := client.TaskCreate(ctx, zabbix.NewCheckNowTasks(items[0].ItemID))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resp.TaskIDs) != 1 {
+		t.Fatalf("expected 1 task, got %v", resp.TaskIDs)
+	}
+
+	// task.get doesn't return check now tasks
+	tasks, err := client.TaskGet(ctx, zabbix.TaskGetParameters{TaskIDs: resp.TaskIDs})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tasks) != 0 {
+		t.Errorf("expected no tasks, got %+v", tasks)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, time.Minute)
+	defer cancel()
+	if err := zabbix.WaitForChecks(ctx, client, []string{items[0].ItemID}, since, 10*time.Millisecond); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestWaitForChecks(t *testing.T) {
+	since := time.Unix(1700000000, 0)
+	var calls int
+	client := &zabbixfake.Client{
+		ItemGetFunc: func(ctx context.Context, params zabbix.ItemGetParameters) ([]zabbix.Item, error) {
+			calls++
+			if calls == 1 {
+				return []zabbix.Item{{ItemID: "1", LastClock: 1700000000}, {ItemID: "2", LastClock: 1699999999}}, nil
+			}
+			if len(params.ItemIDs) != 1 || params.ItemIDs[0] != "2" {
+				t.Errorf("expected to poll item 2 only, got %v", params.ItemIDs)
+			}
+			return []zabbix.Item{{ItemID: "2", LastClock: 1700000001}}, nil
+		},
+	}
+
+	if err := zabbix.WaitForChecks(context.Background(), client, []string{"1", "2"}, since, time.Millisecond); err != nil {
+		t.Fatal(err)
+	}
+	if calls != 2 {
+		t.Errorf("expected 2 calls, got %d", calls)
+	}
+
+	// Items whose value never changes wait until ctx is done
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	err := zabbix.WaitForChecks(ctx, client, []string{"2"}, time.Unix(1800000000, 0), time.Millisecond)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected the deadline to be exceeded, got %v", err)
+	}
+}
@@ -46,6 +46,10 @@ type TemplateGetParameters struct {
 	SortField             any                 `json:"sortfield,omitempty"`
 }
 
+type TemplateUpdateResponse struct {
+	TemplateIDs []string `json:"templateids"` // IDs of the updated templates
+}
+
 func (z *zabbixClient) TemplateGet(ctx context.Context, params TemplateGetParameters) ([]Template, error) {
 
 	var result []Template
@@ -57,3 +61,20 @@ func (z *zabbixClient) TemplateGet(ctx context.Context, params TemplateGetParame
 
 	return result, nil
 }
+
+func (z *zabbixClient) TemplateUpdate(ctx context.Context, params Template, fields ...string) (*TemplateUpdateResponse, error) {
+
+	var result TemplateUpdateResponse
+
+	update, err := withFields(params, fields)
+	if err != nil {
+		return nil, err
+	}
+
+	err = z.makeRequest(ctx, "template.update", update, &result)
+	if err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
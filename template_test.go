@@ -42,3 +42,49 @@ func TestTemplateGet(t *testing.T) {
 	}
 
 }
+
+func TestTemplateUpdate(t *testing.T) {
+	ctx := context.Background()
+
+	client, err := zabbix.NewClient(url, zabbix.WithUserPass(user, passwd))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Authenticate
+	if err := client.Authenticate(); err != nil {
+		t.Fatal("Initial auth failed:", err)
+	}
+
+	templates, err := client.TemplateGet(ctx, zabbix.TemplateGetParameters{
+		GetParameters: zabbix.GetParameters{
+			Filter: map[string]any{"host": "ICMP Ping"},
+			Output: "extend",
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(templates) == 0 {
+		t.Fatal("No template found")
+	}
+	original := templates[0]
+
+	// Restore the description afterwards, even if it was empty
+	defer client.TemplateUpdate(ctx, zabbix.Template{TemplateID: original.TemplateID, Description: original.Description}, "description")
+
+	if _, err := client.TemplateUpdate(ctx, zabbix.Template{TemplateID: original.TemplateID}, "description"); err != nil {
+		t.Fatal(err)
+	}
+
+	templates, err = client.TemplateGet(ctx, zabbix.TemplateGetParameters{
+		GetParameters: zabbix.GetParameters{Output: "extend"},
+		TemplateIDs:   []string{original.TemplateID},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(templates) != 1 || templates[0].Description != "" {
+		t.Errorf("template not updated: %+v", templates)
+	}
+}
@@ -3,19 +3,24 @@ package zabbix
 import "context"
 
 type Token struct {
-	TokenID    string `json:"tokenid,omitempty"`
-	Name       string `json:"name,omitempty"`
-	UserID     string `json:"userid,omitempty"`
-	Token      string `json:"token,omitempty"`
-	Status     int    `json:"status,omitempty"`
-	LastAccess int64  `json:"lastaccess,omitempty"`
-	ExpiresAt  int64  `json:"expires_at,omitempty"`
+	TokenID     string    `json:"tokenid,omitempty"`
+	Name        string    `json:"name,omitempty"`
+	Description string    `json:"description,omitempty"`
+	UserID      string    `json:"userid,omitempty"`
+	Token       string    `json:"token,omitempty"`
+	Status      FlexInt   `json:"status,omitempty"`
+	LastAccess  FlexInt64 `json:"lastaccess,omitempty"`
+	ExpiresAt   FlexInt64 `json:"expires_at,omitempty"`
 }
 
 type TokenCreateResponse struct {
 	TokenIDs []string `json:"tokenids"`
 }
 
+type TokenUpdateResponse struct {
+	TokenIDs []string `json:"tokenids"`
+}
+
 type TokenGenerateParameters []string
 
 type TokenGenerateResponse struct {
@@ -41,6 +46,23 @@ func (z *zabbixClient) TokenCreate(ctx context.Context, params Token) (*TokenCre
 	return &result, nil
 }
 
+func (z *zabbixClient) TokenUpdate(ctx context.Context, params Token, fields ...string) (*TokenUpdateResponse, error) {
+
+	var result TokenUpdateResponse
+
+	update, err := withFields(params, fields)
+	if err != nil {
+		return nil, err
+	}
+
+	err = z.makeRequest(ctx, "token.update", update, &result)
+	if err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
 func (z *zabbixClient) TokenGenerate(ctx context.Context, params TokenGenerateParameters) ([]TokenGenerateResponse, error) {
 
 	var result []TokenGenerateResponse
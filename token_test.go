@@ -41,3 +41,32 @@ func TestTokenCreateAndGenerate(t *testing.T) {
 	}
 
 }
+
+func TestTokenUpdate(t *testing.T) {
+	ctx := context.Background()
+
+	client, err := zabbix.NewClient(url, zabbix.WithUserPass(user, passwd))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Authenticate
+	if err := client.Authenticate(); err != nil {
+		t.Fatal("Initial auth failed:", err)
+	}
+
+	tokenResp, err := client.TokenCreate(ctx, zabbix.Token{Name: "testing-update-token", UserID: "1", Status: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.TokenDelete(ctx, tokenResp.TokenIDs)
+
+	// Enable the token, status 0 being omitted unless named
+	updateResp, err := client.TokenUpdate(ctx, zabbix.Token{TokenID: tokenResp.TokenIDs[0], Status: 0}, "status")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(updateResp.TokenIDs) != 1 || updateResp.TokenIDs[0] != tokenResp.TokenIDs[0] {
+		t.Fatal("token id mismatch")
+	}
+}
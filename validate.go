@@ -0,0 +1,251 @@
+package zabbix
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// ValidationError is a rule broken by a field of an object.
+type ValidationError struct {
+	Field   string // Path of the field, e.g. "interfaces[0].details.community"
+	Message string // Rule broken, e.g. "is required for SNMPv2c"
+}
+
+func (e *ValidationError) Error() string {
+	return e.Field + " " + e.Message
+}
+
+// ValidationErrors are all the rules broken by an object, as returned by the
+// Validate methods.
+type ValidationErrors []*ValidationError
+
+func (e ValidationErrors) Error() string {
+	messages := make([]string, len(e))
+	for i, err := range e {
+		messages[i] = err.Error()
+	}
+	return "validation failed: " + strings.Join(messages, "; ")
+}
+
+// Unwrap returns the errors so they can be inspected with errors.As.
+func (e ValidationErrors) Unwrap() []error {
+	errs := make([]error, len(e))
+	for i, err := range e {
+		errs[i] = err
+	}
+	return errs
+}
+
+// validator collects the ValidationErrors of an object.
+type validator struct {
+	errs ValidationErrors
+}
+
+func (v *validator) fail(field, format string, args ...any) {
+	v.errs = append(v.errs, &ValidationError{Field: field, Message: fmt.Sprintf(format, args...)})
+}
+
+// nest adds the errors of a nested object at field.
+func (v *validator) nest(field string, err error) {
+	if errs, ok := err.(ValidationErrors); ok {
+		for _, e := range errs {
+			v.errs = append(v.errs, &ValidationError{Field: field + "." + e.Field, Message: e.Message})
+		}
+	}
+}
+
+func (v *validator) err() error {
+	if len(v.errs) == 0 {
+		return nil
+	}
+	return v.errs
+}
+
+var (
+	macroPattern = regexp.MustCompile(`^\{\$[A-Z0-9_.]+(:.*)?\}$`)
+	pskPattern   = regexp.MustCompile(`^([0-9A-Fa-f]{2}){16,256}$`)
+)
+
+// Validate checks the rules of the fields of the host that depend on each
+// other, e.g. that ProxyID is set if MonitoredBy is a proxy, and the rules
+// of its interfaces, macros and tags. It returns ValidationErrors, or nil.
+//
+// The fields required for create operations only, such as Host, are not
+// checked. Hosts to update are checked with ValidateUpdate instead.
+func (h Host) Validate() error {
+	return h.validate(false)
+}
+
+// ValidateUpdate checks the host as Validate does, except for the fields
+// other fields require, e.g. ProxyID if MonitoredBy is a proxy: updates only
+// send the fields that change, and the server keeps the others. Hosts read
+// with HostGet and sent back pass too, although Zabbix doesn't return their
+// PSK. Interfaces are checked in full, since they replace the existing ones.
+func (h Host) ValidateUpdate() error {
+	return h.validate(true)
+}
+
+// validate checks the host, without the required fields if update is set.
+func (h Host) validate(update bool) error {
+	var v validator
+
+	switch h.MonitoredBy {
+	case MonitoredByServer:
+	case MonitoredByProxy:
+		if h.ProxyID == "" && !update {
+			v.fail("proxyid", "is required if monitored by a proxy")
+		}
+	case MonitoredByProxyGroup:
+		if h.ProxyGroupID == "" && !update {
+			v.fail("proxy_groupid", "is required if monitored by a proxy group")
+		}
+	default:
+		v.fail("monitored_by", "is invalid: %d", h.MonitoredBy)
+	}
+
+	switch h.TlsConnect {
+	case 0, TLSNoEncryption, TLSPSK, TLSCert:
+	default:
+		v.fail("tls_connect", "is invalid: %d", h.TlsConnect)
+	}
+	if h.TlsAccept < 0 || h.TlsAccept > TLSNoEncryption|TLSPSK|TLSCert {
+		v.fail("tls_accept", "is invalid: %d", h.TlsAccept)
+	}
+	if (h.TlsConnect == TLSPSK || h.TlsAccept&TLSPSK != 0) && !update {
+		if h.TlsPSKIdentity == "" {
+			v.fail("tls_psk_identity", "is required for PSK encryption")
+		}
+		if h.TlsPSK == "" {
+			v.fail("tls_psk", "is required for PSK encryption")
+		}
+	}
+	if h.TlsPSK != "" && !pskPattern.MatchString(h.TlsPSK) {
+		v.fail("tls_psk", "must be 32 to 512 hexadecimal digits")
+	}
+
+	for i, iface := range h.Interfaces {
+		v.nest(fmt.Sprintf("interfaces[%d]", i), iface.Validate())
+	}
+	for i, macro := range h.Macros {
+		if !macroPattern.MatchString(macro.Macro) {
+			v.fail(fmt.Sprintf("macros[%d].macro", i), "is invalid: %q", macro.Macro)
+		}
+	}
+	for i, tag := range h.Tags {
+		if tag.Tag == "" {
+			v.fail(fmt.Sprintf("tags[%d].tag", i), "is required")
+		}
+	}
+
+	return v.err()
+}
+
+// Validate checks the rules of the fields of the interface, and of its
+// details if it is an SNMP interface. It returns ValidationErrors, or nil.
+//
+// HostID is not checked since it is not set on the interfaces of a host.
+func (i HostInterface) Validate() error {
+	var v validator
+
+	switch i.Type {
+	case InterfaceTypeAgent, InterfaceTypeSNMP, InterfaceTypeIPMI, InterfaceTypeJMX:
+	default:
+		v.fail("type", "is invalid: %d", i.Type)
+	}
+
+	switch i.UseIP {
+	case UseIPOptionIP:
+		if i.IP == "" {
+			v.fail("ip", "is required if connecting by IP address")
+		}
+	case UseIPOptionDNS:
+		if i.DNS == "" {
+			v.fail("dns", "is required if connecting by DNS name")
+		}
+	default:
+		v.fail("useip", "is invalid: %d", i.UseIP)
+	}
+	if i.Port == "" {
+		v.fail("port", "is required")
+	}
+	if i.Main != MainInterfaceNo && i.Main != MainInterfaceYes {
+		v.fail("main", "is invalid: %d", i.Main)
+	}
+
+	if i.Type == InterfaceTypeSNMP {
+		v.nest("details", i.Details.Validate())
+	}
+
+	return v.err()
+}
+
+// Validate checks the rules of the fields of the SNMP details depending on
+// their version and, for SNMPv3, their security level. It returns
+// ValidationErrors, or nil.
+func (d InterfaceDetails) Validate() error {
+	var v validator
+
+	switch d.Version {
+	case SNMPv1, SNMPv2c:
+		if d.Community == "" {
+			v.fail("community", "is required for SNMPv1 and SNMPv2c")
+		}
+	case SNMPv3:
+		switch d.SecurityLevel {
+		case SecurityLevelNoAuthNoPriv:
+		case SecurityLevelAuthNoPriv:
+			if d.AuthPassphrase == "" {
+				v.fail("authpassphrase", "is required for security level authNoPriv")
+			}
+		case SecurityLevelAuthPriv:
+			if d.AuthPassphrase == "" {
+				v.fail("authpassphrase", "is required for security level authPriv")
+			}
+			if d.PrivPassphrase == "" {
+				v.fail("privpassphrase", "is required for security level authPriv")
+			}
+		default:
+			v.fail("securitylevel", "is invalid: %d", d.SecurityLevel)
+		}
+		if d.AuthProtocol < AuthProtocolMD5 || d.AuthProtocol > AuthProtocolSHA512 {
+			v.fail("authprotocol", "is invalid: %d", d.AuthProtocol)
+		}
+		if d.PrivProtocol < PrivProtocolDES || d.PrivProtocol > PrivProtocolAES256C {
+			v.fail("privprotocol", "is invalid: %d", d.PrivProtocol)
+		}
+	case 0:
+		v.fail("version", "is required")
+	default:
+		v.fail("version", "is invalid: %d", d.Version)
+	}
+	if d.Bulk != BulkDisabled && d.Bulk != BulkEnabled {
+		v.fail("bulk", "is invalid: %d", d.Bulk)
+	}
+
+	return v.err()
+}
+
+// WithValidation validates the hosts and interfaces passed to HostCreate,
+// HostUpdate and HostInterfaceCreate with their Validate methods, or
+// ValidateUpdate for HostUpdate, failing the calls with ValidationErrors
+// instead of sending them.
+func WithValidation() ClientOption {
+	return func(c *zabbixClient) {
+		c.validation = true
+	}
+}
+
+// validateHosts returns the ValidationErrors of hosts, with the index of the
+// host in the field paths if there are several.
+func validateHosts(hosts []Host) error {
+	if len(hosts) == 1 {
+		return hosts[0].Validate()
+	}
+
+	var v validator
+	for i, host := range hosts {
+		v.nest(fmt.Sprintf("[%d]", i), host.Validate())
+	}
+	return v.err()
+}
@@ -0,0 +1,178 @@
+package zabbix_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	zabbix "github.com/nimok/nim-go-zabbix"
+)
+
+// fields returns the field paths of the ValidationErrors of err.
+func fields(t *testing.T, err error) []string {
+	t.Helper()
+
+	var errs zabbix.ValidationErrors
+	if err == nil {
+		return nil
+	}
+	if !errors.As(err, &errs) {
+		t.Fatalf("got %T error %v, want ValidationErrors", err, err)
+	}
+	var fields []string
+	for _, e := range errs {
+		fields = append(fields, e.Field)
+	}
+	return fields
+}
+
+func TestHostValidate(t *testing.T) {
+	host := zabbix.Host{
+		Host:        "test-host",
+		MonitoredBy: zabbix.MonitoredByProxy,
+		TlsConnect:  zabbix.TLSPSK,
+		TlsAccept:   zabbix.TLSNoEncryption,
+		Interfaces: []zabbix.HostInterface{
+			{Type: zabbix.InterfaceTypeAgent, Main: zabbix.MainInterfaceYes, UseIP: zabbix.UseIPOptionIP, IP: "10.0.0.1", Port: "10050"},
+			{Type: zabbix.InterfaceTypeSNMP, Main: zabbix.MainInterfaceYes, UseIP: zabbix.UseIPOptionDNS, Port: "161",
+				Details: zabbix.InterfaceDetails{Version: zabbix.SNMPv2c}},
+		},
+		Macros: []zabbix.Macro{{Macro: "{$PORT}"}, {Macro: "PORT"}},
+		Tags:   []zabbix.Tag{{Value: "prod"}},
+	}
+	want := []string{
+		"proxyid",
+		"tls_psk_identity",
+		"tls_psk",
+		"interfaces[1].dns",
+		"interfaces[1].details.community",
+		"macros[1].macro",
+		"tags[0].tag",
+	}
+	if got := fields(t, host.Validate()); !reflect.DeepEqual(got, want) {
+		t.Errorf("got errors on %v, want %v", got, want)
+	}
+
+	host.ProxyID = "1"
+	host.TlsPSKIdentity = "PSK 001"
+	host.TlsPSK = "1f87b595725ac58dd977beef14b97461a7c1045b9a1c963065002c5473194952"
+	host.Interfaces[1].DNS = "switch.example.com"
+	host.Interfaces[1].Details.Community = "{$SNMP_COMMUNITY}"
+	host.Macros = host.Macros[:1]
+	host.Tags[0].Tag = "env"
+	if err := host.Validate(); err != nil {
+		t.Error(err)
+	}
+
+	host.TlsPSK = "secret"
+	if err := host.Validate(); err == nil || err.Error() != "validation failed: tls_psk must be 32 to 512 hexadecimal digits" {
+		t.Errorf("got error %v", err)
+	}
+}
+
+func TestHostValidateUpdate(t *testing.T) {
+	// The server keeps the PSK and the proxy of the host
+	host := zabbix.Host{HostID: "10084", MonitoredBy: zabbix.MonitoredByProxy, TlsAccept: zabbix.TLSPSK}
+	if err := host.ValidateUpdate(); err != nil {
+		t.Error(err)
+	}
+	if got := fields(t, host.Validate()); !reflect.DeepEqual(got, []string{"proxyid", "tls_psk_identity", "tls_psk"}) {
+		t.Errorf("got errors on %v", got)
+	}
+
+	host.TlsPSK = "secret"
+	host.Macros = []zabbix.Macro{{Macro: "PORT"}}
+	host.Interfaces = []zabbix.HostInterface{{Type: zabbix.InterfaceTypeAgent, Main: zabbix.MainInterfaceYes, UseIP: zabbix.UseIPOptionIP, Port: "10050"}}
+	if got := fields(t, host.ValidateUpdate()); !reflect.DeepEqual(got, []string{"tls_psk", "interfaces[0].ip", "macros[0].macro"}) {
+		t.Errorf("got errors on %v", got)
+	}
+}
+
+func TestInterfaceDetailsValidate(t *testing.T) {
+	for _, tc := range []struct {
+		details zabbix.InterfaceDetails
+		want    []string
+	}{
+		{zabbix.InterfaceDetails{}, []string{"version"}},
+		{zabbix.InterfaceDetails{Version: zabbix.SNMPv1, Community: "public"}, nil},
+		{zabbix.InterfaceDetails{Version: zabbix.SNMPv3}, nil},
+		{zabbix.InterfaceDetails{Version: zabbix.SNMPv3, SecurityLevel: zabbix.SecurityLevelAuthNoPriv}, []string{"authpassphrase"}},
+		{zabbix.InterfaceDetails{Version: zabbix.SNMPv3, SecurityLevel: zabbix.SecurityLevelAuthPriv, AuthPassphrase: "a"}, []string{"privpassphrase"}},
+		{zabbix.InterfaceDetails{Version: zabbix.SNMPv3, SecurityLevel: 3, AuthProtocol: 6}, []string{"securitylevel", "authprotocol"}},
+	} {
+		if got := fields(t, tc.details.Validate()); !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("%+v: got errors on %v, want %v", tc.details, got, tc.want)
+		}
+	}
+}
+
+func TestWithValidation(t *testing.T) {
+	ctx := context.Background()
+
+	// Nothing listens on the URL: invalid objects fail before being sent
+	client, err := zabbix.NewClient("http://127.0.0.1:1/api_jsonrpc.php", zabbix.WithAPIToken("token"), zabbix.WithValidation())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = client.HostCreate(ctx, []zabbix.Host{
+		{Host: "a", Groups: []zabbix.HostGroup{{GroupID: "2"}}},
+		{Host: "b", Groups: []zabbix.HostGroup{{GroupID: "2"}}, MonitoredBy: zabbix.MonitoredByProxyGroup},
+	})
+	if got := fields(t, err); !reflect.DeepEqual(got, []string{"[1].proxy_groupid"}) {
+		t.Errorf("got errors on %v", got)
+	}
+
+	_, err = client.HostUpdate(ctx, zabbix.Host{HostID: "1", TlsAccept: zabbix.TLSPSK, TlsPSK: "secret"})
+	if got := fields(t, err); !reflect.DeepEqual(got, []string{"tls_psk"}) {
+		t.Errorf("got errors on %v", got)
+	}
+
+	_, err = client.HostInterfaceCreate(ctx, zabbix.HostInterface{HostID: "1", Type: zabbix.InterfaceTypeSNMP, IP: "10.0.0.1", UseIP: zabbix.UseIPOptionIP})
+	if got := fields(t, err); !reflect.DeepEqual(got, []string{"port", "details.version"}) {
+		t.Errorf("got errors on %v", got)
+	}
+}
+
+func TestWithValidationPSKRoundTrip(t *testing.T) {
+	ctx := context.Background()
+
+	client, err := zabbix.NewClient(url, zabbix.WithUserPass(user, passwd), zabbix.WithValidation())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Authenticate
+	if err := client.Authenticate(); err != nil {
+		t.Fatal("Initial auth failed:", err)
+	}
+
+	createResp, err := client.HostCreate(ctx, []zabbix.Host{{
+		Host:           "test-host-psk",
+		Groups:         []zabbix.HostGroup{{GroupID: "2"}},
+		TlsConnect:     zabbix.TLSPSK,
+		TlsAccept:      zabbix.TLSPSK,
+		TlsPSKIdentity: "PSK 001",
+		TlsPSK:         "1f87b595725ac58dd977beef14b97461a7c1045b9a1c963065002c5473194952",
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.HostDelete(ctx, createResp.HostIDs)
+
+	hosts, err := client.HostGet(ctx, zabbix.HostGetParameters{HostIDs: createResp.HostIDs})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(hosts) != 1 {
+		t.Fatalf("expected 1 host, got %d", len(hosts))
+	}
+
+	// The PSK isn't returned, and isn't needed to update the host
+	host := hosts[0]
+	host.Description = "Uses PSK"
+	if _, err := client.HostUpdate(ctx, host); err != nil {
+		t.Fatal(err)
+	}
+}
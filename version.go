@@ -0,0 +1,352 @@
+package zabbix
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Version is the version of a Zabbix server, e.g. 7.0.3.
+type Version struct {
+	Major int
+	Minor int
+	Patch int
+}
+
+// ParseVersion parses a version as returned by apiinfo.version, e.g.
+// "7.0.3". A pre-release suffix such as in "7.2.0rc1" is ignored.
+func ParseVersion(s string) (Version, error) {
+	parts := strings.Split(s, ".")
+	if len(parts) < 2 || len(parts) > 3 {
+		return Version{}, fmt.Errorf("invalid version %q", s)
+	}
+	if len(parts) == 2 {
+		parts = append(parts, "0")
+	}
+
+	// Drop the pre-release suffix of the patch number
+	if i := strings.IndexFunc(parts[2], func(r rune) bool { return r < '0' || r > '9' }); i >= 0 {
+		parts[2] = parts[2][:i]
+	}
+
+	var numbers [3]int
+	for i, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil || n < 0 {
+			return Version{}, fmt.Errorf("invalid version %q", s)
+		}
+		numbers[i] = n
+	}
+	return Version{Major: numbers[0], Minor: numbers[1], Patch: numbers[2]}, nil
+}
+
+func (v Version) String() string {
+	return fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
+}
+
+// Compare returns -1, 0 or +1 depending on whether v is lower than, equal to
+// or higher than other.
+func (v Version) Compare(other Version) int {
+	switch {
+	case v.Major != other.Major:
+		return cmpInt(v.Major, other.Major)
+	case v.Minor != other.Minor:
+		return cmpInt(v.Minor, other.Minor)
+	default:
+		return cmpInt(v.Patch, other.Patch)
+	}
+}
+
+// AtLeast reports whether v is major.minor or later.
+func (v Version) AtLeast(major, minor int) bool {
+	return v.Compare(Version{Major: major, Minor: minor}) >= 0
+}
+
+func cmpInt(a, b int) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// APIVersion returns the version of the Zabbix API, as reported by
+// apiinfo.version. It is requested without authentication on first use and
+// cached by the client.
+func (c *zabbixClient) APIVersion(ctx context.Context) (Version, error) {
+	c.versionLock.RLock()
+	version := c.version
+	c.versionLock.RUnlock()
+	if version != nil {
+		return *version, nil
+	}
+
+	var s string
+	if err := c.makeRequest(ctx, "apiinfo.version", []string{}, &s); err != nil {
+		return Version{}, err
+	}
+	v, err := ParseVersion(s)
+	if err != nil {
+		return Version{}, err
+	}
+
+	c.versionLock.Lock()
+	c.version = &v
+	c.versionLock.Unlock()
+	return v, nil
+}
+
+// CompatibilityMode is how a client handles methods and params not supported
+// by the version of the server.
+type CompatibilityMode int
+
+const (
+	// CompatibilityOff sends every call as is. It is the default.
+	CompatibilityOff CompatibilityMode = iota
+
+	// CompatibilityStrict fails calls using a method or param not supported
+	// by the server with an *UnsupportedError, without sending them.
+	CompatibilityStrict
+
+	// CompatibilityStrip removes the params not supported by the server
+	// from calls. Calls of unsupported methods still fail with an
+	// *UnsupportedError.
+	CompatibilityStrip
+)
+
+// WithCompatibility checks the calls of the client against the version of
+// the server, which is requested with APIVersion before the first call
+// needing it. The library targets Zabbix 7.0; the checks cover the methods
+// and params of this library that 6.0 and 6.4 servers don't support.
+func WithCompatibility(mode CompatibilityMode) ClientOption {
+	return func(c *zabbixClient) {
+		c.compatibility = mode
+	}
+}
+
+// UnsupportedError is returned for a call using a method or param not
+// supported by the version of the server.
+type UnsupportedError struct {
+	Method  string  // JSON-RPC method, e.g. "proxygroup.get"
+	Param   string  // Unsupported param; empty if the method is unsupported
+	Version Version // Version of the server
+	Since   Version // First version supporting it; zero if any
+	Until   Version // First version not supporting it anymore; zero if none
+}
+
+func (e *UnsupportedError) Error() string {
+	name := e.Method
+	if e.Param != "" {
+		name = fmt.Sprintf("%s param %q", e.Method, e.Param)
+	}
+
+	if e.Until != (Version{}) && e.Version.Compare(e.Until) >= 0 {
+		return fmt.Sprintf("%s is not supported by Zabbix %s: removed in %d.%d", name, e.Version, e.Until.Major, e.Until.Minor)
+	}
+	return fmt.Sprintf("%s is not supported by Zabbix %s: requires %d.%d", name, e.Version, e.Since.Major, e.Since.Minor)
+}
+
+// versionRange is the range of versions supporting a method or param.
+type versionRange struct {
+	since Version // First version supporting it; zero if any
+	until Version // First version not supporting it anymore; zero if none
+}
+
+func (r versionRange) contains(v Version) bool {
+	return v.Compare(r.since) >= 0 && (r.until == Version{} || v.Compare(r.until) < 0)
+}
+
+var (
+	since5_4 = versionRange{since: Version{Major: 5, Minor: 4}}
+	since6_0 = versionRange{since: Version{Major: 6}}
+	since6_2 = versionRange{since: Version{Major: 6, Minor: 2}}
+	since7_0 = versionRange{since: Version{Major: 7}}
+	until5_4 = versionRange{until: Version{Major: 5, Minor: 4}}
+	until7_0 = versionRange{until: Version{Major: 7}}
+)
+
+// objectVersions are the versions supporting the methods of an object,
+// e.g. of "proxygroup" for proxygroup.get.
+var objectVersions = map[string]versionRange{
+	"proxygroup": since7_0,
+	"service":    since6_0, // Replaced IT services with business services
+	"sla":        since6_0,
+	"token":      since5_4,
+}
+
+// hostVersions are the versions supporting the fields of the host object.
+var hostVersions = map[string]versionRange{
+	"monitored_by":  since7_0,
+	"proxyid":       since7_0,
+	"proxy_groupid": since7_0,
+}
+
+// proxyVersions are the versions supporting the fields of the proxy object,
+// which replaced the host and status fields of proxies in 7.0.
+var proxyVersions = map[string]versionRange{
+	"name":                   since7_0,
+	"operating_mode":         since7_0,
+	"proxy_groupid":          since7_0,
+	"local_address":          since7_0,
+	"local_port":             since7_0,
+	"address":                since7_0,
+	"port":                   since7_0,
+	"allowed_addresses":      since7_0,
+	"custom_timeouts":        since7_0,
+	"timeout_zabbix_agent":   since7_0,
+	"timeout_simple_check":   since7_0,
+	"timeout_snmp_agent":     since7_0,
+	"timeout_external_check": since7_0,
+	"timeout_db_monitor":     since7_0,
+	"timeout_http_agent":     since7_0,
+	"timeout_ssh_agent":      since7_0,
+	"timeout_telnet_agent":   since7_0,
+	"timeout_script":         since7_0,
+	"timeout_browser":        since7_0,
+}
+
+// dashboardVersions are the versions supporting the fields of the dashboard
+// object, whose widgets moved to pages in 5.4.
+var dashboardVersions = map[string]versionRange{
+	"pages":          since5_4,
+	"display_period": since5_4,
+	"auto_start":     since5_4,
+}
+
+// scriptVersions are the versions supporting the fields of the script object.
+var scriptVersions = map[string]versionRange{
+	"url":                since7_0,
+	"new_window":         since7_0,
+	"manualinput":        since7_0,
+	"manualinput_prompt": since7_0,
+}
+
+// paramVersions are the versions supporting the params of a method. For
+// methods taking an array of objects, they apply to each object.
+var paramVersions = map[string]map[string]versionRange{
+	"host.get": {
+		"applicationids":     until5_4,
+		"with_applications":  until5_4,
+		"selectApplications": until5_4,
+		"selectScreens":      until5_4,
+		"selectHostGroups":   since6_2,
+		"selectGroups":       until7_0,
+		"proxy_hosts":        until7_0,
+	},
+	"host.create": hostVersions,
+	"host.update": hostVersions,
+
+	"dashboard.get": {
+		"selectPages": since5_4,
+	},
+	"dashboard.create": dashboardVersions,
+	"dashboard.update": dashboardVersions,
+
+	"hostgroup.get": {
+		"with_hosts": since6_2,
+	},
+
+	"template.get": {
+		"selectTemplateGroups": since6_2,
+	},
+
+	"proxy.get": {
+		"proxy_groupids":      since7_0,
+		"selectAssignedHosts": since7_0,
+		"selectProxyGroup":    since7_0,
+	},
+	"proxy.create": proxyVersions,
+	"proxy.update": proxyVersions,
+
+	"script.get": {
+		"selectHostGroups": since6_2,
+	},
+	"script.create": scriptVersions,
+	"script.update": scriptVersions,
+	"script.execute": {
+		"manualinput": since7_0,
+	},
+}
+
+// checkCompatibility checks a call of method against the version of the
+// server and returns the params to send, which are params itself unless
+// some were stripped.
+func (c *zabbixClient) checkCompatibility(ctx context.Context, method string, params any) (any, error) {
+	object, _, _ := strings.Cut(method, ".")
+	methodRange, gated := objectVersions[object]
+	fields := paramVersions[method]
+	if !gated && fields == nil {
+		return params, nil
+	}
+
+	version, err := c.APIVersion(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("version check failed: %w", err)
+	}
+
+	if gated && !methodRange.contains(version) {
+		return nil, &UnsupportedError{Method: method, Version: version, Since: methodRange.since, Until: methodRange.until}
+	}
+	if fields == nil {
+		return params, nil
+	}
+
+	data, err := json.Marshal(params)
+	if err != nil {
+		return nil, err
+	}
+
+	// Params are an object, or an array of objects for create methods
+	var objects []map[string]json.RawMessage
+	var array bool
+	switch data = bytes.TrimSpace(data); {
+	case bytes.HasPrefix(data, []byte("{")):
+		var object map[string]json.RawMessage
+		if err := json.Unmarshal(data, &object); err != nil {
+			return nil, err
+		}
+		objects = append(objects, object)
+	case bytes.HasPrefix(data, []byte("[")):
+		if err := json.Unmarshal(data, &objects); err != nil {
+			return params, nil // Not an array of objects, nothing to check
+		}
+		array = true
+	default:
+		return params, nil
+	}
+
+	var stripped []string
+	for _, object := range objects {
+		for _, name := range slices.Sorted(maps.Keys(object)) {
+			r, ok := fields[name]
+			if !ok || r.contains(version) {
+				continue
+			}
+			if c.compatibility != CompatibilityStrip {
+				return nil, &UnsupportedError{Method: method, Param: name, Version: version, Since: r.since, Until: r.until}
+			}
+			delete(object, name)
+			if !slices.Contains(stripped, name) {
+				stripped = append(stripped, name)
+			}
+		}
+	}
+
+	if len(stripped) == 0 {
+		return params, nil
+	}
+	c.log(ctx, slog.LevelDebug, "zabbix params stripped", slog.String("method", method), slog.String("version", version.String()), slog.Any("params", stripped))
+	if array {
+		return objects, nil
+	}
+	return objects[0], nil
+}
@@ -0,0 +1,148 @@
+package zabbix_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	zabbix "github.com/nimok/nim-go-zabbix"
+)
+
+func TestParseVersion(t *testing.T) {
+	tests := []struct {
+		in   string
+		want zabbix.Version
+	}{
+		{"7.0.3", zabbix.Version{Major: 7, Minor: 0, Patch: 3}},
+		{"6.4.12", zabbix.Version{Major: 6, Minor: 4, Patch: 12}},
+		{"7.2.0rc1", zabbix.Version{Major: 7, Minor: 2, Patch: 0}},
+		{"6.0", zabbix.Version{Major: 6, Minor: 0, Patch: 0}},
+	}
+	for _, tt := range tests {
+		got, err := zabbix.ParseVersion(tt.in)
+		if err != nil || got != tt.want {
+			t.Errorf("ParseVersion(%q) = %v, %v, want %v", tt.in, got, err, tt.want)
+		}
+	}
+
+	for _, in := range []string{"", "7", "7.x.1", "7.0.0.1", "-1.0.0"} {
+		if _, err := zabbix.ParseVersion(in); err == nil {
+			t.Errorf("ParseVersion(%q) succeeded", in)
+		}
+	}
+
+	if v := (zabbix.Version{Major: 6, Minor: 4, Patch: 12}); !v.AtLeast(6, 0) || v.AtLeast(7, 0) {
+		t.Errorf("unexpected AtLeast for %s", v)
+	}
+}
+
+func TestAPIVersion(t *testing.T) {
+	ctx := context.Background()
+
+	client, err := zabbix.NewClient(url, zabbix.WithUserPass(user, passwd))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Doesn't need a login
+	version, err := client.APIVersion(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !version.AtLeast(6, 0) {
+		t.Errorf("unexpected version %s", version)
+	}
+
+	if err := client.Authenticate(); err != nil {
+		t.Fatal("Initial auth failed:", err)
+	}
+	if again, err := client.APIVersion(ctx); err != nil || again != version {
+		t.Errorf("got %v, %v after login, want %v", again, err, version)
+	}
+}
+
+// versionServer reports version and records the params of the other calls.
+func versionServer(t *testing.T, version string, params *[]map[string]any) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Method string          `json:"method"`
+			Params json.RawMessage `json:"params"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Error(err)
+			return
+		}
+
+		var result any = []any{}
+		switch req.Method {
+		case "apiinfo.version":
+			result = version
+		case "host.create":
+			var hosts []map[string]any
+			if err := json.Unmarshal(req.Params, &hosts); err != nil {
+				t.Error(err)
+			}
+			*params = append(*params, hosts...)
+			result = map[string]any{"hostids": []string{"10500"}}
+		default:
+			var p map[string]any
+			if err := json.Unmarshal(req.Params, &p); err != nil {
+				t.Error(err)
+			}
+			*params = append(*params, p)
+		}
+		json.NewEncoder(w).Encode(map[string]any{"jsonrpc": "2.0", "result": result, "id": 1})
+	}))
+}
+
+func TestCompatibility(t *testing.T) {
+	ctx := context.Background()
+
+	var params []map[string]any
+	srv := versionServer(t, "6.4.12", &params)
+	defer srv.Close()
+
+	strict, err := zabbix.NewClient(srv.URL, zabbix.WithAPIToken("token"), zabbix.WithCompatibility(zabbix.CompatibilityStrict))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var unsupported *zabbix.UnsupportedError
+	_, err = strict.HostGet(ctx, zabbix.HostGetParameters{SelectScreens: "extend"})
+	if !errors.As(err, &unsupported) || unsupported.Param != "selectScreens" {
+		t.Fatalf("got %v, want an *UnsupportedError for selectScreens", err)
+	}
+	if want := `host.get param "selectScreens" is not supported by Zabbix 6.4.12: removed in 5.4`; err.Error() != want {
+		t.Errorf("got error %q, want %q", err, want)
+	}
+	if _, err := strict.ProxyGroupGet(ctx, zabbix.ProxyGroupGetParameters{}); err == nil || err.Error() != "proxygroup.get is not supported by Zabbix 6.4.12: requires 7.0" {
+		t.Errorf("unexpected error %v", err)
+	}
+	if _, err := strict.HostGet(ctx, zabbix.HostGetParameters{SelectGroups: "extend", SelectHostGroups: "extend"}); err != nil {
+		t.Error(err)
+	}
+	if len(params) != 1 {
+		t.Fatalf("got %d calls sent, want 1", len(params))
+	}
+
+	params = nil
+	strip, err := zabbix.NewClient(srv.URL, zabbix.WithAPIToken("token"), zabbix.WithCompatibility(zabbix.CompatibilityStrip))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := strip.HostCreate(ctx, []zabbix.Host{{Host: "web-01", MonitoredBy: 1, ProxyID: "10"}, {Host: "web-02"}}); err != nil {
+		t.Fatal(err)
+	}
+	if len(params) != 2 || params[0]["host"] != "web-01" || params[1]["host"] != "web-02" {
+		t.Fatalf("unexpected params %v", params)
+	}
+	if _, ok := params[0]["monitored_by"]; ok {
+		t.Errorf("monitored_by not stripped: %v", params[0])
+	}
+	if _, ok := params[0]["proxyid"]; ok {
+		t.Errorf("proxyid not stripped: %v", params[0])
+	}
+}
@@ -0,0 +1,279 @@
+package zabbix
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// WebhookSecretHeader is the header checked against the shared secret
+// configured with WithWebhookSecret.
+const WebhookSecretHeader = "X-Zabbix-Webhook-Secret"
+
+// Values of WebhookEvent.Status as sent by the {EVENT.STATUS} macro.
+const (
+	WebhookStatusProblem  = "PROBLEM"
+	WebhookStatusResolved = "RESOLVED"
+)
+
+// webhookMaxBodySize caps the size of an accepted webhook payload.
+const webhookMaxBodySize = 1 << 20
+
+// WebhookEvent is the payload posted by a Zabbix webhook media type.
+//
+// Zabbix passes every webhook parameter as a string, so the media type is
+// expected to define the parameters below (named after the json tags) and
+// post them as a JSON object:
+//
+//	var params = JSON.parse(value), req = new HttpRequest(), url = params.url;
+//	req.addHeader('Content-Type: application/json');
+//	req.addHeader('X-Zabbix-Webhook-Secret: ' + params.secret);
+//	delete params.url;
+//	delete params.secret;
+//	req.post(url, JSON.stringify(params));
+//	if (req.getStatus() >= 300) throw 'webhook failed: ' + req.getStatus();
+//	return 'OK';
+type WebhookEvent struct {
+	EventID         string       `json:"event_id"`                 // {EVENT.ID}
+	EventName       string       `json:"event_name"`               // {EVENT.NAME}
+	Severity        Severity     `json:"event_nseverity"`          // {EVENT.NSEVERITY}
+	Status          string       `json:"event_status"`             // {EVENT.STATUS}; PROBLEM or RESOLVED
+	Value           string       `json:"event_value"`              // {EVENT.VALUE}; "1" problem, "0" recovery
+	UpdateStatus    string       `json:"event_update_status"`      // {EVENT.UPDATE.STATUS}; "1" if the event was updated (acknowledged etc.)
+	Clock           time.Time    `json:"event_timestamp"`          // {EVENT.TIMESTAMP}
+	OpData          string       `json:"event_opdata"`             // {EVENT.OPDATA}
+	Tags            WebhookTags  `json:"event_tags"`               // {EVENT.TAGSJSON}
+	RecoveryEventID string       `json:"event_recovery_id"`        // {EVENT.RECOVERY.ID}; empty if not recovered
+	RecoveryClock   time.Time    `json:"event_recovery_timestamp"` // {EVENT.RECOVERY.TIMESTAMP}; zero if not recovered
+	HostID          string       `json:"host_id"`                  // {HOST.ID}
+	Host            string       `json:"host_host"`                // {HOST.HOST}; technical name
+	HostName        string       `json:"host_name"`                // {HOST.NAME}; visible name
+	HostIP          string       `json:"host_ip"`                  // {HOST.IP}
+	TriggerID       string       `json:"trigger_id"`               // {TRIGGER.ID}
+	TriggerName     string       `json:"trigger_name"`             // {TRIGGER.NAME}
+	TriggerStatus   string       `json:"trigger_status"`           // {TRIGGER.STATUS}; PROBLEM or OK
+	Extra           WebhookExtra `json:"-"`                        // any other parameters defined on the media type
+}
+
+// WebhookTags holds the event tags. It accepts both a JSON array and the
+// string-encoded array produced by passing {EVENT.TAGSJSON} as a parameter.
+type WebhookTags []ProblemTag
+
+// WebhookExtra holds webhook parameters that are not mapped to a field of
+// WebhookEvent.
+type WebhookExtra map[string]string
+
+func (t *WebhookTags) UnmarshalJSON(data []byte) error {
+	var encoded string
+	if err := json.Unmarshal(data, &encoded); err == nil {
+		if encoded == "" {
+			*t = nil
+			return nil
+		}
+		data = []byte(encoded)
+	}
+
+	var tags []ProblemTag
+	if err := json.Unmarshal(data, &tags); err != nil {
+		return fmt.Errorf("invalid event tags: %v", err)
+	}
+	*t = tags
+	return nil
+}
+
+// IsRecovery reports whether the event is a recovery of a problem.
+func (e WebhookEvent) IsRecovery() bool {
+	return e.Status == WebhookStatusResolved || e.Value == "0"
+}
+
+// IsUpdate reports whether the event is an update (acknowledgement, severity
+// change, comment...) of an existing problem.
+func (e WebhookEvent) IsUpdate() bool {
+	return e.UpdateStatus == "1"
+}
+
+// Problem converts the event into the Problem representation returned by
+// problem.get.
+func (e WebhookEvent) Problem() Problem {
+	return Problem{
+		EventID:  e.EventID,
+		Source:   "0",
+		Object:   "0",
+		ObjectID: e.TriggerID,
+		Clock:    e.Clock,
+		REventID: e.RecoveryEventID,
+		RClock:   e.RecoveryClock,
+		Name:     e.EventName,
+		Severity: e.Severity,
+		OpData:   e.OpData,
+		Tags:     e.Tags,
+	}
+}
+
+func (e *WebhookEvent) UnmarshalJSON(data []byte) error {
+	type webhookEvent WebhookEvent
+
+	var event webhookEvent
+	aux := struct {
+		*webhookEvent
+		Clock         json.RawMessage `json:"event_timestamp"`
+		RecoveryClock json.RawMessage `json:"event_recovery_timestamp"`
+	}{webhookEvent: &event}
+
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+
+	// Zabbix leaves the recovery macros unexpanded in problem alerts
+	if unexpandedMacro(unquote(aux.RecoveryClock)) {
+		aux.RecoveryClock = nil
+	}
+	if unexpandedMacro([]byte(event.RecoveryEventID)) {
+		event.RecoveryEventID = ""
+	}
+
+	var err error
+	if event.Clock, err = parseUnixTime(aux.Clock, nil); err != nil {
+		return fmt.Errorf("invalid event timestamp: %v", err)
+	}
+	if event.RecoveryClock, err = parseUnixTime(aux.RecoveryClock, nil); err != nil {
+		return fmt.Errorf("invalid event recovery timestamp: %v", err)
+	}
+
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	for _, key := range webhookEventKeys {
+		delete(raw, key)
+	}
+	for key, value := range raw {
+		var s string
+		if err := json.Unmarshal(value, &s); err != nil {
+			s = string(value)
+		}
+		if event.Extra == nil {
+			event.Extra = make(WebhookExtra, len(raw))
+		}
+		event.Extra[key] = s
+	}
+
+	*e = WebhookEvent(event)
+	return nil
+}
+
+// unexpandedMacro reports whether a webhook parameter is a macro Zabbix
+// didn't expand, such as {EVENT.RECOVERY.ID} in problem alerts.
+func unexpandedMacro(value []byte) bool {
+	return len(value) > 1 && value[0] == '{' && value[len(value)-1] == '}'
+}
+
+var webhookEventKeys = []string{
+	"event_id", "event_name", "event_nseverity", "event_status", "event_value",
+	"event_update_status", "event_timestamp", "event_opdata", "event_tags",
+	"event_recovery_id", "event_recovery_timestamp", "host_id", "host_host",
+	"host_name", "host_ip", "trigger_id", "trigger_name", "trigger_status",
+}
+
+// WebhookHandlerFunc handles a decoded webhook event. Returning an error makes
+// the handler respond with 500 so Zabbix marks the alert as failed and
+// retries it according to the media type settings.
+type WebhookHandlerFunc func(ctx context.Context, event WebhookEvent) error
+
+type webhookRoute struct {
+	status string
+	fn     WebhookHandlerFunc
+}
+
+// WebhookHandler is an http.Handler receiving Zabbix webhook alerts.
+type WebhookHandler struct {
+	secret string
+
+	routes     []webhookRoute
+	routesLock sync.RWMutex
+}
+
+var _ http.Handler = (*WebhookHandler)(nil)
+
+type WebhookOption func(*WebhookHandler)
+
+// WithWebhookSecret requires every request to carry the given secret in the
+// WebhookSecretHeader header.
+func WithWebhookSecret(secret string) WebhookOption {
+	return func(h *WebhookHandler) {
+		h.secret = secret
+	}
+}
+
+func NewWebhookHandler(opts ...WebhookOption) *WebhookHandler {
+	handler := &WebhookHandler{}
+	for _, opt := range opts {
+		opt(handler)
+	}
+	return handler
+}
+
+// Handle registers fn for every event.
+func (h *WebhookHandler) Handle(fn WebhookHandlerFunc) {
+	h.HandleStatus("", fn)
+}
+
+// HandleStatus registers fn for events with the given status
+// (WebhookStatusProblem or WebhookStatusResolved). An empty status matches
+// every event.
+func (h *WebhookHandler) HandleStatus(status string, fn WebhookHandlerFunc) {
+	h.routesLock.Lock()
+	defer h.routesLock.Unlock()
+
+	h.routes = append(h.routes, webhookRoute{status: status, fn: fn})
+}
+
+func (h *WebhookHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	if h.secret != "" {
+		got := r.Header.Get(WebhookSecretHeader)
+		if subtle.ConstantTimeCompare([]byte(got), []byte(h.secret)) != 1 {
+			http.Error(w, "invalid webhook secret", http.StatusUnauthorized)
+			return
+		}
+	}
+
+	var event WebhookEvent
+	decoder := json.NewDecoder(http.MaxBytesReader(w, r.Body, webhookMaxBodySize))
+	if err := decoder.Decode(&event); err != nil {
+		http.Error(w, fmt.Sprintf("invalid webhook payload: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	if err := h.dispatch(r.Context(), event); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *WebhookHandler) dispatch(ctx context.Context, event WebhookEvent) error {
+	h.routesLock.RLock()
+	routes := h.routes
+	h.routesLock.RUnlock()
+
+	for _, route := range routes {
+		if route.status != "" && route.status != event.Status {
+			continue
+		}
+		if err := route.fn(ctx, event); err != nil {
+			return fmt.Errorf("webhook handler failed for event %s: %w", event.EventID, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,167 @@
+package zabbix_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	zabbix "github.com/nimok/nim-go-zabbix"
+)
+
+const webhookPayload = `{
+	"event_id": "1234",
+	"event_name": "High CPU utilization",
+	"event_nseverity": "4",
+	"event_status": "PROBLEM",
+	"event_value": "1",
+	"event_timestamp": "1700000000",
+	"event_tags": "[{\"tag\":\"service\",\"value\":\"web\"}]",
+	"host_id": "10084",
+	"host_host": "web-01",
+	"trigger_id": "22000",
+	"alert_subject": "Problem: High CPU utilization"
+}`
+
+func TestWebhookHandlerDispatch(t *testing.T) {
+	handler := zabbix.NewWebhookHandler(zabbix.WithWebhookSecret("s3cret"))
+
+	var problems, recoveries []zabbix.WebhookEvent
+	handler.HandleStatus(zabbix.WebhookStatusProblem, func(ctx context.Context, event zabbix.WebhookEvent) error {
+		problems = append(problems, event)
+		return nil
+	})
+	handler.HandleStatus(zabbix.WebhookStatusResolved, func(ctx context.Context, event zabbix.WebhookEvent) error {
+		recoveries = append(recoveries, event)
+		return nil
+	})
+
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(webhookPayload))
+	req.Header.Set(zabbix.WebhookSecretHeader, "s3cret")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusNoContent {
+		t.Fatalf("unexpected status %d: %s", rec.Code, rec.Body.String())
+	}
+	if len(problems) != 1 || len(recoveries) != 0 {
+		t.Fatalf("expected one problem and no recoveries, got %d and %d", len(problems), len(recoveries))
+	}
+
+	event := problems[0]
+	if len(event.Tags) != 1 || event.Tags[0].Tag != "service" || event.Tags[0].Value != "web" {
+		t.Fatalf("unexpected tags: %+v", event.Tags)
+	}
+	if event.Extra["alert_subject"] != "Problem: High CPU utilization" {
+		t.Fatalf("unexpected extra parameters: %+v", event.Extra)
+	}
+
+	if event.Severity != zabbix.SeverityHigh || event.Clock.Unix() != 1700000000 || !event.RecoveryClock.IsZero() {
+		t.Fatalf("unexpected event: %+v", event)
+	}
+
+	problem := event.Problem()
+	if problem.EventID != "1234" || problem.ObjectID != "22000" || len(problem.Tags) != 1 {
+		t.Fatalf("unexpected problem: %+v", problem)
+	}
+	if problem.Resolved() || problem.Duration() <= 0 {
+		t.Fatalf("problem should be open: %+v", problem)
+	}
+}
+
+func TestWebhookHandlerRejectsInvalidSecret(t *testing.T) {
+	handler := zabbix.NewWebhookHandler(zabbix.WithWebhookSecret("s3cret"))
+	handler.Handle(func(ctx context.Context, event zabbix.WebhookEvent) error {
+		t.Error("handler should not be called")
+		return nil
+	})
+
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(webhookPayload))
+	req.Header.Set(zabbix.WebhookSecretHeader, "wrong")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusUnauthorized {
+		t.Fatalf("expected 401, got %d", rec.Code)
+	}
+}
+
+func TestWebhookHandlerError(t *testing.T) {
+	handler := zabbix.NewWebhookHandler()
+	handler.Handle(func(ctx context.Context, event zabbix.WebhookEvent) error {
+		return errors.New("ticketing system unavailable")
+	})
+
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(webhookPayload))
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusInternalServerError {
+		t.Fatalf("expected 500, got %d", rec.Code)
+	}
+
+	req = httptest.NewRequest(http.MethodPost, "/", strings.NewReader("not json"))
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400, got %d", rec.Code)
+	}
+}
+
+// A problem alert as posted by a media type passing every event macro, where
+// Zabbix leaves the recovery macros unexpanded.
+const webhookProblemAlert = `{
+	"event_id": "5678",
+	"event_name": "Zabbix agent is not available",
+	"event_nseverity": "3",
+	"event_status": "PROBLEM",
+	"event_value": "1",
+	"event_update_status": "0",
+	"event_timestamp": "1700000100",
+	"event_opdata": "",
+	"event_tags": "[]",
+	"event_recovery_id": "{EVENT.RECOVERY.ID}",
+	"event_recovery_timestamp": "{EVENT.RECOVERY.TIMESTAMP}",
+	"host_id": "10084",
+	"host_host": "Zabbix server",
+	"host_name": "Zabbix server",
+	"host_ip": "127.0.0.1",
+	"trigger_id": "22001",
+	"trigger_name": "Zabbix agent is not available",
+	"trigger_status": "PROBLEM"
+}`
+
+func TestWebhookHandlerUnexpandedRecoveryMacros(t *testing.T) {
+	handler := zabbix.NewWebhookHandler()
+
+	var events []zabbix.WebhookEvent
+	handler.Handle(func(ctx context.Context, event zabbix.WebhookEvent) error {
+		events = append(events, event)
+		return nil
+	})
+
+	for _, payload := range []string{
+		webhookProblemAlert,
+		strings.NewReplacer(`"{EVENT.RECOVERY.ID}"`, `""`, `"{EVENT.RECOVERY.TIMESTAMP}"`, `""`).Replace(webhookProblemAlert),
+	} {
+		req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(payload))
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusNoContent {
+			t.Fatalf("unexpected status %d: %s", rec.Code, rec.Body.String())
+		}
+	}
+
+	for _, event := range events {
+		if event.RecoveryEventID != "" || !event.RecoveryClock.IsZero() || event.IsRecovery() {
+			t.Errorf("expected an unrecovered problem, got %+v", event)
+		}
+		if event.Clock.Unix() != 1700000100 || event.Problem().Resolved() {
+			t.Errorf("unexpected event %+v", event)
+		}
+	}
+}
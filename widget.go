@@ -0,0 +1,189 @@
+package zabbix
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// The widget builders below build the common widgets with the field names of
+// Zabbix 7.0, where fields with several values are indexed, e.g.
+// "hostids.0". They are placed at the top left of the page unless
+// WithWidgetPosition is given:
+//
+//	page := zabbix.DashboardPage{Widgets: []zabbix.DashboardWidget{
+//		zabbix.NewProblemsWidget(hostIDs, nil, zabbix.WithWidgetSize(36, 8)),
+//		zabbix.NewGraphWidget(cpuItemID, zabbix.WithWidgetPosition(36, 0)),
+//	}}
+
+// Default size of the widgets built by the widget builders.
+const (
+	DefaultWidgetWidth  = 18
+	DefaultWidgetHeight = 5
+)
+
+// WidgetOption configures a widget built by a widget builder.
+type WidgetOption func(*DashboardWidget)
+
+// WithWidgetName sets the name shown in the header of the widget instead of
+// the default name of its type.
+func WithWidgetName(name string) WidgetOption {
+	return func(w *DashboardWidget) {
+		w.Name = name
+	}
+}
+
+// WithWidgetPosition places the widget at column x and row y of the page.
+func WithWidgetPosition(x, y int) WidgetOption {
+	return func(w *DashboardWidget) {
+		w.X, w.Y = FlexInt(x), FlexInt(y)
+	}
+}
+
+// WithWidgetSize sets the width of the widget in columns and its height in
+// rows.
+func WithWidgetSize(width, height int) WidgetOption {
+	return func(w *DashboardWidget) {
+		w.Width, w.Height = FlexInt(width), FlexInt(height)
+	}
+}
+
+// WithWidgetRefresh sets the refresh interval of the widget in seconds, 0 to
+// never refresh it.
+func WithWidgetRefresh(seconds int) WidgetOption {
+	return func(w *DashboardWidget) {
+		w.Fields = append(w.Fields, intField("rf_rate", seconds))
+	}
+}
+
+// WithWidgetFields adds fields to the widget, e.g. the ones a builder doesn't
+// set.
+func WithWidgetFields(fields ...WidgetField) WidgetOption {
+	return func(w *DashboardWidget) {
+		w.Fields = append(w.Fields, fields...)
+	}
+}
+
+// NewGraphWidget returns a graph (classic) widget showing the simple graph of
+// an item.
+func NewGraphWidget(itemID string, opts ...WidgetOption) DashboardWidget {
+	return newWidget("graph", []WidgetField{
+		intField("source_type", 1), // Simple graph
+		{Type: WidgetFieldTypeItem, Name: "itemid.0", Value: itemID},
+	}, opts)
+}
+
+// NewItemValueWidget returns an item value widget showing the last value of
+// an item.
+func NewItemValueWidget(itemID string, opts ...WidgetOption) DashboardWidget {
+	return newWidget("item", []WidgetField{
+		{Type: WidgetFieldTypeItem, Name: "itemid.0", Value: itemID},
+	}, opts)
+}
+
+// NewProblemsWidget returns a problems widget listing the problems of the
+// given hosts, or of all hosts if hostIDs is empty, with the given
+// severities, or all severities if severities is empty.
+func NewProblemsWidget(hostIDs []string, severities []Severity, opts ...WidgetOption) DashboardWidget {
+	fields := idFields(WidgetFieldTypeHost, "hostids", hostIDs)
+	for i, severity := range severities {
+		fields = append(fields, intField(fmt.Sprintf("severities.%d", i), int(severity)))
+	}
+	return newWidget("problems", fields, opts)
+}
+
+// Data shown by the columns of a top hosts widget.
+const (
+	TopHostsDataItemValue = 1
+	TopHostsDataHostName  = 2
+	TopHostsDataText      = 3
+)
+
+// TopHostsColumn is a column of a top hosts widget.
+type TopHostsColumn struct {
+	Name string // Header of the column
+	Data int    // Data shown, one of the TopHostsData constants; TopHostsDataItemValue if 0
+	Item string // Name of the item whose value is shown, for TopHostsDataItemValue
+	Text string // Text shown, which may contain macros, for TopHostsDataText
+}
+
+// NewTopHostsWidget returns a top hosts widget listing the given hosts, or
+// all hosts if hostIDs is empty, with the given columns. Items are given by
+// name since a column shows the item of that name on each host. The hosts are
+// sorted by the first column.
+func NewTopHostsWidget(hostIDs []string, columns []TopHostsColumn, opts ...WidgetOption) DashboardWidget {
+	fields := idFields(WidgetFieldTypeHost, "hostids", hostIDs)
+	for i, column := range columns {
+		prefix := fmt.Sprintf("columns.%d.", i)
+		data := column.Data
+		if data == 0 {
+			data = TopHostsDataItemValue
+		}
+		fields = append(fields,
+			WidgetField{Type: WidgetFieldTypeString, Name: prefix + "name", Value: column.Name},
+			intField(prefix+"data", data),
+		)
+		switch data {
+		case TopHostsDataItemValue:
+			fields = append(fields, WidgetField{Type: WidgetFieldTypeString, Name: prefix + "item", Value: column.Item})
+		case TopHostsDataText:
+			fields = append(fields, WidgetField{Type: WidgetFieldTypeString, Name: prefix + "text", Value: column.Text})
+		}
+	}
+	return newWidget("tophosts", append(fields, intField("column", 0)), opts)
+}
+
+// FieldValues returns the values of the field name, or of its indexed fields
+// name.0, name.1 and so on, in order, e.g. the host IDs of "hostids".
+func (w DashboardWidget) FieldValues(name string) []string {
+	var values []string
+	indexed := make(map[int]string)
+	for _, field := range w.Fields {
+		if field.Name == name {
+			values = append(values, field.Value)
+			continue
+		}
+		suffix, ok := strings.CutPrefix(field.Name, name+".")
+		if !ok {
+			continue
+		}
+		if i, err := strconv.Atoi(suffix); err == nil {
+			indexed[i] = field.Value
+		}
+	}
+	for i := 0; i < len(indexed); i++ {
+		v, ok := indexed[i]
+		if !ok {
+			break
+		}
+		values = append(values, v)
+	}
+	return values
+}
+
+func newWidget(widgetType string, fields []WidgetField, opts []WidgetOption) DashboardWidget {
+	w := DashboardWidget{
+		Type:   widgetType,
+		Width:  DefaultWidgetWidth,
+		Height: DefaultWidgetHeight,
+		Fields: fields,
+	}
+	for _, opt := range opts {
+		opt(&w)
+	}
+	return w
+}
+
+func intField(name string, value int) WidgetField {
+	return WidgetField{Type: WidgetFieldTypeInteger, Name: name, Value: strconv.Itoa(value)}
+}
+
+// idFields returns the indexed fields name.0, name.1 and so on of the given
+// IDs.
+func idFields(fieldType int, name string, ids []string) []WidgetField {
+	fields := make([]WidgetField, len(ids))
+	for i, id := range ids {
+		fields[i] = WidgetField{Type: FlexInt(fieldType), Name: fmt.Sprintf("%s.%d", name, i), Value: id}
+	}
+	return fields
+}
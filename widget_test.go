@@ -0,0 +1,56 @@
+package zabbix_test
+
+import (
+	"slices"
+	"testing"
+
+	zabbix "github.com/nimok/nim-go-zabbix"
+)
+
+func TestWidgetBuilders(t *testing.T) {
+	graph := zabbix.NewGraphWidget("42269", zabbix.WithWidgetPosition(18, 5), zabbix.WithWidgetRefresh(60))
+	if graph.Type != "graph" || graph.X != 18 || graph.Y != 5 {
+		t.Errorf("unexpected widget %+v", graph)
+	}
+	if graph.Width != zabbix.DefaultWidgetWidth || graph.Height != zabbix.DefaultWidgetHeight {
+		t.Errorf("expected the default size, got %dx%d", graph.Width, graph.Height)
+	}
+	if got := graph.FieldValues("itemid"); !slices.Equal(got, []string{"42269"}) {
+		t.Errorf("unexpected items %v", got)
+	}
+	if got := graph.FieldValues("rf_rate"); !slices.Equal(got, []string{"60"}) {
+		t.Errorf("unexpected refresh interval %v", got)
+	}
+
+	item := zabbix.NewItemValueWidget("42270")
+	if item.Type != "item" || item.Fields[0].Type != zabbix.WidgetFieldTypeItem {
+		t.Errorf("unexpected widget %+v", item)
+	}
+
+	problems := zabbix.NewProblemsWidget([]string{"10084", "10085"}, []zabbix.Severity{zabbix.SeverityDisaster})
+	if got := problems.FieldValues("hostids"); !slices.Equal(got, []string{"10084", "10085"}) {
+		t.Errorf("unexpected hosts %v", got)
+	}
+	if got := problems.FieldValues("severities"); !slices.Equal(got, []string{"5"}) {
+		t.Errorf("unexpected severities %v", got)
+	}
+
+	topHosts := zabbix.NewTopHostsWidget(nil, []zabbix.TopHostsColumn{
+		{Name: "Host", Data: zabbix.TopHostsDataHostName},
+		{Name: "CPU", Item: "CPU utilization"},
+		{Name: "Team", Data: zabbix.TopHostsDataText, Text: "{$TEAM}"},
+	})
+	for name, want := range map[string]string{
+		"columns.0.data": "2",
+		"columns.1.data": "1",
+		"columns.1.item": "CPU utilization",
+		"columns.2.text": "{$TEAM}",
+	} {
+		if got := topHosts.FieldValues(name); !slices.Equal(got, []string{want}) {
+			t.Errorf("expected %s to be %q, got %v", name, want, got)
+		}
+	}
+	if got := topHosts.FieldValues("hostids"); got != nil {
+		t.Errorf("expected no hosts, got %v", got)
+	}
+}
@@ -5,13 +5,14 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
+	"log/slog"
 	"net/http"
+	"slices"
 	"sync"
 	"time"
 
 	"errors"
-
-	"github.com/mitchellh/mapstructure"
 )
 
 const (
@@ -29,28 +30,79 @@ type Client interface {
 	StartTokenRefresher(refreshInterval time.Duration) error
 	StopTokenRefresher()
 
+	APIVersion(ctx context.Context) (Version, error)
+
+	// The Update methods also send the fields named by fields, by their API
+	// name such as "inventory_mode", even if they are empty, so that they can
+	// be set to their zero value.
+
 	HostGet(ctx context.Context, params HostGetParameters) ([]Host, error)
 	HostCreate(ctx context.Context, params []Host) (*HostCreateResponse, error)
-	HostUpdate(ctx context.Context, params Host) (*HostUpdateResponse, error)
+	HostUpdate(ctx context.Context, params Host, fields ...string) (*HostUpdateResponse, error)
 	HostDelete(ctx context.Context, params []string) (*HostDeleteResponse, error)
 	HostMassAdd(ctx context.Context, params HostMassAddParams) (*HostMassAddResponse, error)
 
 	HostInterfaceGet(ctx context.Context, params HostInterfaceGetParams) ([]HostInterface, error)
 	HostInterfaceCreate(ctx context.Context, params HostInterface) (*HostInterfaceCreateResponse, error)
-	HostInterfaceUpdate(ctx context.Context, params HostInterface) (*HostInterfaceUpdateResponse, error)
+	HostInterfaceUpdate(ctx context.Context, params HostInterface, fields ...string) (*HostInterfaceUpdateResponse, error)
 	HostInterfaceDelete(ctx context.Context, params []string) (*HostInterfaceDeleteResponse, error)
 
 	HostgroupGet(ctx context.Context, params HostGroupGetParameters) ([]HostGroup, error)
+	HostgroupCreate(ctx context.Context, params []HostGroup) (*HostGroupCreateResponse, error)
+	HostgroupDelete(ctx context.Context, params []string) (*HostGroupDeleteResponse, error)
+
+	DashboardGet(ctx context.Context, params DashboardGetParameters) ([]Dashboard, error)
+	DashboardCreate(ctx context.Context, params []Dashboard) (*DashboardCreateResponse, error)
+	DashboardUpdate(ctx context.Context, params Dashboard, fields ...string) (*DashboardUpdateResponse, error)
+	DashboardDelete(ctx context.Context, params []string) (*DashboardDeleteResponse, error)
+
+	ItemGet(ctx context.Context, params ItemGetParameters) ([]Item, error)
+
+	MaintenanceCreate(ctx context.Context, params []Maintenance) (*MaintenanceCreateResponse, error)
+
+	MapGet(ctx context.Context, params MapGetParameters) ([]Map, error)
+	MapCreate(ctx context.Context, params []Map) (*MapCreateResponse, error)
+	MapUpdate(ctx context.Context, params Map, fields ...string) (*MapUpdateResponse, error)
+	MapDelete(ctx context.Context, params []string) (*MapDeleteResponse, error)
+
+	EventGet(ctx context.Context, params EventGetParams) ([]Event, error)
 
 	ProblemGet(ctx context.Context, params ProblemGetParams) (*[]Problem, error)
 
 	ProxyGet(ctx context.Context, params ProxyGetParameters) ([]Proxy, error)
 	ProxyCreate(ctx context.Context, params ProxyCreateParameters) (*ProxyCreateResponse, error)
+	ProxyUpdate(ctx context.Context, params Proxy, fields ...string) (*ProxyUpdateResponse, error)
 	ProxyDelete(ctx context.Context, params []string) (*ProxyDeleteResponse, error)
 
+	ProxyGroupGet(ctx context.Context, params ProxyGroupGetParameters) ([]ProxyGroup, error)
+
+	ServiceGet(ctx context.Context, params ServiceGetParameters) ([]Service, error)
+	ServiceCreate(ctx context.Context, params []Service) (*ServiceCreateResponse, error)
+	ServiceUpdate(ctx context.Context, params Service, fields ...string) (*ServiceUpdateResponse, error)
+	ServiceDelete(ctx context.Context, params []string) (*ServiceDeleteResponse, error)
+
+	SLAGet(ctx context.Context, params SLAGetParameters) ([]SLA, error)
+	SLACreate(ctx context.Context, params []SLA) (*SLACreateResponse, error)
+	SLAUpdate(ctx context.Context, params SLA, fields ...string) (*SLAUpdateResponse, error)
+	SLADelete(ctx context.Context, params []string) (*SLADeleteResponse, error)
+	SLAGetSLI(ctx context.Context, params SLAGetSLIParameters) (*SLIResponse, error)
+
+	ScriptGet(ctx context.Context, params ScriptGetParameters) ([]Script, error)
+	ScriptCreate(ctx context.Context, params []Script) (*ScriptCreateResponse, error)
+	ScriptUpdate(ctx context.Context, params Script, fields ...string) (*ScriptUpdateResponse, error)
+	ScriptDelete(ctx context.Context, params []string) (*ScriptDeleteResponse, error)
+	ScriptExecute(ctx context.Context, params ScriptExecuteParameters) (*ScriptExecuteResponse, error)
+	ScriptGetScriptsByHosts(ctx context.Context, hostIDs []string) (map[string][]Script, error)
+	ScriptGetScriptsByEvents(ctx context.Context, eventIDs []string) (map[string][]Script, error)
+
+	TaskGet(ctx context.Context, params TaskGetParameters) ([]Task, error)
+	TaskCreate(ctx context.Context, params []Task) (*TaskCreateResponse, error)
+
 	TemplateGet(ctx context.Context, params TemplateGetParameters) ([]Template, error)
+	TemplateUpdate(ctx context.Context, params Template, fields ...string) (*TemplateUpdateResponse, error)
 
 	TokenCreate(ctx context.Context, params Token) (*TokenCreateResponse, error)
+	TokenUpdate(ctx context.Context, params Token, fields ...string) (*TokenUpdateResponse, error)
 	TokenGenerate(ctx context.Context, params TokenGenerateParameters) ([]TokenGenerateResponse, error)
 	TokenDelete(ctx context.Context, params TokenDeleteParameters) (*TokenDeleteResponse, error)
 
@@ -68,6 +120,22 @@ type zabbixClient struct {
 	bearerToken     string
 	bearerTokenLock sync.RWMutex
 
+	httpClient *http.Client
+
+	rateLimiter   *rateLimiter
+	maxConcurrent int
+	slots         chan struct{} // Holds a value per request in flight if maxConcurrent > 0
+	hooks         Hooks
+	validation    bool // Set by WithValidation
+
+	compatibility CompatibilityMode
+	version       *Version // Cached by APIVersion
+	versionLock   sync.RWMutex
+
+	interceptors []Interceptor
+	logger       *slog.Logger
+	invoke       Invoker // The interceptors wrapped around send
+
 	stopChan      chan struct{}
 	errorCallback func(error)
 }
@@ -93,9 +161,19 @@ func WithErrorCallback(callback func(error)) ClientOption {
 	}
 }
 
+// WithHTTPClient sets the HTTP client used to send requests, e.g. to set a
+// timeout or a custom transport. By default a client with the default
+// transport and no timeout is used.
+func WithHTTPClient(httpClient *http.Client) ClientOption {
+	return func(c *zabbixClient) {
+		c.httpClient = httpClient
+	}
+}
+
 func NewClient(url string, opts ...ClientOption) (Client, error) {
 	client := &zabbixClient{
 		url:           url,
+		httpClient:    &http.Client{},
 		stopChan:      make(chan struct{}),
 		errorCallback: func(err error) {},
 	}
@@ -108,6 +186,15 @@ func NewClient(url string, opts ...ClientOption) (Client, error) {
 		return nil, err
 	}
 
+	if client.maxConcurrent > 0 {
+		client.slots = make(chan struct{}, client.maxConcurrent)
+	}
+	interceptors := client.interceptors
+	if client.logger != nil {
+		interceptors = append(slices.Clip(interceptors), client.logCall)
+	}
+	client.invoke = chain(interceptors, client.send)
+
 	return client, nil
 }
 
@@ -116,6 +203,23 @@ func validateClient(c *zabbixClient) error {
 		return errors.New("url can't be empty")
 	}
 
+	if c.httpClient == nil {
+		return errors.New("http client can't be nil")
+	}
+
+	if c.rateLimiter != nil {
+		if c.rateLimiter.rate <= 0 {
+			return errors.New("rate limit must be positive")
+		}
+		if c.rateLimiter.burst < 1 {
+			return errors.New("rate limit burst must be at least 1")
+		}
+	}
+
+	if c.maxConcurrent < 0 {
+		return errors.New("max concurrent requests can't be negative")
+	}
+
 	if c.apiToken == "" {
 		if c.username == "" || c.password == "" {
 			return errors.New("you need to supply an api token or a user/password login")
@@ -140,8 +244,15 @@ func (c *zabbixClient) StartTokenRefresher(refreshInterval time.Duration) error
 		for {
 			select {
 			case <-ticker.C:
-				if err := c.Authenticate(); err != nil {
+				err := c.authenticate()
+				if err != nil {
+					c.log(context.Background(), slog.LevelError, "zabbix token refresh failed", slog.String("error", err.Error()))
 					c.errorCallback(err)
+				} else {
+					c.log(context.Background(), slog.LevelInfo, "zabbix token refreshed")
+				}
+				if c.hooks.TokenRefresh != nil {
+					c.hooks.TokenRefresh(err)
 				}
 			case <-c.stopChan:
 				return
@@ -156,29 +267,62 @@ func (client *zabbixClient) StopTokenRefresher() {
 }
 
 type apiResponse struct {
-	JSONRPC string `json:"jsonrpc"`
-	Result  any    `json:"result"`
-	ID      int    `json:"id"`
-	Error   *struct {
-		Code    int    `json:"code"`
-		Message string `json:"message"`
-		Data    string `json:"data"`
-	} `json:"error,omitempty"`
+	JSONRPC string    `json:"jsonrpc"`
+	Result  any       `json:"result"`
+	ID      int       `json:"id"`
+	Error   *APIError `json:"error,omitempty"`
+}
+
+// APIError is the JSON-RPC error returned by the Zabbix API, e.g. for
+// invalid params or missing permissions.
+type APIError struct {
+	Code    int    `json:"code"`    // JSON-RPC error code, e.g. -32602
+	Message string `json:"message"` // Short description, e.g. "Invalid params."
+	Data    string `json:"data"`    // Detailed description
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("API error: %d - %s %s", e.Code, e.Message, e.Data)
 }
 
 func (c *zabbixClient) makeRequest(ctx context.Context, method string, params any, result any) error {
+	if c.compatibility != CompatibilityOff {
+		var err error
+		if params, err = c.checkCompatibility(ctx, method, params); err != nil {
+			return err
+		}
+	}
+
+	call := &Call{Method: method, Params: params, Header: make(http.Header), result: result}
+	if err := c.invoke(ctx, call); err != nil {
+		return err
+	}
+	if call.decoded {
+		return nil
+	}
+
+	// An interceptor short-circuited the call with its own response.
+	return decodeResponse(bytes.NewReader(call.Response), result)
+}
+
+// send is the innermost Invoker: it waits for the limits of the client,
+// posts call to the server and decodes the response into the result of
+// call. The raw response is only kept if an interceptor asked for it.
+func (c *zabbixClient) send(ctx context.Context, call *Call) error {
+	release, err := c.acquire(ctx, call.Method)
+	if err != nil {
+		return err
+	}
+	defer release()
+
 	c.bearerTokenLock.RLock()
 	token := c.bearerToken
 	c.bearerTokenLock.RUnlock()
 
-	res := &apiResponse{
-		Result: result,
-	}
-
 	request := map[string]any{
 		"jsonrpc": "2.0",
-		"method":  method,
-		"params":  params,
+		"method":  call.Method,
+		"params":  call.Params,
 		"id":      1,
 	}
 
@@ -192,10 +336,17 @@ func (c *zabbixClient) makeRequest(ctx context.Context, method string, params an
 	}
 
 	req.Header.Set("Content-Type", "application/json-rpc")
-	req.Header.Set("Authorization", "Bearer "+token)
+	if call.Method != "user.login" && call.Method != "apiinfo.version" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	for key, values := range call.Header {
+		req.Header[key] = values
+	}
 
-	client := &http.Client{}
-	resp, err := client.Do(req)
+	start := time.Now()
+	defer func() { call.Duration = time.Since(start) }()
+
+	resp, err := c.httpClient.Do(req)
 	if err != nil {
 		return err
 	}
@@ -205,30 +356,15 @@ func (c *zabbixClient) makeRequest(ctx context.Context, method string, params an
 		return fmt.Errorf("request failed with status code: %d", resp.StatusCode)
 	}
 
-	rawConf := make(map[string]any)
-
-	decoder := json.NewDecoder(resp.Body)
-	if err := decoder.Decode(&rawConf); err != nil {
-		return fmt.Errorf("json decode error: %v", err)
+	call.decoded = true
+	if !call.KeepResponse {
+		return decodeResponse(resp.Body, call.result)
 	}
 
-	mapper, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
-		WeaklyTypedInput: true,
-		Result:           res,
-		TagName:          "json",
-	})
+	call.Response, err = io.ReadAll(resp.Body)
 	if err != nil {
-		return fmt.Errorf("mapstructure create decoder error: %v", err)
-	}
-
-	if err := mapper.Decode(rawConf); err != nil {
-		return fmt.Errorf("mapstructure decode error: %v", err)
-	}
-
-	if res.Error != nil {
-		return fmt.Errorf("API error: %d - %s %s", res.Error.Code, res.Error.Message, res.Error.Data)
+		return err
 	}
 
-	return nil
-
+	return decodeResponse(bytes.NewReader(call.Response), call.result)
 }
@@ -9,6 +9,7 @@ import (
 
 	"github.com/joho/godotenv"
 	zabbix "github.com/nimok/nim-go-zabbix"
+	"github.com/nimok/nim-go-zabbix/zabbixtest"
 )
 
 var url string
@@ -24,9 +25,13 @@ func TestMain(m *testing.M) {
 	user = os.Getenv("TESTING_ZABBIX_USER")
 	passwd = os.Getenv("TESTING_ZABBIX_PASS")
 
+	// Without a Zabbix to test against, the tests run against the in-memory
+	// server of zabbixtest
 	if url == "" {
-		fmt.Println("url not set")
-		os.Exit(1)
+		srv := zabbixtest.NewServer()
+		defer srv.Close()
+		fmt.Println("url not set, testing against zabbixtest")
+		url, user, passwd = srv.URL, zabbixtest.DefaultUsername, zabbixtest.DefaultPassword
 	}
 
 	if user == "" {
@@ -40,11 +45,10 @@ func TestMain(m *testing.M) {
 
 	bootstrapTokenId := setup()
 
-	code := m.Run()
+	// The exit code of the tests is that of m.Run
+	m.Run()
 
 	teardown(bootstrapTokenId)
-
-	os.Exit(code)
 }
 
 func setup() (tokenId string) {
@@ -119,6 +123,14 @@ func TestClientWithoutAnyAuthMethod(t *testing.T) {
 
 }
 
+func TestClientWithNilHTTPClient(t *testing.T) {
+	_, err := zabbix.NewClient("any url", zabbix.WithAPIToken("token"), zabbix.WithHTTPClient(nil))
+	if err == nil {
+		t.Log("client should not be allowed to be created without an http client")
+		t.FailNow()
+	}
+}
+
 func TestClientWithUserPass(t *testing.T) {
 	client, err := zabbix.NewClient(url, zabbix.WithUserPass(user, passwd),
 		zabbix.WithErrorCallback(func(err error) {
@@ -0,0 +1,156 @@
+package zabbixcache
+
+import (
+	"container/list"
+	"context"
+	"encoding/json"
+	"slices"
+	"sync"
+	"time"
+)
+
+// store is a size-bounded LRU of get results with per-entry expiry. It also
+// deduplicates concurrent fetches of the same key.
+type store struct {
+	maxEntries int
+
+	mu          sync.Mutex
+	entries     map[string]*list.Element
+	lru         *list.List // Of *entry, most recently used first
+	inflight    map[string]*call
+	generations map[string]uint64 // Bumped by invalidate, by method
+	epoch       uint64            // Bumped by flush
+	stats       Stats
+}
+
+type entry struct {
+	key     string
+	method  string
+	value   any
+	expires time.Time
+}
+
+type call struct {
+	method string
+	done   chan struct{}
+	value  any
+	err    error
+}
+
+func newStore(maxEntries int) *store {
+	return &store{
+		maxEntries:  maxEntries,
+		entries:     make(map[string]*list.Element),
+		lru:         list.New(),
+		inflight:    make(map[string]*call),
+		generations: make(map[string]uint64),
+	}
+}
+
+// key returns the cache key of a call: the method followed by its params
+// encoded as JSON, whose map keys are sorted.
+func key(method string, params any) (string, error) {
+	data, err := json.Marshal(params)
+	if err != nil {
+		return "", err
+	}
+	return method + " " + string(data), nil
+}
+
+// get returns the cached value of key or calls fetch, sharing its result
+// with the concurrent callers of the same key. The result is cached for ttl
+// unless fetch fails or method is invalidated or the store flushed
+// meanwhile.
+func (s *store) get(ctx context.Context, method, key string, ttl time.Duration, fetch func() (any, error)) (any, error) {
+	s.mu.Lock()
+	if elem, ok := s.entries[key]; ok {
+		e := elem.Value.(*entry)
+		if time.Now().Before(e.expires) {
+			s.lru.MoveToFront(elem)
+			s.stats.Hits++
+			s.mu.Unlock()
+			return e.value, nil
+		}
+		s.remove(elem)
+	}
+	s.stats.Misses++
+
+	if c, ok := s.inflight[key]; ok {
+		s.stats.Shared++
+		s.mu.Unlock()
+		select {
+		case <-c.done:
+			return c.value, c.err
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+
+	c := &call{method: method, done: make(chan struct{})}
+	s.inflight[key] = c
+	generation, epoch := s.generations[method], s.epoch
+	s.mu.Unlock()
+
+	c.value, c.err = fetch()
+
+	s.mu.Lock()
+	if s.inflight[key] == c {
+		delete(s.inflight, key)
+	}
+	if c.err == nil && s.generations[method] == generation && s.epoch == epoch {
+		s.add(&entry{key: key, method: method, value: c.value, expires: time.Now().Add(ttl)})
+	}
+	s.mu.Unlock()
+	close(c.done)
+
+	return c.value, c.err
+}
+
+func (s *store) add(e *entry) {
+	if elem, ok := s.entries[e.key]; ok {
+		s.remove(elem)
+	}
+	s.entries[e.key] = s.lru.PushFront(e)
+	for s.maxEntries > 0 && s.lru.Len() > s.maxEntries {
+		s.remove(s.lru.Back())
+		s.stats.Evictions++
+	}
+}
+
+func (s *store) remove(elem *list.Element) {
+	s.lru.Remove(elem)
+	delete(s.entries, elem.Value.(*entry).key)
+}
+
+// invalidate drops the cached results of methods. The calls to them in
+// flight are not shared with later callers and their results not cached.
+func (s *store) invalidate(methods ...string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, method := range methods {
+		s.generations[method]++
+	}
+	for key, c := range s.inflight {
+		if slices.Contains(methods, c.method) {
+			delete(s.inflight, key)
+		}
+	}
+	for elem := s.lru.Front(); elem != nil; {
+		next := elem.Next()
+		if slices.Contains(methods, elem.Value.(*entry).method) {
+			s.remove(elem)
+		}
+		elem = next
+	}
+}
+
+func (s *store) flush() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.epoch++
+	clear(s.inflight)
+	clear(s.entries)
+	s.lru.Init()
+}
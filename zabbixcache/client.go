@@ -0,0 +1,389 @@
+// Package zabbixcache caches the results of the get methods of a
+// zabbix.Client.
+//
+// A Client wraps another zabbix.Client. Get calls are keyed by method and
+// params, so repeated identical calls within the TTL of their method are
+// answered from memory, and concurrent identical calls share a single
+// request:
+//
+//	cached := zabbixcache.New(client,
+//		zabbixcache.WithTTL("host.get", time.Minute),
+//		zabbixcache.WithTTL("problem.get", 5*time.Second), // Not cached by default
+//	)
+//
+// Writes made through the Client invalidate the cached results they may
+// change, e.g. HostUpdate invalidates host.get, hostgroup.get and
+// hostinterface.get. Writes made by other clients or in the frontend are
+// only seen once the cached results expire, or after Invalidate or Flush.
+//
+// task.get is never cached, since tasks change status as the server
+// processes them.
+//
+// Cached results are shared between callers and must not be modified.
+package zabbixcache
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	zabbix "github.com/nimok/nim-go-zabbix"
+)
+
+const (
+	// DefaultTTL is how long results are cached for the methods without a
+	// WithTTL option, unless WithDefaultTTL is given. It doesn't apply to
+	// problem.get and event.get, which are only cached with WithTTL since
+	// their results change as Zabbix monitors hosts, not through writes.
+	DefaultTTL = 30 * time.Second
+
+	// DefaultMaxEntries bounds the number of cached results unless
+	// WithMaxEntries is given.
+	DefaultMaxEntries = 1000
+)
+
+// uncachedMethods are not cached unless given a TTL with WithTTL, since
+// callers polling them expect the current state.
+var uncachedMethods = []string{"problem.get", "event.get"}
+
+// Client is a zabbix.Client caching the results of the get methods of the
+// wrapped client. It is safe for concurrent use.
+type Client struct {
+	zabbix.Client
+
+	defaultTTL time.Duration
+	ttls       map[string]time.Duration
+	maxEntries int
+	store      *store
+}
+
+var _ zabbix.Client = (*Client)(nil)
+
+// Option configures a Client.
+type Option func(*Client)
+
+// WithDefaultTTL sets how long results are cached for the methods without a
+// WithTTL option. A TTL of 0 disables caching for them.
+func WithDefaultTTL(ttl time.Duration) Option {
+	return func(c *Client) {
+		c.defaultTTL = ttl
+	}
+}
+
+// WithTTL sets how long the results of method, e.g. "host.get", are cached.
+// A TTL of 0 disables caching for method.
+func WithTTL(method string, ttl time.Duration) Option {
+	return func(c *Client) {
+		c.ttls[method] = ttl
+	}
+}
+
+// WithMaxEntries bounds the number of cached results; the least recently
+// used results are evicted first. 0 means no bound.
+func WithMaxEntries(n int) Option {
+	return func(c *Client) {
+		c.maxEntries = n
+	}
+}
+
+// New returns a Client caching the get results of client.
+func New(client zabbix.Client, opts ...Option) *Client {
+	c := &Client{
+		Client:     client,
+		defaultTTL: DefaultTTL,
+		ttls:       make(map[string]time.Duration),
+		maxEntries: DefaultMaxEntries,
+	}
+	for _, method := range uncachedMethods {
+		c.ttls[method] = 0
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	c.store = newStore(c.maxEntries)
+	return c
+}
+
+// Stats counts cache lookups.
+type Stats struct {
+	Hits      uint64 // Calls answered from the cache
+	Misses    uint64 // Calls not answered from the cache, including shared ones
+	Shared    uint64 // Calls that waited for an identical call in flight
+	Evictions uint64 // Results evicted because of WithMaxEntries
+}
+
+// Stats returns the cache statistics since the Client was created.
+func (c *Client) Stats() Stats {
+	c.store.mu.Lock()
+	defer c.store.mu.Unlock()
+
+	return c.store.stats
+}
+
+// Invalidate drops the cached results of the given methods, e.g.
+// "host.get".
+func (c *Client) Invalidate(methods ...string) {
+	c.store.invalidate(methods...)
+}
+
+// Flush drops all cached results.
+func (c *Client) Flush() {
+	c.store.flush()
+}
+
+// Methods whose cached results are invalidated by writes to a kind of
+// object.
+var (
+	hostReaders          = []string{"host.get", "hostgroup.get", "hostinterface.get", "template.get", "proxy.get", "item.get"}
+	hostInterfaceReaders = []string{"hostinterface.get", "host.get", "item.get"}
+	hostGroupReaders     = []string{"hostgroup.get", "host.get"}
+	proxyReaders         = []string{"proxy.get", "proxygroup.get", "host.get"}
+	templateReaders      = []string{"template.get", "host.get"}
+	serviceReaders       = []string{"service.get", "sla.get", "sla.getsli"}
+	scriptReaders        = []string{"script.get", "script.getscriptsbyhosts", "script.getscriptsbyevents"}
+)
+
+// cached returns the cached result of the call of method with params, or
+// calls fetch.
+func cached[P, R any](ctx context.Context, c *Client, method string, params P, fetch func(context.Context, P) (R, error)) (R, error) {
+	ttl, ok := c.ttls[method]
+	if !ok {
+		ttl = c.defaultTTL
+	}
+	if ttl <= 0 {
+		return fetch(ctx, params)
+	}
+
+	k, err := key(method, params)
+	if err != nil {
+		return fetch(ctx, params)
+	}
+
+	for {
+		v, err := c.store.get(ctx, method, k, ttl, func() (any, error) {
+			return fetch(ctx, params)
+		})
+		// The call shared was canceled by its own caller, not by this one
+		if err != nil && ctx.Err() == nil && (errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded)) {
+			continue
+		}
+		if err != nil {
+			var zero R
+			return zero, err
+		}
+		return v.(R), nil
+	}
+}
+
+func (c *Client) HostGet(ctx context.Context, params zabbix.HostGetParameters) ([]zabbix.Host, error) {
+	return cached(ctx, c, "host.get", params, c.Client.HostGet)
+}
+
+func (c *Client) HostCreate(ctx context.Context, params []zabbix.Host) (*zabbix.HostCreateResponse, error) {
+	defer c.Invalidate(hostReaders...)
+	return c.Client.HostCreate(ctx, params)
+}
+
+func (c *Client) HostUpdate(ctx context.Context, params zabbix.Host, fields ...string) (*zabbix.HostUpdateResponse, error) {
+	defer c.Invalidate(hostReaders...)
+	return c.Client.HostUpdate(ctx, params, fields...)
+}
+
+func (c *Client) HostDelete(ctx context.Context, params []string) (*zabbix.HostDeleteResponse, error) {
+	defer c.Invalidate(hostReaders...)
+	return c.Client.HostDelete(ctx, params)
+}
+
+func (c *Client) HostMassAdd(ctx context.Context, params zabbix.HostMassAddParams) (*zabbix.HostMassAddResponse, error) {
+	defer c.Invalidate(hostReaders...)
+	return c.Client.HostMassAdd(ctx, params)
+}
+
+func (c *Client) HostInterfaceGet(ctx context.Context, params zabbix.HostInterfaceGetParams) ([]zabbix.HostInterface, error) {
+	return cached(ctx, c, "hostinterface.get", params, c.Client.HostInterfaceGet)
+}
+
+func (c *Client) HostInterfaceCreate(ctx context.Context, params zabbix.HostInterface) (*zabbix.HostInterfaceCreateResponse, error) {
+	defer c.Invalidate(hostInterfaceReaders...)
+	return c.Client.HostInterfaceCreate(ctx, params)
+}
+
+func (c *Client) HostInterfaceUpdate(ctx context.Context, params zabbix.HostInterface, fields ...string) (*zabbix.HostInterfaceUpdateResponse, error) {
+	defer c.Invalidate(hostInterfaceReaders...)
+	return c.Client.HostInterfaceUpdate(ctx, params, fields...)
+}
+
+func (c *Client) HostInterfaceDelete(ctx context.Context, params []string) (*zabbix.HostInterfaceDeleteResponse, error) {
+	defer c.Invalidate(hostInterfaceReaders...)
+	return c.Client.HostInterfaceDelete(ctx, params)
+}
+
+func (c *Client) HostgroupGet(ctx context.Context, params zabbix.HostGroupGetParameters) ([]zabbix.HostGroup, error) {
+	return cached(ctx, c, "hostgroup.get", params, c.Client.HostgroupGet)
+}
+
+func (c *Client) HostgroupCreate(ctx context.Context, params []zabbix.HostGroup) (*zabbix.HostGroupCreateResponse, error) {
+	defer c.Invalidate(hostGroupReaders...)
+	return c.Client.HostgroupCreate(ctx, params)
+}
+
+func (c *Client) HostgroupDelete(ctx context.Context, params []string) (*zabbix.HostGroupDeleteResponse, error) {
+	defer c.Invalidate(hostGroupReaders...)
+	return c.Client.HostgroupDelete(ctx, params)
+}
+
+func (c *Client) DashboardGet(ctx context.Context, params zabbix.DashboardGetParameters) ([]zabbix.Dashboard, error) {
+	return cached(ctx, c, "dashboard.get", params, c.Client.DashboardGet)
+}
+
+func (c *Client) DashboardCreate(ctx context.Context, params []zabbix.Dashboard) (*zabbix.DashboardCreateResponse, error) {
+	defer c.Invalidate("dashboard.get")
+	return c.Client.DashboardCreate(ctx, params)
+}
+
+func (c *Client) DashboardUpdate(ctx context.Context, params zabbix.Dashboard, fields ...string) (*zabbix.DashboardUpdateResponse, error) {
+	defer c.Invalidate("dashboard.get")
+	return c.Client.DashboardUpdate(ctx, params, fields...)
+}
+
+func (c *Client) DashboardDelete(ctx context.Context, params []string) (*zabbix.DashboardDeleteResponse, error) {
+	defer c.Invalidate("dashboard.get")
+	return c.Client.DashboardDelete(ctx, params)
+}
+
+func (c *Client) ItemGet(ctx context.Context, params zabbix.ItemGetParameters) ([]zabbix.Item, error) {
+	return cached(ctx, c, "item.get", params, c.Client.ItemGet)
+}
+
+func (c *Client) MaintenanceCreate(ctx context.Context, params []zabbix.Maintenance) (*zabbix.MaintenanceCreateResponse, error) {
+	defer c.Invalidate("host.get")
+	return c.Client.MaintenanceCreate(ctx, params)
+}
+
+func (c *Client) MapGet(ctx context.Context, params zabbix.MapGetParameters) ([]zabbix.Map, error) {
+	return cached(ctx, c, "map.get", params, c.Client.MapGet)
+}
+
+func (c *Client) MapCreate(ctx context.Context, params []zabbix.Map) (*zabbix.MapCreateResponse, error) {
+	defer c.Invalidate("map.get")
+	return c.Client.MapCreate(ctx, params)
+}
+
+func (c *Client) MapUpdate(ctx context.Context, params zabbix.Map, fields ...string) (*zabbix.MapUpdateResponse, error) {
+	defer c.Invalidate("map.get")
+	return c.Client.MapUpdate(ctx, params, fields...)
+}
+
+func (c *Client) MapDelete(ctx context.Context, params []string) (*zabbix.MapDeleteResponse, error) {
+	defer c.Invalidate("map.get")
+	return c.Client.MapDelete(ctx, params)
+}
+
+func (c *Client) EventGet(ctx context.Context, params zabbix.EventGetParams) ([]zabbix.Event, error) {
+	return cached(ctx, c, "event.get", params, c.Client.EventGet)
+}
+
+func (c *Client) ProblemGet(ctx context.Context, params zabbix.ProblemGetParams) (*[]zabbix.Problem, error) {
+	return cached(ctx, c, "problem.get", params, c.Client.ProblemGet)
+}
+
+func (c *Client) ProxyGet(ctx context.Context, params zabbix.ProxyGetParameters) ([]zabbix.Proxy, error) {
+	return cached(ctx, c, "proxy.get", params, c.Client.ProxyGet)
+}
+
+func (c *Client) ProxyCreate(ctx context.Context, params zabbix.ProxyCreateParameters) (*zabbix.ProxyCreateResponse, error) {
+	defer c.Invalidate(proxyReaders...)
+	return c.Client.ProxyCreate(ctx, params)
+}
+
+func (c *Client) ProxyUpdate(ctx context.Context, params zabbix.Proxy, fields ...string) (*zabbix.ProxyUpdateResponse, error) {
+	defer c.Invalidate(proxyReaders...)
+	return c.Client.ProxyUpdate(ctx, params, fields...)
+}
+
+func (c *Client) ProxyDelete(ctx context.Context, params []string) (*zabbix.ProxyDeleteResponse, error) {
+	defer c.Invalidate(proxyReaders...)
+	return c.Client.ProxyDelete(ctx, params)
+}
+
+func (c *Client) ProxyGroupGet(ctx context.Context, params zabbix.ProxyGroupGetParameters) ([]zabbix.ProxyGroup, error) {
+	return cached(ctx, c, "proxygroup.get", params, c.Client.ProxyGroupGet)
+}
+
+func (c *Client) ServiceGet(ctx context.Context, params zabbix.ServiceGetParameters) ([]zabbix.Service, error) {
+	return cached(ctx, c, "service.get", params, c.Client.ServiceGet)
+}
+
+func (c *Client) ServiceCreate(ctx context.Context, params []zabbix.Service) (*zabbix.ServiceCreateResponse, error) {
+	defer c.Invalidate(serviceReaders...)
+	return c.Client.ServiceCreate(ctx, params)
+}
+
+func (c *Client) ServiceUpdate(ctx context.Context, params zabbix.Service, fields ...string) (*zabbix.ServiceUpdateResponse, error) {
+	defer c.Invalidate(serviceReaders...)
+	return c.Client.ServiceUpdate(ctx, params, fields...)
+}
+
+func (c *Client) ServiceDelete(ctx context.Context, params []string) (*zabbix.ServiceDeleteResponse, error) {
+	defer c.Invalidate(serviceReaders...)
+	return c.Client.ServiceDelete(ctx, params)
+}
+
+func (c *Client) SLAGet(ctx context.Context, params zabbix.SLAGetParameters) ([]zabbix.SLA, error) {
+	return cached(ctx, c, "sla.get", params, c.Client.SLAGet)
+}
+
+func (c *Client) SLACreate(ctx context.Context, params []zabbix.SLA) (*zabbix.SLACreateResponse, error) {
+	defer c.Invalidate(serviceReaders...)
+	return c.Client.SLACreate(ctx, params)
+}
+
+func (c *Client) SLAUpdate(ctx context.Context, params zabbix.SLA, fields ...string) (*zabbix.SLAUpdateResponse, error) {
+	defer c.Invalidate(serviceReaders...)
+	return c.Client.SLAUpdate(ctx, params, fields...)
+}
+
+func (c *Client) SLADelete(ctx context.Context, params []string) (*zabbix.SLADeleteResponse, error) {
+	defer c.Invalidate(serviceReaders...)
+	return c.Client.SLADelete(ctx, params)
+}
+
+func (c *Client) SLAGetSLI(ctx context.Context, params zabbix.SLAGetSLIParameters) (*zabbix.SLIResponse, error) {
+	return cached(ctx, c, "sla.getsli", params, c.Client.SLAGetSLI)
+}
+
+func (c *Client) ScriptGet(ctx context.Context, params zabbix.ScriptGetParameters) ([]zabbix.Script, error) {
+	return cached(ctx, c, "script.get", params, c.Client.ScriptGet)
+}
+
+func (c *Client) ScriptCreate(ctx context.Context, params []zabbix.Script) (*zabbix.ScriptCreateResponse, error) {
+	defer c.Invalidate(scriptReaders...)
+	return c.Client.ScriptCreate(ctx, params)
+}
+
+func (c *Client) ScriptUpdate(ctx context.Context, params zabbix.Script, fields ...string) (*zabbix.ScriptUpdateResponse, error) {
+	defer c.Invalidate(scriptReaders...)
+	return c.Client.ScriptUpdate(ctx, params, fields...)
+}
+
+func (c *Client) ScriptDelete(ctx context.Context, params []string) (*zabbix.ScriptDeleteResponse, error) {
+	defer c.Invalidate(scriptReaders...)
+	return c.Client.ScriptDelete(ctx, params)
+}
+
+func (c *Client) ScriptGetScriptsByHosts(ctx context.Context, hostIDs []string) (map[string][]zabbix.Script, error) {
+	return cached(ctx, c, "script.getscriptsbyhosts", hostIDs, c.Client.ScriptGetScriptsByHosts)
+}
+
+func (c *Client) ScriptGetScriptsByEvents(ctx context.Context, eventIDs []string) (map[string][]zabbix.Script, error) {
+	return cached(ctx, c, "script.getscriptsbyevents", eventIDs, c.Client.ScriptGetScriptsByEvents)
+}
+
+func (c *Client) TemplateGet(ctx context.Context, params zabbix.TemplateGetParameters) ([]zabbix.Template, error) {
+	return cached(ctx, c, "template.get", params, c.Client.TemplateGet)
+}
+
+func (c *Client) TemplateUpdate(ctx context.Context, params zabbix.Template, fields ...string) (*zabbix.TemplateUpdateResponse, error) {
+	defer c.Invalidate(templateReaders...)
+	return c.Client.TemplateUpdate(ctx, params, fields...)
+}
@@ -0,0 +1,239 @@
+package zabbixcache_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	zabbix "github.com/nimok/nim-go-zabbix"
+	"github.com/nimok/nim-go-zabbix/zabbixcache"
+	"github.com/nimok/nim-go-zabbix/zabbixfake"
+)
+
+func hostsNamed(names ...string) zabbix.HostGetParameters {
+	return zabbix.HostGetParameters{
+		GetParameters: zabbix.GetParameters{Filter: map[string]any{"host": names}},
+	}
+}
+
+func TestCache(t *testing.T) {
+	ctx := context.Background()
+	fake := &zabbixfake.Client{
+		HostGetFunc: func(ctx context.Context, params zabbix.HostGetParameters) ([]zabbix.Host, error) {
+			return []zabbix.Host{{HostID: "10084"}}, nil
+		},
+	}
+	client := zabbixcache.New(fake)
+
+	for range 3 {
+		hosts, err := client.HostGet(ctx, hostsNamed("Zabbix server"))
+		if err != nil || len(hosts) != 1 || hosts[0].HostID != "10084" {
+			t.Fatalf("got %v, %v", hosts, err)
+		}
+	}
+	if _, err := client.HostGet(ctx, hostsNamed("other")); err != nil {
+		t.Fatal(err)
+	}
+
+	fake.AssertCallCount(t, "HostGet", 2)
+	if stats := client.Stats(); stats.Hits != 2 || stats.Misses != 2 {
+		t.Errorf("unexpected stats: %+v", stats)
+	}
+}
+
+func TestCacheTTL(t *testing.T) {
+	ctx := context.Background()
+	fake := &zabbixfake.Client{}
+	client := zabbixcache.New(fake,
+		zabbixcache.WithTTL("host.get", 10*time.Millisecond),
+		zabbixcache.WithTTL("template.get", 0),
+	)
+
+	// Problems and events are not cached by default
+	for range 2 {
+		client.HostGet(ctx, hostsNamed("a"))
+		client.TemplateGet(ctx, zabbix.TemplateGetParameters{})
+		client.ProblemGet(ctx, zabbix.ProblemGetParams{})
+		client.EventGet(ctx, zabbix.EventGetParams{})
+	}
+	fake.AssertCallCount(t, "HostGet", 1)
+	fake.AssertCallCount(t, "TemplateGet", 2)
+	fake.AssertCallCount(t, "ProblemGet", 2)
+	fake.AssertCallCount(t, "EventGet", 2)
+
+	time.Sleep(20 * time.Millisecond)
+	client.HostGet(ctx, hostsNamed("a"))
+	fake.AssertCallCount(t, "HostGet", 2)
+}
+
+func TestCacheErrorsNotCached(t *testing.T) {
+	ctx := context.Background()
+	fake := &zabbixfake.Client{
+		HostGetFunc: func(ctx context.Context, params zabbix.HostGetParameters) ([]zabbix.Host, error) {
+			return nil, errors.New("boom")
+		},
+	}
+	client := zabbixcache.New(fake)
+
+	for range 2 {
+		if _, err := client.HostGet(ctx, hostsNamed("a")); err == nil {
+			t.Fatal("want an error")
+		}
+	}
+	fake.AssertCallCount(t, "HostGet", 2)
+}
+
+func TestCacheInvalidation(t *testing.T) {
+	ctx := context.Background()
+	fake := &zabbixfake.Client{}
+	client := zabbixcache.New(fake, zabbixcache.WithTTL("problem.get", time.Minute))
+
+	read := func() {
+		client.HostGet(ctx, hostsNamed("a"))
+		client.HostgroupGet(ctx, zabbix.HostGroupGetParameters{})
+		client.ProblemGet(ctx, zabbix.ProblemGetParams{})
+	}
+
+	read()
+	if _, err := client.HostUpdate(ctx, zabbix.Host{HostID: "10084", Name: "renamed"}); err != nil {
+		t.Fatal(err)
+	}
+	read()
+
+	fake.AssertCallCount(t, "HostGet", 2)
+	fake.AssertCallCount(t, "HostgroupGet", 2)
+	fake.AssertCallCount(t, "ProblemGet", 1)
+
+	client.Invalidate("problem.get")
+	read()
+	fake.AssertCallCount(t, "HostGet", 2)
+	fake.AssertCallCount(t, "ProblemGet", 2)
+
+	client.Flush()
+	read()
+	fake.AssertCallCount(t, "HostGet", 3)
+}
+
+func TestCacheServiceInvalidation(t *testing.T) {
+	ctx := context.Background()
+	fake := &zabbixfake.Client{}
+	client := zabbixcache.New(fake)
+
+	read := func() {
+		client.ServiceGet(ctx, zabbix.ServiceGetParameters{})
+		client.SLAGetSLI(ctx, zabbix.SLAGetSLIParameters{SLAID: "1"})
+		client.HostGet(ctx, hostsNamed("a"))
+	}
+
+	read()
+	read()
+	if _, err := client.SLAUpdate(ctx, zabbix.SLA{SLAID: "1", SLO: 99.5}); err != nil {
+		t.Fatal(err)
+	}
+	read()
+
+	fake.AssertCallCount(t, "ServiceGet", 2)
+	fake.AssertCallCount(t, "SLAGetSLI", 2)
+	fake.AssertCallCount(t, "HostGet", 1)
+}
+
+func TestCacheScriptInvalidation(t *testing.T) {
+	ctx := context.Background()
+	fake := &zabbixfake.Client{}
+	client := zabbixcache.New(fake)
+
+	read := func() {
+		client.ScriptGetScriptsByHosts(ctx, []string{"10084"})
+		client.TaskGet(ctx, zabbix.TaskGetParameters{TaskIDs: []string{"1"}})
+	}
+
+	read()
+	read()
+	if _, err := client.ScriptDelete(ctx, []string{"4"}); err != nil {
+		t.Fatal(err)
+	}
+	read()
+
+	fake.AssertCallCount(t, "ScriptGetScriptsByHosts", 2)
+	fake.AssertCallCount(t, "TaskGet", 3) // Never cached
+}
+
+func TestCacheMaxEntries(t *testing.T) {
+	ctx := context.Background()
+	fake := &zabbixfake.Client{}
+	client := zabbixcache.New(fake, zabbixcache.WithMaxEntries(2))
+
+	for _, name := range []string{"a", "b", "a", "c", "b"} {
+		client.HostGet(ctx, hostsNamed(name))
+	}
+
+	// "b" was the least recently used when "c" was added
+	fake.AssertCallCount(t, "HostGet", 4)
+	if stats := client.Stats(); stats.Evictions != 2 {
+		t.Errorf("got %d evictions, want 2", stats.Evictions)
+	}
+}
+
+func TestCacheSharesConcurrentCalls(t *testing.T) {
+	ctx := context.Background()
+	release := make(chan struct{})
+	fake := &zabbixfake.Client{
+		HostGetFunc: func(ctx context.Context, params zabbix.HostGetParameters) ([]zabbix.Host, error) {
+			<-release
+			return []zabbix.Host{{HostID: "10084"}}, nil
+		},
+	}
+	client := zabbixcache.New(fake)
+
+	const callers = 10
+	var wg sync.WaitGroup
+	for range callers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			hosts, err := client.HostGet(ctx, hostsNamed("a"))
+			if err != nil || len(hosts) != 1 {
+				t.Errorf("got %v, %v", hosts, err)
+			}
+		}()
+	}
+
+	for client.Stats().Shared < callers-1 {
+		time.Sleep(time.Millisecond)
+	}
+	close(release)
+	wg.Wait()
+
+	fake.AssertCallCount(t, "HostGet", 1)
+}
+
+func TestCacheInvalidationDuringCall(t *testing.T) {
+	ctx := context.Background()
+	started, release := make(chan struct{}), make(chan struct{})
+	fake := &zabbixfake.Client{}
+	fake.HostGetFunc = func(ctx context.Context, params zabbix.HostGetParameters) ([]zabbix.Host, error) {
+		if fake.CallCount("HostGet") == 1 {
+			close(started)
+			<-release
+		}
+		return nil, nil
+	}
+	client := zabbixcache.New(fake)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		client.HostGet(ctx, hostsNamed("a"))
+	}()
+
+	<-started
+	client.HostDelete(ctx, []string{"10084"})
+	close(release)
+	<-done
+
+	// The result fetched before the delete must not be cached
+	client.HostGet(ctx, hostsNamed("a"))
+	fake.AssertCallCount(t, "HostGet", 2)
+}
@@ -0,0 +1,871 @@
+// Code generated by gen.go; DO NOT EDIT.
+
+package zabbixfake
+
+import (
+	"context"
+	"time"
+
+	zabbix "github.com/nimok/nim-go-zabbix"
+)
+
+// Client is a fake zabbix.Client. Each method records its call, then calls
+// the matching hook, such as HostGetFunc, if it is set. Methods without a
+// hook return zero values and a nil error; pointer results point to a zero
+// value rather than being nil.
+type Client struct {
+	recorder
+
+	AuthenticateFunc             func() error
+	StartTokenRefresherFunc      func(refreshInterval time.Duration) error
+	StopTokenRefresherFunc       func()
+	APIVersionFunc               func(ctx context.Context) (zabbix.Version, error)
+	HostGetFunc                  func(ctx context.Context, params zabbix.HostGetParameters) ([]zabbix.Host, error)
+	HostCreateFunc               func(ctx context.Context, params []zabbix.Host) (*zabbix.HostCreateResponse, error)
+	HostUpdateFunc               func(ctx context.Context, params zabbix.Host, fields ...string) (*zabbix.HostUpdateResponse, error)
+	HostDeleteFunc               func(ctx context.Context, params []string) (*zabbix.HostDeleteResponse, error)
+	HostMassAddFunc              func(ctx context.Context, params zabbix.HostMassAddParams) (*zabbix.HostMassAddResponse, error)
+	HostInterfaceGetFunc         func(ctx context.Context, params zabbix.HostInterfaceGetParams) ([]zabbix.HostInterface, error)
+	HostInterfaceCreateFunc      func(ctx context.Context, params zabbix.HostInterface) (*zabbix.HostInterfaceCreateResponse, error)
+	HostInterfaceUpdateFunc      func(ctx context.Context, params zabbix.HostInterface, fields ...string) (*zabbix.HostInterfaceUpdateResponse, error)
+	HostInterfaceDeleteFunc      func(ctx context.Context, params []string) (*zabbix.HostInterfaceDeleteResponse, error)
+	HostgroupGetFunc             func(ctx context.Context, params zabbix.HostGroupGetParameters) ([]zabbix.HostGroup, error)
+	HostgroupCreateFunc          func(ctx context.Context, params []zabbix.HostGroup) (*zabbix.HostGroupCreateResponse, error)
+	HostgroupDeleteFunc          func(ctx context.Context, params []string) (*zabbix.HostGroupDeleteResponse, error)
+	DashboardGetFunc             func(ctx context.Context, params zabbix.DashboardGetParameters) ([]zabbix.Dashboard, error)
+	DashboardCreateFunc          func(ctx context.Context, params []zabbix.Dashboard) (*zabbix.DashboardCreateResponse, error)
+	DashboardUpdateFunc          func(ctx context.Context, params zabbix.Dashboard, fields ...string) (*zabbix.DashboardUpdateResponse, error)
+	DashboardDeleteFunc          func(ctx context.Context, params []string) (*zabbix.DashboardDeleteResponse, error)
+	ItemGetFunc                  func(ctx context.Context, params zabbix.ItemGetParameters) ([]zabbix.Item, error)
+	MaintenanceCreateFunc        func(ctx context.Context, params []zabbix.Maintenance) (*zabbix.MaintenanceCreateResponse, error)
+	MapGetFunc                   func(ctx context.Context, params zabbix.MapGetParameters) ([]zabbix.Map, error)
+	MapCreateFunc                func(ctx context.Context, params []zabbix.Map) (*zabbix.MapCreateResponse, error)
+	MapUpdateFunc                func(ctx context.Context, params zabbix.Map, fields ...string) (*zabbix.MapUpdateResponse, error)
+	MapDeleteFunc                func(ctx context.Context, params []string) (*zabbix.MapDeleteResponse, error)
+	EventGetFunc                 func(ctx context.Context, params zabbix.EventGetParams) ([]zabbix.Event, error)
+	ProblemGetFunc               func(ctx context.Context, params zabbix.ProblemGetParams) (*[]zabbix.Problem, error)
+	ProxyGetFunc                 func(ctx context.Context, params zabbix.ProxyGetParameters) ([]zabbix.Proxy, error)
+	ProxyCreateFunc              func(ctx context.Context, params zabbix.ProxyCreateParameters) (*zabbix.ProxyCreateResponse, error)
+	ProxyUpdateFunc              func(ctx context.Context, params zabbix.Proxy, fields ...string) (*zabbix.ProxyUpdateResponse, error)
+	ProxyDeleteFunc              func(ctx context.Context, params []string) (*zabbix.ProxyDeleteResponse, error)
+	ProxyGroupGetFunc            func(ctx context.Context, params zabbix.ProxyGroupGetParameters) ([]zabbix.ProxyGroup, error)
+	ServiceGetFunc               func(ctx context.Context, params zabbix.ServiceGetParameters) ([]zabbix.Service, error)
+	ServiceCreateFunc            func(ctx context.Context, params []zabbix.Service) (*zabbix.ServiceCreateResponse, error)
+	ServiceUpdateFunc            func(ctx context.Context, params zabbix.Service, fields ...string) (*zabbix.ServiceUpdateResponse, error)
+	ServiceDeleteFunc            func(ctx context.Context, params []string) (*zabbix.ServiceDeleteResponse, error)
+	SLAGetFunc                   func(ctx context.Context, params zabbix.SLAGetParameters) ([]zabbix.SLA, error)
+	SLACreateFunc                func(ctx context.Context, params []zabbix.SLA) (*zabbix.SLACreateResponse, error)
+	SLAUpdateFunc                func(ctx context.Context, params zabbix.SLA, fields ...string) (*zabbix.SLAUpdateResponse, error)
+	SLADeleteFunc                func(ctx context.Context, params []string) (*zabbix.SLADeleteResponse, error)
+	SLAGetSLIFunc                func(ctx context.Context, params zabbix.SLAGetSLIParameters) (*zabbix.SLIResponse, error)
+	ScriptGetFunc                func(ctx context.Context, params zabbix.ScriptGetParameters) ([]zabbix.Script, error)
+	ScriptCreateFunc             func(ctx context.Context, params []zabbix.Script) (*zabbix.ScriptCreateResponse, error)
+	ScriptUpdateFunc             func(ctx context.Context, params zabbix.Script, fields ...string) (*zabbix.ScriptUpdateResponse, error)
+	ScriptDeleteFunc             func(ctx context.Context, params []string) (*zabbix.ScriptDeleteResponse, error)
+	ScriptExecuteFunc            func(ctx context.Context, params zabbix.ScriptExecuteParameters) (*zabbix.ScriptExecuteResponse, error)
+	ScriptGetScriptsByHostsFunc  func(ctx context.Context, hostIDs []string) (map[string][]zabbix.Script, error)
+	ScriptGetScriptsByEventsFunc func(ctx context.Context, eventIDs []string) (map[string][]zabbix.Script, error)
+	TaskGetFunc                  func(ctx context.Context, params zabbix.TaskGetParameters) ([]zabbix.Task, error)
+	TaskCreateFunc               func(ctx context.Context, params []zabbix.Task) (*zabbix.TaskCreateResponse, error)
+	TemplateGetFunc              func(ctx context.Context, params zabbix.TemplateGetParameters) ([]zabbix.Template, error)
+	TemplateUpdateFunc           func(ctx context.Context, params zabbix.Template, fields ...string) (*zabbix.TemplateUpdateResponse, error)
+	TokenCreateFunc              func(ctx context.Context, params zabbix.Token) (*zabbix.TokenCreateResponse, error)
+	TokenUpdateFunc              func(ctx context.Context, params zabbix.Token, fields ...string) (*zabbix.TokenUpdateResponse, error)
+	TokenGenerateFunc            func(ctx context.Context, params zabbix.TokenGenerateParameters) ([]zabbix.TokenGenerateResponse, error)
+	TokenDeleteFunc              func(ctx context.Context, params zabbix.TokenDeleteParameters) (*zabbix.TokenDeleteResponse, error)
+	LogoutFunc                   func(ctx context.Context) (zabbix.LogoutSuccess, error)
+}
+
+// Authenticate records the call and calls AuthenticateFunc if it is set.
+func (c *Client) Authenticate() error {
+	c.record("Authenticate", nil)
+	if c.AuthenticateFunc != nil {
+		return c.AuthenticateFunc()
+	}
+	return nil
+}
+
+// StartTokenRefresher records the call and calls StartTokenRefresherFunc if it is set.
+func (c *Client) StartTokenRefresher(refreshInterval time.Duration) error {
+	c.record("StartTokenRefresher", refreshInterval)
+	if c.StartTokenRefresherFunc != nil {
+		return c.StartTokenRefresherFunc(refreshInterval)
+	}
+	return nil
+}
+
+// StartTokenRefresherCalls returns the refreshInterval of the recorded StartTokenRefresher calls, in order.
+func (c *Client) StartTokenRefresherCalls() []time.Duration {
+	return callsTo[time.Duration](&c.recorder, "StartTokenRefresher")
+}
+
+// StopTokenRefresher records the call and calls StopTokenRefresherFunc if it is set.
+func (c *Client) StopTokenRefresher() {
+	c.record("StopTokenRefresher", nil)
+	if c.StopTokenRefresherFunc != nil {
+		c.StopTokenRefresherFunc()
+		return
+	}
+}
+
+// APIVersion records the call and calls APIVersionFunc if it is set.
+func (c *Client) APIVersion(ctx context.Context) (zabbix.Version, error) {
+	c.record("APIVersion", nil)
+	if c.APIVersionFunc != nil {
+		return c.APIVersionFunc(ctx)
+	}
+	var r0 zabbix.Version
+	return r0, nil
+}
+
+// HostGet records the call and calls HostGetFunc if it is set.
+func (c *Client) HostGet(ctx context.Context, params zabbix.HostGetParameters) ([]zabbix.Host, error) {
+	c.record("HostGet", params)
+	if c.HostGetFunc != nil {
+		return c.HostGetFunc(ctx, params)
+	}
+	return nil, nil
+}
+
+// HostGetCalls returns the params of the recorded HostGet calls, in order.
+func (c *Client) HostGetCalls() []zabbix.HostGetParameters {
+	return callsTo[zabbix.HostGetParameters](&c.recorder, "HostGet")
+}
+
+// HostCreate records the call and calls HostCreateFunc if it is set.
+func (c *Client) HostCreate(ctx context.Context, params []zabbix.Host) (*zabbix.HostCreateResponse, error) {
+	c.record("HostCreate", params)
+	if c.HostCreateFunc != nil {
+		return c.HostCreateFunc(ctx, params)
+	}
+	return new(zabbix.HostCreateResponse), nil
+}
+
+// HostCreateCalls returns the params of the recorded HostCreate calls, in order.
+func (c *Client) HostCreateCalls() [][]zabbix.Host {
+	return callsTo[[]zabbix.Host](&c.recorder, "HostCreate")
+}
+
+// HostUpdate records the call and calls HostUpdateFunc if it is set.
+func (c *Client) HostUpdate(ctx context.Context, params zabbix.Host, fields ...string) (*zabbix.HostUpdateResponse, error) {
+	c.record("HostUpdate", params, fields...)
+	if c.HostUpdateFunc != nil {
+		return c.HostUpdateFunc(ctx, params, fields...)
+	}
+	return new(zabbix.HostUpdateResponse), nil
+}
+
+// HostUpdateCalls returns the params of the recorded HostUpdate calls, in order.
+func (c *Client) HostUpdateCalls() []zabbix.Host {
+	return callsTo[zabbix.Host](&c.recorder, "HostUpdate")
+}
+
+// HostDelete records the call and calls HostDeleteFunc if it is set.
+func (c *Client) HostDelete(ctx context.Context, params []string) (*zabbix.HostDeleteResponse, error) {
+	c.record("HostDelete", params)
+	if c.HostDeleteFunc != nil {
+		return c.HostDeleteFunc(ctx, params)
+	}
+	return new(zabbix.HostDeleteResponse), nil
+}
+
+// HostDeleteCalls returns the params of the recorded HostDelete calls, in order.
+func (c *Client) HostDeleteCalls() [][]string {
+	return callsTo[[]string](&c.recorder, "HostDelete")
+}
+
+// HostMassAdd records the call and calls HostMassAddFunc if it is set.
+func (c *Client) HostMassAdd(ctx context.Context, params zabbix.HostMassAddParams) (*zabbix.HostMassAddResponse, error) {
+	c.record("HostMassAdd", params)
+	if c.HostMassAddFunc != nil {
+		return c.HostMassAddFunc(ctx, params)
+	}
+	return new(zabbix.HostMassAddResponse), nil
+}
+
+// HostMassAddCalls returns the params of the recorded HostMassAdd calls, in order.
+func (c *Client) HostMassAddCalls() []zabbix.HostMassAddParams {
+	return callsTo[zabbix.HostMassAddParams](&c.recorder, "HostMassAdd")
+}
+
+// HostInterfaceGet records the call and calls HostInterfaceGetFunc if it is set.
+func (c *Client) HostInterfaceGet(ctx context.Context, params zabbix.HostInterfaceGetParams) ([]zabbix.HostInterface, error) {
+	c.record("HostInterfaceGet", params)
+	if c.HostInterfaceGetFunc != nil {
+		return c.HostInterfaceGetFunc(ctx, params)
+	}
+	return nil, nil
+}
+
+// HostInterfaceGetCalls returns the params of the recorded HostInterfaceGet calls, in order.
+func (c *Client) HostInterfaceGetCalls() []zabbix.HostInterfaceGetParams {
+	return callsTo[zabbix.HostInterfaceGetParams](&c.recorder, "HostInterfaceGet")
+}
+
+// HostInterfaceCreate records the call and calls HostInterfaceCreateFunc if it is set.
+func (c *Client) HostInterfaceCreate(ctx context.Context, params zabbix.HostInterface) (*zabbix.HostInterfaceCreateResponse, error) {
+	c.record("HostInterfaceCreate", params)
+	if c.HostInterfaceCreateFunc != nil {
+		return c.HostInterfaceCreateFunc(ctx, params)
+	}
+	return new(zabbix.HostInterfaceCreateResponse), nil
+}
+
+// HostInterfaceCreateCalls returns the params of the recorded HostInterfaceCreate calls, in order.
+func (c *Client) HostInterfaceCreateCalls() []zabbix.HostInterface {
+	return callsTo[zabbix.HostInterface](&c.recorder, "HostInterfaceCreate")
+}
+
+// HostInterfaceUpdate records the call and calls HostInterfaceUpdateFunc if it is set.
+func (c *Client) HostInterfaceUpdate(ctx context.Context, params zabbix.HostInterface, fields ...string) (*zabbix.HostInterfaceUpdateResponse, error) {
+	c.record("HostInterfaceUpdate", params, fields...)
+	if c.HostInterfaceUpdateFunc != nil {
+		return c.HostInterfaceUpdateFunc(ctx, params, fields...)
+	}
+	return new(zabbix.HostInterfaceUpdateResponse), nil
+}
+
+// HostInterfaceUpdateCalls returns the params of the recorded HostInterfaceUpdate calls, in order.
+func (c *Client) HostInterfaceUpdateCalls() []zabbix.HostInterface {
+	return callsTo[zabbix.HostInterface](&c.recorder, "HostInterfaceUpdate")
+}
+
+// HostInterfaceDelete records the call and calls HostInterfaceDeleteFunc if it is set.
+func (c *Client) HostInterfaceDelete(ctx context.Context, params []string) (*zabbix.HostInterfaceDeleteResponse, error) {
+	c.record("HostInterfaceDelete", params)
+	if c.HostInterfaceDeleteFunc != nil {
+		return c.HostInterfaceDeleteFunc(ctx, params)
+	}
+	return new(zabbix.HostInterfaceDeleteResponse), nil
+}
+
+// HostInterfaceDeleteCalls returns the params of the recorded HostInterfaceDelete calls, in order.
+func (c *Client) HostInterfaceDeleteCalls() [][]string {
+	return callsTo[[]string](&c.recorder, "HostInterfaceDelete")
+}
+
+// HostgroupGet records the call and calls HostgroupGetFunc if it is set.
+func (c *Client) HostgroupGet(ctx context.Context, params zabbix.HostGroupGetParameters) ([]zabbix.HostGroup, error) {
+	c.record("HostgroupGet", params)
+	if c.HostgroupGetFunc != nil {
+		return c.HostgroupGetFunc(ctx, params)
+	}
+	return nil, nil
+}
+
+// HostgroupGetCalls returns the params of the recorded HostgroupGet calls, in order.
+func (c *Client) HostgroupGetCalls() []zabbix.HostGroupGetParameters {
+	return callsTo[zabbix.HostGroupGetParameters](&c.recorder, "HostgroupGet")
+}
+
+// HostgroupCreate records the call and calls HostgroupCreateFunc if it is set.
+func (c *Client) HostgroupCreate(ctx context.Context, params []zabbix.HostGroup) (*zabbix.HostGroupCreateResponse, error) {
+	c.record("HostgroupCreate", params)
+	if c.HostgroupCreateFunc != nil {
+		return c.HostgroupCreateFunc(ctx, params)
+	}
+	return new(zabbix.HostGroupCreateResponse), nil
+}
+
+// HostgroupCreateCalls returns the params of the recorded HostgroupCreate calls, in order.
+func (c *Client) HostgroupCreateCalls() [][]zabbix.HostGroup {
+	return callsTo[[]zabbix.HostGroup](&c.recorder, "HostgroupCreate")
+}
+
+// HostgroupDelete records the call and calls HostgroupDeleteFunc if it is set.
+func (c *Client) HostgroupDelete(ctx context.Context, params []string) (*zabbix.HostGroupDeleteResponse, error) {
+	c.record("HostgroupDelete", params)
+	if c.HostgroupDeleteFunc != nil {
+		return c.HostgroupDeleteFunc(ctx, params)
+	}
+	return new(zabbix.HostGroupDeleteResponse), nil
+}
+
+// HostgroupDeleteCalls returns the params of the recorded HostgroupDelete calls, in order.
+func (c *Client) HostgroupDeleteCalls() [][]string {
+	return callsTo[[]string](&c.recorder, "HostgroupDelete")
+}
+
+// DashboardGet records the call and calls DashboardGetFunc if it is set.
+func (c *Client) DashboardGet(ctx context.Context, params zabbix.DashboardGetParameters) ([]zabbix.Dashboard, error) {
+	c.record("DashboardGet", params)
+	if c.DashboardGetFunc != nil {
+		return c.DashboardGetFunc(ctx, params)
+	}
+	return nil, nil
+}
+
+// DashboardGetCalls returns the params of the recorded DashboardGet calls, in order.
+func (c *Client) DashboardGetCalls() []zabbix.DashboardGetParameters {
+	return callsTo[zabbix.DashboardGetParameters](&c.recorder, "DashboardGet")
+}
+
+// DashboardCreate records the call and calls DashboardCreateFunc if it is set.
+func (c *Client) DashboardCreate(ctx context.Context, params []zabbix.Dashboard) (*zabbix.DashboardCreateResponse, error) {
+	c.record("DashboardCreate", params)
+	if c.DashboardCreateFunc != nil {
+		return c.DashboardCreateFunc(ctx, params)
+	}
+	return new(zabbix.DashboardCreateResponse), nil
+}
+
+// DashboardCreateCalls returns the params of the recorded DashboardCreate calls, in order.
+func (c *Client) DashboardCreateCalls() [][]zabbix.Dashboard {
+	return callsTo[[]zabbix.Dashboard](&c.recorder, "DashboardCreate")
+}
+
+// DashboardUpdate records the call and calls DashboardUpdateFunc if it is set.
+func (c *Client) DashboardUpdate(ctx context.Context, params zabbix.Dashboard, fields ...string) (*zabbix.DashboardUpdateResponse, error) {
+	c.record("DashboardUpdate", params, fields...)
+	if c.DashboardUpdateFunc != nil {
+		return c.DashboardUpdateFunc(ctx, params, fields...)
+	}
+	return new(zabbix.DashboardUpdateResponse), nil
+}
+
+// DashboardUpdateCalls returns the params of the recorded DashboardUpdate calls, in order.
+func (c *Client) DashboardUpdateCalls() []zabbix.Dashboard {
+	return callsTo[zabbix.Dashboard](&c.recorder, "DashboardUpdate")
+}
+
+// DashboardDelete records the call and calls DashboardDeleteFunc if it is set.
+func (c *Client) DashboardDelete(ctx context.Context, params []string) (*zabbix.DashboardDeleteResponse, error) {
+	c.record("DashboardDelete", params)
+	if c.DashboardDeleteFunc != nil {
+		return c.DashboardDeleteFunc(ctx, params)
+	}
+	return new(zabbix.DashboardDeleteResponse), nil
+}
+
+// DashboardDeleteCalls returns the params of the recorded DashboardDelete calls, in order.
+func (c *Client) DashboardDeleteCalls() [][]string {
+	return callsTo[[]string](&c.recorder, "DashboardDelete")
+}
+
+// ItemGet records the call and calls ItemGetFunc if it is set.
+func (c *Client) ItemGet(ctx context.Context, params zabbix.ItemGetParameters) ([]zabbix.Item, error) {
+	c.record("ItemGet", params)
+	if c.ItemGetFunc != nil {
+		return c.ItemGetFunc(ctx, params)
+	}
+	return nil, nil
+}
+
+// ItemGetCalls returns the params of the recorded ItemGet calls, in order.
+func (c *Client) ItemGetCalls() []zabbix.ItemGetParameters {
+	return callsTo[zabbix.ItemGetParameters](&c.recorder, "ItemGet")
+}
+
+// MaintenanceCreate records the call and calls MaintenanceCreateFunc if it is set.
+func (c *Client) MaintenanceCreate(ctx context.Context, params []zabbix.Maintenance) (*zabbix.MaintenanceCreateResponse, error) {
+	c.record("MaintenanceCreate", params)
+	if c.MaintenanceCreateFunc != nil {
+		return c.MaintenanceCreateFunc(ctx, params)
+	}
+	return new(zabbix.MaintenanceCreateResponse), nil
+}
+
+// MaintenanceCreateCalls returns the params of the recorded MaintenanceCreate calls, in order.
+func (c *Client) MaintenanceCreateCalls() [][]zabbix.Maintenance {
+	return callsTo[[]zabbix.Maintenance](&c.recorder, "MaintenanceCreate")
+}
+
+// MapGet records the call and calls MapGetFunc if it is set.
+func (c *Client) MapGet(ctx context.Context, params zabbix.MapGetParameters) ([]zabbix.Map, error) {
+	c.record("MapGet", params)
+	if c.MapGetFunc != nil {
+		return c.MapGetFunc(ctx, params)
+	}
+	return nil, nil
+}
+
+// MapGetCalls returns the params of the recorded MapGet calls, in order.
+func (c *Client) MapGetCalls() []zabbix.MapGetParameters {
+	return callsTo[zabbix.MapGetParameters](&c.recorder, "MapGet")
+}
+
+// MapCreate records the call and calls MapCreateFunc if it is set.
+func (c *Client) MapCreate(ctx context.Context, params []zabbix.Map) (*zabbix.MapCreateResponse, error) {
+	c.record("MapCreate", params)
+	if c.MapCreateFunc != nil {
+		return c.MapCreateFunc(ctx, params)
+	}
+	return new(zabbix.MapCreateResponse), nil
+}
+
+// MapCreateCalls returns the params of the recorded MapCreate calls, in order.
+func (c *Client) MapCreateCalls() [][]zabbix.Map {
+	return callsTo[[]zabbix.Map](&c.recorder, "MapCreate")
+}
+
+// MapUpdate records the call and calls MapUpdateFunc if it is set.
+func (c *Client) MapUpdate(ctx context.Context, params zabbix.Map, fields ...string) (*zabbix.MapUpdateResponse, error) {
+	c.record("MapUpdate", params, fields...)
+	if c.MapUpdateFunc != nil {
+		return c.MapUpdateFunc(ctx, params, fields...)
+	}
+	return new(zabbix.MapUpdateResponse), nil
+}
+
+// MapUpdateCalls returns the params of the recorded MapUpdate calls, in order.
+func (c *Client) MapUpdateCalls() []zabbix.Map {
+	return callsTo[zabbix.Map](&c.recorder, "MapUpdate")
+}
+
+// MapDelete records the call and calls MapDeleteFunc if it is set.
+func (c *Client) MapDelete(ctx context.Context, params []string) (*zabbix.MapDeleteResponse, error) {
+	c.record("MapDelete", params)
+	if c.MapDeleteFunc != nil {
+		return c.MapDeleteFunc(ctx, params)
+	}
+	return new(zabbix.MapDeleteResponse), nil
+}
+
+// MapDeleteCalls returns the params of the recorded MapDelete calls, in order.
+func (c *Client) MapDeleteCalls() [][]string {
+	return callsTo[[]string](&c.recorder, "MapDelete")
+}
+
+// EventGet records the call and calls EventGetFunc if it is set.
+func (c *Client) EventGet(ctx context.Context, params zabbix.EventGetParams) ([]zabbix.Event, error) {
+	c.record("EventGet", params)
+	if c.EventGetFunc != nil {
+		return c.EventGetFunc(ctx, params)
+	}
+	return nil, nil
+}
+
+// EventGetCalls returns the params of the recorded EventGet calls, in order.
+func (c *Client) EventGetCalls() []zabbix.EventGetParams {
+	return callsTo[zabbix.EventGetParams](&c.recorder, "EventGet")
+}
+
+// ProblemGet records the call and calls ProblemGetFunc if it is set.
+func (c *Client) ProblemGet(ctx context.Context, params zabbix.ProblemGetParams) (*[]zabbix.Problem, error) {
+	c.record("ProblemGet", params)
+	if c.ProblemGetFunc != nil {
+		return c.ProblemGetFunc(ctx, params)
+	}
+	return new([]zabbix.Problem), nil
+}
+
+// ProblemGetCalls returns the params of the recorded ProblemGet calls, in order.
+func (c *Client) ProblemGetCalls() []zabbix.ProblemGetParams {
+	return callsTo[zabbix.ProblemGetParams](&c.recorder, "ProblemGet")
+}
+
+// ProxyGet records the call and calls ProxyGetFunc if it is set.
+func (c *Client) ProxyGet(ctx context.Context, params zabbix.ProxyGetParameters) ([]zabbix.Proxy, error) {
+	c.record("ProxyGet", params)
+	if c.ProxyGetFunc != nil {
+		return c.ProxyGetFunc(ctx, params)
+	}
+	return nil, nil
+}
+
+// ProxyGetCalls returns the params of the recorded ProxyGet calls, in order.
+func (c *Client) ProxyGetCalls() []zabbix.ProxyGetParameters {
+	return callsTo[zabbix.ProxyGetParameters](&c.recorder, "ProxyGet")
+}
+
+// ProxyCreate records the call and calls ProxyCreateFunc if it is set.
+func (c *Client) ProxyCreate(ctx context.Context, params zabbix.ProxyCreateParameters) (*zabbix.ProxyCreateResponse, error) {
+	c.record("ProxyCreate", params)
+	if c.ProxyCreateFunc != nil {
+		return c.ProxyCreateFunc(ctx, params)
+	}
+	return new(zabbix.ProxyCreateResponse), nil
+}
+
+// ProxyCreateCalls returns the params of the recorded ProxyCreate calls, in order.
+func (c *Client) ProxyCreateCalls() []zabbix.ProxyCreateParameters {
+	return callsTo[zabbix.ProxyCreateParameters](&c.recorder, "ProxyCreate")
+}
+
+// ProxyUpdate records the call and calls ProxyUpdateFunc if it is set.
+func (c *Client) ProxyUpdate(ctx context.Context, params zabbix.Proxy, fields ...string) (*zabbix.ProxyUpdateResponse, error) {
+	c.record("ProxyUpdate", params, fields...)
+	if c.ProxyUpdateFunc != nil {
+		return c.ProxyUpdateFunc(ctx, params, fields...)
+	}
+	return new(zabbix.ProxyUpdateResponse), nil
+}
+
+// ProxyUpdateCalls returns the params of the recorded ProxyUpdate calls, in order.
+func (c *Client) ProxyUpdateCalls() []zabbix.Proxy {
+	return callsTo[zabbix.Proxy](&c.recorder, "ProxyUpdate")
+}
+
+// ProxyDelete records the call and calls ProxyDeleteFunc if it is set.
+func (c *Client) ProxyDelete(ctx context.Context, params []string) (*zabbix.ProxyDeleteResponse, error) {
+	c.record("ProxyDelete", params)
+	if c.ProxyDeleteFunc != nil {
+		return c.ProxyDeleteFunc(ctx, params)
+	}
+	return new(zabbix.ProxyDeleteResponse), nil
+}
+
+// ProxyDeleteCalls returns the params of the recorded ProxyDelete calls, in order.
+func (c *Client) ProxyDeleteCalls() [][]string {
+	return callsTo[[]string](&c.recorder, "ProxyDelete")
+}
+
+// ProxyGroupGet records the call and calls ProxyGroupGetFunc if it is set.
+func (c *Client) ProxyGroupGet(ctx context.Context, params zabbix.ProxyGroupGetParameters) ([]zabbix.ProxyGroup, error) {
+	c.record("ProxyGroupGet", params)
+	if c.ProxyGroupGetFunc != nil {
+		return c.ProxyGroupGetFunc(ctx, params)
+	}
+	return nil, nil
+}
+
+// ProxyGroupGetCalls returns the params of the recorded ProxyGroupGet calls, in order.
+func (c *Client) ProxyGroupGetCalls() []zabbix.ProxyGroupGetParameters {
+	return callsTo[zabbix.ProxyGroupGetParameters](&c.recorder, "ProxyGroupGet")
+}
+
+// ServiceGet records the call and calls ServiceGetFunc if it is set.
+func (c *Client) ServiceGet(ctx context.Context, params zabbix.ServiceGetParameters) ([]zabbix.Service, error) {
+	c.record("ServiceGet", params)
+	if c.ServiceGetFunc != nil {
+		return c.ServiceGetFunc(ctx, params)
+	}
+	return nil, nil
+}
+
+// ServiceGetCalls returns the params of the recorded ServiceGet calls, in order.
+func (c *Client) ServiceGetCalls() []zabbix.ServiceGetParameters {
+	return callsTo[zabbix.ServiceGetParameters](&c.recorder, "ServiceGet")
+}
+
+// ServiceCreate records the call and calls ServiceCreateFunc if it is set.
+func (c *Client) ServiceCreate(ctx context.Context, params []zabbix.Service) (*zabbix.ServiceCreateResponse, error) {
+	c.record("ServiceCreate", params)
+	if c.ServiceCreateFunc != nil {
+		return c.ServiceCreateFunc(ctx, params)
+	}
+	return new(zabbix.ServiceCreateResponse), nil
+}
+
+// ServiceCreateCalls returns the params of the recorded ServiceCreate calls, in order.
+func (c *Client) ServiceCreateCalls() [][]zabbix.Service {
+	return callsTo[[]zabbix.Service](&c.recorder, "ServiceCreate")
+}
+
+// ServiceUpdate records the call and calls ServiceUpdateFunc if it is set.
+func (c *Client) ServiceUpdate(ctx context.Context, params zabbix.Service, fields ...string) (*zabbix.ServiceUpdateResponse, error) {
+	c.record("ServiceUpdate", params, fields...)
+	if c.ServiceUpdateFunc != nil {
+		return c.ServiceUpdateFunc(ctx, params, fields...)
+	}
+	return new(zabbix.ServiceUpdateResponse), nil
+}
+
+// ServiceUpdateCalls returns the params of the recorded ServiceUpdate calls, in order.
+func (c *Client) ServiceUpdateCalls() []zabbix.Service {
+	return callsTo[zabbix.Service](&c.recorder, "ServiceUpdate")
+}
+
+// ServiceDelete records the call and calls ServiceDeleteFunc if it is set.
+func (c *Client) ServiceDelete(ctx context.Context, params []string) (*zabbix.ServiceDeleteResponse, error) {
+	c.record("ServiceDelete", params)
+	if c.ServiceDeleteFunc != nil {
+		return c.ServiceDeleteFunc(ctx, params)
+	}
+	return new(zabbix.ServiceDeleteResponse), nil
+}
+
+// ServiceDeleteCalls returns the params of the recorded ServiceDelete calls, in order.
+func (c *Client) ServiceDeleteCalls() [][]string {
+	return callsTo[[]string](&c.recorder, "ServiceDelete")
+}
+
+// SLAGet records the call and calls SLAGetFunc if it is set.
+func (c *Client) SLAGet(ctx context.Context, params zabbix.SLAGetParameters) ([]zabbix.SLA, error) {
+	c.record("SLAGet", params)
+	if c.SLAGetFunc != nil {
+		return c.SLAGetFunc(ctx, params)
+	}
+	return nil, nil
+}
+
+// SLAGetCalls returns the params of the recorded SLAGet calls, in order.
+func (c *Client) SLAGetCalls() []zabbix.SLAGetParameters {
+	return callsTo[zabbix.SLAGetParameters](&c.recorder, "SLAGet")
+}
+
+// SLACreate records the call and calls SLACreateFunc if it is set.
+func (c *Client) SLACreate(ctx context.Context, params []zabbix.SLA) (*zabbix.SLACreateResponse, error) {
+	c.record("SLACreate", params)
+	if c.SLACreateFunc != nil {
+		return c.SLACreateFunc(ctx, params)
+	}
+	return new(zabbix.SLACreateResponse), nil
+}
+
+// SLACreateCalls returns the params of the recorded SLACreate calls, in order.
+func (c *Client) SLACreateCalls() [][]zabbix.SLA {
+	return callsTo[[]zabbix.SLA](&c.recorder, "SLACreate")
+}
+
+// SLAUpdate records the call and calls SLAUpdateFunc if it is set.
+func (c *Client) SLAUpdate(ctx context.Context, params zabbix.SLA, fields ...string) (*zabbix.SLAUpdateResponse, error) {
+	c.record("SLAUpdate", params, fields...)
+	if c.SLAUpdateFunc != nil {
+		return c.SLAUpdateFunc(ctx, params, fields...)
+	}
+	return new(zabbix.SLAUpdateResponse), nil
+}
+
+// SLAUpdateCalls returns the params of the recorded SLAUpdate calls, in order.
+func (c *Client) SLAUpdateCalls() []zabbix.SLA {
+	return callsTo[zabbix.SLA](&c.recorder, "SLAUpdate")
+}
+
+// SLADelete records the call and calls SLADeleteFunc if it is set.
+func (c *Client) SLADelete(ctx context.Context, params []string) (*zabbix.SLADeleteResponse, error) {
+	c.record("SLADelete", params)
+	if c.SLADeleteFunc != nil {
+		return c.SLADeleteFunc(ctx, params)
+	}
+	return new(zabbix.SLADeleteResponse), nil
+}
+
+// SLADeleteCalls returns the params of the recorded SLADelete calls, in order.
+func (c *Client) SLADeleteCalls() [][]string {
+	return callsTo[[]string](&c.recorder, "SLADelete")
+}
+
+// SLAGetSLI records the call and calls SLAGetSLIFunc if it is set.
+func (c *Client) SLAGetSLI(ctx context.Context, params zabbix.SLAGetSLIParameters) (*zabbix.SLIResponse, error) {
+	c.record("SLAGetSLI", params)
+	if c.SLAGetSLIFunc != nil {
+		return c.SLAGetSLIFunc(ctx, params)
+	}
+	return new(zabbix.SLIResponse), nil
+}
+
+// SLAGetSLICalls returns the params of the recorded SLAGetSLI calls, in order.
+func (c *Client) SLAGetSLICalls() []zabbix.SLAGetSLIParameters {
+	return callsTo[zabbix.SLAGetSLIParameters](&c.recorder, "SLAGetSLI")
+}
+
+// ScriptGet records the call and calls ScriptGetFunc if it is set.
+func (c *Client) ScriptGet(ctx context.Context, params zabbix.ScriptGetParameters) ([]zabbix.Script, error) {
+	c.record("ScriptGet", params)
+	if c.ScriptGetFunc != nil {
+		return c.ScriptGetFunc(ctx, params)
+	}
+	return nil, nil
+}
+
+// ScriptGetCalls returns the params of the recorded ScriptGet calls, in order.
+func (c *Client) ScriptGetCalls() []zabbix.ScriptGetParameters {
+	return callsTo[zabbix.ScriptGetParameters](&c.recorder, "ScriptGet")
+}
+
+// ScriptCreate records the call and calls ScriptCreateFunc if it is set.
+func (c *Client) ScriptCreate(ctx context.Context, params []zabbix.Script) (*zabbix.ScriptCreateResponse, error) {
+	c.record("ScriptCreate", params)
+	if c.ScriptCreateFunc != nil {
+		return c.ScriptCreateFunc(ctx, params)
+	}
+	return new(zabbix.ScriptCreateResponse), nil
+}
+
+// ScriptCreateCalls returns the params of the recorded ScriptCreate calls, in order.
+func (c *Client) ScriptCreateCalls() [][]zabbix.Script {
+	return callsTo[[]zabbix.Script](&c.recorder, "ScriptCreate")
+}
+
+// ScriptUpdate records the call and calls ScriptUpdateFunc if it is set.
+func (c *Client) ScriptUpdate(ctx context.Context, params zabbix.Script, fields ...string) (*zabbix.ScriptUpdateResponse, error) {
+	c.record("ScriptUpdate", params, fields...)
+	if c.ScriptUpdateFunc != nil {
+		return c.ScriptUpdateFunc(ctx, params, fields...)
+	}
+	return new(zabbix.ScriptUpdateResponse), nil
+}
+
+// ScriptUpdateCalls returns the params of the recorded ScriptUpdate calls, in order.
+func (c *Client) ScriptUpdateCalls() []zabbix.Script {
+	return callsTo[zabbix.Script](&c.recorder, "ScriptUpdate")
+}
+
+// ScriptDelete records the call and calls ScriptDeleteFunc if it is set.
+func (c *Client) ScriptDelete(ctx context.Context, params []string) (*zabbix.ScriptDeleteResponse, error) {
+	c.record("ScriptDelete", params)
+	if c.ScriptDeleteFunc != nil {
+		return c.ScriptDeleteFunc(ctx, params)
+	}
+	return new(zabbix.ScriptDeleteResponse), nil
+}
+
+// ScriptDeleteCalls returns the params of the recorded ScriptDelete calls, in order.
+func (c *Client) ScriptDeleteCalls() [][]string {
+	return callsTo[[]string](&c.recorder, "ScriptDelete")
+}
+
+// ScriptExecute records the call and calls ScriptExecuteFunc if it is set.
+func (c *Client) ScriptExecute(ctx context.Context, params zabbix.ScriptExecuteParameters) (*zabbix.ScriptExecuteResponse, error) {
+	c.record("ScriptExecute", params)
+	if c.ScriptExecuteFunc != nil {
+		return c.ScriptExecuteFunc(ctx, params)
+	}
+	return new(zabbix.ScriptExecuteResponse), nil
+}
+
+// ScriptExecuteCalls returns the params of the recorded ScriptExecute calls, in order.
+func (c *Client) ScriptExecuteCalls() []zabbix.ScriptExecuteParameters {
+	return callsTo[zabbix.ScriptExecuteParameters](&c.recorder, "ScriptExecute")
+}
+
+// ScriptGetScriptsByHosts records the call and calls ScriptGetScriptsByHostsFunc if it is set.
+func (c *Client) ScriptGetScriptsByHosts(ctx context.Context, hostIDs []string) (map[string][]zabbix.Script, error) {
+	c.record("ScriptGetScriptsByHosts", hostIDs)
+	if c.ScriptGetScriptsByHostsFunc != nil {
+		return c.ScriptGetScriptsByHostsFunc(ctx, hostIDs)
+	}
+	return nil, nil
+}
+
+// ScriptGetScriptsByHostsCalls returns the hostIDs of the recorded ScriptGetScriptsByHosts calls, in order.
+func (c *Client) ScriptGetScriptsByHostsCalls() [][]string {
+	return callsTo[[]string](&c.recorder, "ScriptGetScriptsByHosts")
+}
+
+// ScriptGetScriptsByEvents records the call and calls ScriptGetScriptsByEventsFunc if it is set.
+func (c *Client) ScriptGetScriptsByEvents(ctx context.Context, eventIDs []string) (map[string][]zabbix.Script, error) {
+	c.record("ScriptGetScriptsByEvents", eventIDs)
+	if c.ScriptGetScriptsByEventsFunc != nil {
+		return c.ScriptGetScriptsByEventsFunc(ctx, eventIDs)
+	}
+	return nil, nil
+}
+
+// ScriptGetScriptsByEventsCalls returns the eventIDs of the recorded ScriptGetScriptsByEvents calls, in order.
+func (c *Client) ScriptGetScriptsByEventsCalls() [][]string {
+	return callsTo[[]string](&c.recorder, "ScriptGetScriptsByEvents")
+}
+
+// TaskGet records the call and calls TaskGetFunc if it is set.
+func (c *Client) TaskGet(ctx context.Context, params zabbix.TaskGetParameters) ([]zabbix.Task, error) {
+	c.record("TaskGet", params)
+	if c.TaskGetFunc != nil {
+		return c.TaskGetFunc(ctx, params)
+	}
+	return nil, nil
+}
+
+// TaskGetCalls returns the params of the recorded TaskGet calls, in order.
+func (c *Client) TaskGetCalls() []zabbix.TaskGetParameters {
+	return callsTo[zabbix.TaskGetParameters](&c.recorder, "TaskGet")
+}
+
+// TaskCreate records the call and calls TaskCreateFunc if it is set.
+func (c *Client) TaskCreate(ctx context.Context, params []zabbix.Task) (*zabbix.TaskCreateResponse, error) {
+	c.record("TaskCreate", params)
+	if c.TaskCreateFunc != nil {
+		return c.TaskCreateFunc(ctx, params)
+	}
+	return new(zabbix.TaskCreateResponse), nil
+}
+
+// TaskCreateCalls returns the params of the recorded TaskCreate calls, in order.
+func (c *Client) TaskCreateCalls() [][]zabbix.Task {
+	return callsTo[[]zabbix.Task](&c.recorder, "TaskCreate")
+}
+
+// TemplateGet records the call and calls TemplateGetFunc if it is set.
+func (c *Client) TemplateGet(ctx context.Context, params zabbix.TemplateGetParameters) ([]zabbix.Template, error) {
+	c.record("TemplateGet", params)
+	if c.TemplateGetFunc != nil {
+		return c.TemplateGetFunc(ctx, params)
+	}
+	return nil, nil
+}
+
+// TemplateGetCalls returns the params of the recorded TemplateGet calls, in order.
+func (c *Client) TemplateGetCalls() []zabbix.TemplateGetParameters {
+	return callsTo[zabbix.TemplateGetParameters](&c.recorder, "TemplateGet")
+}
+
+// TemplateUpdate records the call and calls TemplateUpdateFunc if it is set.
+func (c *Client) TemplateUpdate(ctx context.Context, params zabbix.Template, fields ...string) (*zabbix.TemplateUpdateResponse, error) {
+	c.record("TemplateUpdate", params, fields...)
+	if c.TemplateUpdateFunc != nil {
+		return c.TemplateUpdateFunc(ctx, params, fields...)
+	}
+	return new(zabbix.TemplateUpdateResponse), nil
+}
+
+// TemplateUpdateCalls returns the params of the recorded TemplateUpdate calls, in order.
+func (c *Client) TemplateUpdateCalls() []zabbix.Template {
+	return callsTo[zabbix.Template](&c.recorder, "TemplateUpdate")
+}
+
+// TokenCreate records the call and calls TokenCreateFunc if it is set.
+func (c *Client) TokenCreate(ctx context.Context, params zabbix.Token) (*zabbix.TokenCreateResponse, error) {
+	c.record("TokenCreate", params)
+	if c.TokenCreateFunc != nil {
+		return c.TokenCreateFunc(ctx, params)
+	}
+	return new(zabbix.TokenCreateResponse), nil
+}
+
+// TokenCreateCalls returns the params of the recorded TokenCreate calls, in order.
+func (c *Client) TokenCreateCalls() []zabbix.Token {
+	return callsTo[zabbix.Token](&c.recorder, "TokenCreate")
+}
+
+// TokenUpdate records the call and calls TokenUpdateFunc if it is set.
+func (c *Client) TokenUpdate(ctx context.Context, params zabbix.Token, fields ...string) (*zabbix.TokenUpdateResponse, error) {
+	c.record("TokenUpdate", params, fields...)
+	if c.TokenUpdateFunc != nil {
+		return c.TokenUpdateFunc(ctx, params, fields...)
+	}
+	return new(zabbix.TokenUpdateResponse), nil
+}
+
+// TokenUpdateCalls returns the params of the recorded TokenUpdate calls, in order.
+func (c *Client) TokenUpdateCalls() []zabbix.Token {
+	return callsTo[zabbix.Token](&c.recorder, "TokenUpdate")
+}
+
+// TokenGenerate records the call and calls TokenGenerateFunc if it is set.
+func (c *Client) TokenGenerate(ctx context.Context, params zabbix.TokenGenerateParameters) ([]zabbix.TokenGenerateResponse, error) {
+	c.record("TokenGenerate", params)
+	if c.TokenGenerateFunc != nil {
+		return c.TokenGenerateFunc(ctx, params)
+	}
+	return nil, nil
+}
+
+// TokenGenerateCalls returns the params of the recorded TokenGenerate calls, in order.
+func (c *Client) TokenGenerateCalls() []zabbix.TokenGenerateParameters {
+	return callsTo[zabbix.TokenGenerateParameters](&c.recorder, "TokenGenerate")
+}
+
+// TokenDelete records the call and calls TokenDeleteFunc if it is set.
+func (c *Client) TokenDelete(ctx context.Context, params zabbix.TokenDeleteParameters) (*zabbix.TokenDeleteResponse, error) {
+	c.record("TokenDelete", params)
+	if c.TokenDeleteFunc != nil {
+		return c.TokenDeleteFunc(ctx, params)
+	}
+	return new(zabbix.TokenDeleteResponse), nil
+}
+
+// TokenDeleteCalls returns the params of the recorded TokenDelete calls, in order.
+func (c *Client) TokenDeleteCalls() []zabbix.TokenDeleteParameters {
+	return callsTo[zabbix.TokenDeleteParameters](&c.recorder, "TokenDelete")
+}
+
+// Logout records the call and calls LogoutFunc if it is set.
+func (c *Client) Logout(ctx context.Context) (zabbix.LogoutSuccess, error) {
+	c.record("Logout", nil)
+	if c.LogoutFunc != nil {
+		return c.LogoutFunc(ctx)
+	}
+	var r0 zabbix.LogoutSuccess
+	return r0, nil
+}
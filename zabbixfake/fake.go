@@ -0,0 +1,165 @@
+// Package zabbixfake provides a fake implementation of zabbix.Client for
+// unit tests of code that uses the client.
+//
+// Every method of the fake records its call and then calls the matching
+// hook, such as HostGetFunc, if it is set:
+//
+//	fake := &zabbixfake.Client{
+//		HostGetFunc: func(ctx context.Context, params zabbix.HostGetParameters) ([]zabbix.Host, error) {
+//			return []zabbix.Host{{HostID: "10084", Host: "Zabbix server"}}, nil
+//		},
+//	}
+//
+//	disableHost(ctx, fake, "Zabbix server")
+//
+//	zabbixfake.AssertCalledWith(t, fake, "HostUpdate", func(h zabbix.Host) bool {
+//		return h.HostID == "10084"
+//	})
+//
+// The hooks and the typed call accessors, such as HostUpdateCalls, are
+// generated from the zabbix.Client interface by gen.go.
+package zabbixfake
+
+//go:generate go run gen.go
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	zabbix "github.com/nimok/nim-go-zabbix"
+)
+
+var _ zabbix.Client = (*Client)(nil)
+
+// Call is a recorded method call.
+type Call struct {
+	Method string   // Name of the Client method, e.g. "HostGet"
+	Params any      // Parameters of the call besides ctx; nil for methods without any
+	Fields []string // Fields given to the Update methods, if any
+}
+
+// recorder records the calls of a Client. It is safe for concurrent use.
+type recorder struct {
+	mu    sync.Mutex
+	calls []Call
+}
+
+func (r *recorder) record(method string, params any, fields ...string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.calls = append(r.calls, Call{Method: method, Params: params, Fields: fields})
+}
+
+// Calls returns all recorded calls, in order.
+func (r *recorder) Calls() []Call {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return append([]Call(nil), r.calls...)
+}
+
+// CallCount returns the number of recorded calls to method.
+func (r *recorder) CallCount(method string) int {
+	n := 0
+	for _, call := range r.Calls() {
+		if call.Method == method {
+			n++
+		}
+	}
+	return n
+}
+
+// Reset forgets all recorded calls. Hooks are left unchanged.
+func (r *recorder) Reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.calls = nil
+}
+
+// AssertCalled fails the test if method was not called.
+func (r *recorder) AssertCalled(t testing.TB, method string) {
+	t.Helper()
+
+	if r.CallCount(method) == 0 {
+		t.Errorf("expected a call to %s, got calls %v", method, r.methods())
+	}
+}
+
+// AssertNotCalled fails the test if method was called.
+func (r *recorder) AssertNotCalled(t testing.TB, method string) {
+	t.Helper()
+
+	if n := r.CallCount(method); n > 0 {
+		t.Errorf("expected no call to %s, got %d", method, n)
+	}
+}
+
+// AssertCallCount fails the test if method was not called exactly n times.
+func (r *recorder) AssertCallCount(t testing.TB, method string, n int) {
+	t.Helper()
+
+	if got := r.CallCount(method); got != n {
+		t.Errorf("expected %d calls to %s, got %d", n, method, got)
+	}
+}
+
+// AssertCalledWith fails the test unless method was called with params of
+// type P for which match returns true.
+func AssertCalledWith[P any](t testing.TB, c *Client, method string, match func(params P) bool) {
+	t.Helper()
+
+	var seen []any
+	for _, call := range c.Calls() {
+		if call.Method != method {
+			continue
+		}
+		params, ok := call.Params.(P)
+		if !ok {
+			t.Fatalf("%s is called with %T, not %T", method, call.Params, params)
+		}
+		if match(params) {
+			return
+		}
+		seen = append(seen, call.Params)
+	}
+
+	if len(seen) == 0 {
+		t.Errorf("expected a call to %s, got none", method)
+		return
+	}
+	t.Errorf("no call to %s matches, got params %s", method, formatParams(seen))
+}
+
+// methods returns the recorded method names, in order.
+func (r *recorder) methods() []string {
+	var methods []string
+	for _, call := range r.Calls() {
+		methods = append(methods, call.Method)
+	}
+	return methods
+}
+
+// callsTo returns the params of the recorded calls to method.
+func callsTo[P any](r *recorder, method string) []P {
+	var params []P
+	for _, call := range r.Calls() {
+		if call.Method == method {
+			params = append(params, call.Params.(P))
+		}
+	}
+	return params
+}
+
+func formatParams(params []any) string {
+	s := ""
+	for i, p := range params {
+		if i > 0 {
+			s += ", "
+		}
+		s += fmt.Sprintf("%+v", p)
+	}
+	return s
+}
@@ -0,0 +1,115 @@
+package zabbixfake_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	zabbix "github.com/nimok/nim-go-zabbix"
+	"github.com/nimok/nim-go-zabbix/zabbixfake"
+)
+
+// disableHost is the kind of code under test: it looks a host up by name and
+// sets its status to unmonitored.
+func disableHost(ctx context.Context, client zabbix.Client, name string) error {
+	hosts, err := client.HostGet(ctx, zabbix.HostGetParameters{
+		GetParameters: zabbix.GetParameters{Filter: map[string]any{"host": name}},
+	})
+	if err != nil {
+		return err
+	}
+	if len(hosts) == 0 {
+		return errors.New("host not found")
+	}
+
+	status := 1
+	_, err = client.HostUpdate(ctx, zabbix.Host{HostID: hosts[0].HostID, Status: &status})
+	return err
+}
+
+func TestClientHooks(t *testing.T) {
+	fake := &zabbixfake.Client{
+		HostGetFunc: func(ctx context.Context, params zabbix.HostGetParameters) ([]zabbix.Host, error) {
+			return []zabbix.Host{{HostID: "10084", Host: "Zabbix server"}}, nil
+		},
+	}
+
+	if err := disableHost(context.Background(), fake, "Zabbix server"); err != nil {
+		t.Fatal(err)
+	}
+
+	fake.AssertCalled(t, "HostGet")
+	fake.AssertCallCount(t, "HostUpdate", 1)
+	fake.AssertNotCalled(t, "HostDelete")
+	zabbixfake.AssertCalledWith(t, fake, "HostUpdate", func(h zabbix.Host) bool {
+		return h.HostID == "10084" && h.Status != nil && *h.Status == 1
+	})
+
+	updates := fake.HostUpdateCalls()
+	if len(updates) != 1 || updates[0].HostID != "10084" {
+		t.Errorf("unexpected HostUpdate calls: %+v", updates)
+	}
+
+	if _, err := fake.HostUpdate(context.Background(), zabbix.Host{HostID: "10084"}, "description"); err != nil {
+		t.Fatal(err)
+	}
+	if calls := fake.Calls(); len(calls[2].Fields) != 1 || calls[2].Fields[0] != "description" {
+		t.Errorf("got fields %v, want [description]", calls[2].Fields)
+	}
+
+	fake.Reset()
+	if calls := fake.Calls(); len(calls) != 0 {
+		t.Errorf("got %d calls after Reset, want 0", len(calls))
+	}
+}
+
+func TestClientZeroValues(t *testing.T) {
+	ctx := context.Background()
+	fake := &zabbixfake.Client{}
+
+	created, err := fake.HostCreate(ctx, []zabbix.Host{{Host: "h"}})
+	if err != nil || created == nil {
+		t.Errorf("got %v, %v; want a zero response and no error", created, err)
+	}
+
+	problems, err := fake.ProblemGet(ctx, zabbix.ProblemGetParams{})
+	if err != nil || problems == nil || len(*problems) != 0 {
+		t.Errorf("got %v, %v; want an empty list and no error", problems, err)
+	}
+
+	wantErr := errors.New("boom")
+	fake.HostGetFunc = func(ctx context.Context, params zabbix.HostGetParameters) ([]zabbix.Host, error) {
+		return nil, wantErr
+	}
+	if err := disableHost(ctx, fake, "h"); !errors.Is(err, wantErr) {
+		t.Errorf("got %v, want %v", err, wantErr)
+	}
+	fake.AssertNotCalled(t, "HostUpdate")
+}
+
+// TestGenerated fails if client_gen.go is out of date with the Client
+// interface.
+func TestGenerated(t *testing.T) {
+	out := filepath.Join(t.TempDir(), "client_gen.go")
+
+	cmd := exec.Command("go", "run", "gen.go", "-o", out)
+	if output, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("running gen.go: %v\n%s", err, output)
+	}
+
+	want, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := os.ReadFile("client_gen.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Error("client_gen.go is out of date, run go generate ./zabbixfake")
+	}
+}
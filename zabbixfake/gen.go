@@ -0,0 +1,272 @@
+//go:build ignore
+
+// gen.go generates client_gen.go, the hooks and call accessors of the fake
+// Client, from the Client interface of the zabbix package. Run it with
+// go generate after changing the interface.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"unicode"
+)
+
+type method struct {
+	name    string
+	ctx     bool     // whether the first parameter is a context.Context
+	param   string   // name of the parameter other than ctx, if any
+	params  []string // types of the parameters other than ctx
+	rest    string   // name of the variadic parameter, if any
+	restTyp string   // element type of the variadic parameter
+	results []string
+}
+
+var output = flag.String("o", "client_gen.go", "output file")
+
+func main() {
+	flag.Parse()
+
+	methods, err := clientMethods("..")
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	var b bytes.Buffer
+	fmt.Fprintf(&b, `// Code generated by gen.go; DO NOT EDIT.
+
+package zabbixfake
+
+import (
+%s
+	zabbix "github.com/nimok/nim-go-zabbix"
+)
+
+// Client is a fake zabbix.Client. Each method records its call, then calls
+// the matching hook, such as HostGetFunc, if it is set. Methods without a
+// hook return zero values and a nil error; pointer results point to a zero
+// value rather than being nil.
+type Client struct {
+	recorder
+
+`, imports(methods))
+	for _, m := range methods {
+		fmt.Fprintf(&b, "\t%sFunc func(%s) %s\n", m.name, m.signature(), m.resultList())
+	}
+	b.WriteString("}\n")
+
+	for _, m := range methods {
+		m.write(&b)
+	}
+
+	src, err := format.Source(b.Bytes())
+	if err != nil {
+		log.Fatalf("formatting generated code: %v\n%s", err, b.Bytes())
+	}
+	if err := os.WriteFile(*output, src, 0o644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// imports returns the standard library imports used by the signatures of
+// methods.
+func imports(methods []method) string {
+	var s string
+	for _, pkg := range []string{"context", "time"} {
+		for _, m := range methods {
+			sig := m.signature() + m.resultList()
+			if strings.Contains(sig, pkg+".") {
+				s += fmt.Sprintf("\t%q\n", pkg)
+				break
+			}
+		}
+	}
+	return s
+}
+
+// clientMethods parses the package in dir and returns the methods of its
+// Client interface.
+func clientMethods(dir string) ([]method, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*.go"))
+	if err != nil {
+		return nil, err
+	}
+
+	fset := token.NewFileSet()
+	for _, file := range files {
+		if strings.HasSuffix(file, "_test.go") {
+			continue
+		}
+		f, err := parser.ParseFile(fset, file, nil, 0)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, decl := range f.Decls {
+			gen, ok := decl.(*ast.GenDecl)
+			if !ok || gen.Tok != token.TYPE {
+				continue
+			}
+			for _, spec := range gen.Specs {
+				ts := spec.(*ast.TypeSpec)
+				iface, ok := ts.Type.(*ast.InterfaceType)
+				if ts.Name.Name != "Client" || !ok {
+					continue
+				}
+				return interfaceMethods(iface)
+			}
+		}
+	}
+	return nil, fmt.Errorf("no Client interface in %s", dir)
+}
+
+func interfaceMethods(iface *ast.InterfaceType) ([]method, error) {
+	var methods []method
+	for _, field := range iface.Methods.List {
+		fn, ok := field.Type.(*ast.FuncType)
+		if !ok {
+			return nil, fmt.Errorf("embedded interfaces are not supported")
+		}
+
+		m := method{name: field.Names[0].Name}
+		for i, param := range fn.Params.List {
+			if ellipsis, ok := param.Type.(*ast.Ellipsis); ok {
+				m.rest, m.restTyp = param.Names[0].Name, typeString(ellipsis.Elt)
+				continue
+			}
+			typ := typeString(param.Type)
+			if i == 0 && typ == "context.Context" {
+				m.ctx = true
+				continue
+			}
+			m.param = "params"
+			if len(param.Names) > 0 {
+				m.param = param.Names[0].Name
+			}
+			for range max(len(param.Names), 1) {
+				m.params = append(m.params, typ)
+			}
+		}
+		if len(m.params) > 1 {
+			return nil, fmt.Errorf("%s: methods with more than one parameter besides ctx are not supported", m.name)
+		}
+		if fn.Results != nil {
+			for _, result := range fn.Results.List {
+				m.results = append(m.results, typeString(result.Type))
+			}
+		}
+		methods = append(methods, m)
+	}
+	return methods, nil
+}
+
+// typeString returns the source of a type expression, qualifying the types
+// declared by the zabbix package.
+func typeString(expr ast.Expr) string {
+	switch t := expr.(type) {
+	case *ast.Ident:
+		if unicode.IsUpper(rune(t.Name[0])) {
+			return "zabbix." + t.Name
+		}
+		return t.Name
+	case *ast.SelectorExpr:
+		return typeString(t.X) + "." + t.Sel.Name
+	case *ast.StarExpr:
+		return "*" + typeString(t.X)
+	case *ast.ArrayType:
+		return "[]" + typeString(t.Elt)
+	case *ast.MapType:
+		return "map[" + typeString(t.Key) + "]" + typeString(t.Value)
+	}
+	log.Fatalf("unsupported type %T", expr)
+	return ""
+}
+
+func (m method) signature() string {
+	var params []string
+	if m.ctx {
+		params = append(params, "ctx context.Context")
+	}
+	for _, p := range m.params {
+		params = append(params, m.param+" "+p)
+	}
+	if m.rest != "" {
+		params = append(params, m.rest+" ..."+m.restTyp)
+	}
+	return strings.Join(params, ", ")
+}
+
+func (m method) args() string {
+	var args []string
+	if m.ctx {
+		args = append(args, "ctx")
+	}
+	if len(m.params) > 0 {
+		args = append(args, m.param)
+	}
+	if m.rest != "" {
+		args = append(args, m.rest+"...")
+	}
+	return strings.Join(args, ", ")
+}
+
+func (m method) resultList() string {
+	if len(m.results) < 2 {
+		return strings.Join(m.results, "")
+	}
+	return "(" + strings.Join(m.results, ", ") + ")"
+}
+
+func (m method) write(b *bytes.Buffer) {
+	recorded := "nil"
+	if len(m.params) > 0 {
+		recorded = m.param
+	}
+
+	fmt.Fprintf(b, "\n// %s records the call and calls %sFunc if it is set.\n", m.name, m.name)
+	fmt.Fprintf(b, "func (c *Client) %s(%s) %s {\n", m.name, m.signature(), m.resultList())
+	if m.rest != "" {
+		recorded += ", " + m.rest + "..."
+	}
+	fmt.Fprintf(b, "\tc.record(%q, %s)\n", m.name, recorded)
+	fmt.Fprintf(b, "\tif c.%sFunc != nil {\n", m.name)
+	if len(m.results) > 0 {
+		fmt.Fprintf(b, "\t\treturn c.%sFunc(%s)\n", m.name, m.args())
+	} else {
+		fmt.Fprintf(b, "\t\tc.%sFunc(%s)\n\t\treturn\n", m.name, m.args())
+	}
+	b.WriteString("\t}\n")
+
+	if len(m.results) > 0 {
+		var zeros []string
+		for i, r := range m.results {
+			switch {
+			case r == "error", strings.HasPrefix(r, "[]"), strings.HasPrefix(r, "map["):
+				zeros = append(zeros, "nil")
+			case strings.HasPrefix(r, "*"):
+				zeros = append(zeros, "new("+r[1:]+")")
+			default:
+				fmt.Fprintf(b, "\tvar r%d %s\n", i, r)
+				zeros = append(zeros, fmt.Sprintf("r%d", i))
+			}
+		}
+		fmt.Fprintf(b, "\treturn %s\n", strings.Join(zeros, ", "))
+	}
+	b.WriteString("}\n")
+
+	if len(m.params) == 0 {
+		return
+	}
+	fmt.Fprintf(b, "\n// %sCalls returns the %s of the recorded %s calls, in order.\n", m.name, m.param, m.name)
+	fmt.Fprintf(b, "func (c *Client) %sCalls() []%s {\n", m.name, m.params[0])
+	fmt.Fprintf(b, "\treturn callsTo[%s](&c.recorder, %q)\n", m.params[0], m.name)
+	b.WriteString("}\n")
+}
@@ -0,0 +1,498 @@
+// Package zabbiximport creates Zabbix hosts in bulk from CSV or YAML
+// manifests, such as the ones exported from an onboarding spreadsheet.
+//
+// A manifest is parsed into rows, which an Importer validates, resolves and
+// creates:
+//
+//	rows, err := zabbiximport.ParseCSV(file)
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	report, err := zabbiximport.NewImporter(client).Import(ctx, rows)
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	for _, result := range report.Failed() {
+//		log.Printf("line %d: %s: %v", result.Line, result.Row.Host, result.Err)
+//	}
+//
+// Groups, templates and the proxy are given by name and resolved to IDs.
+// A row that is invalid, refers to missing objects or is rejected by Zabbix
+// fails on its own: the other rows are still imported.
+package zabbiximport
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"net"
+	"regexp"
+	"slices"
+	"strings"
+
+	zabbix "github.com/nimok/nim-go-zabbix"
+)
+
+// DefaultChunkSize is the number of hosts created per host.create call
+// unless WithChunkSize is given.
+const DefaultChunkSize = 100
+
+// Importer creates the hosts of manifests.
+type Importer struct {
+	client       zabbix.Client
+	chunkSize    int
+	dryRun       bool
+	createGroups bool
+}
+
+// Option configures an Importer.
+type Option func(*Importer)
+
+// WithChunkSize sets the number of hosts created per host.create call.
+func WithChunkSize(n int) Option {
+	return func(i *Importer) {
+		i.chunkSize = n
+	}
+}
+
+// WithDryRun makes Import validate and resolve the rows without creating
+// anything.
+func WithDryRun() Option {
+	return func(i *Importer) {
+		i.dryRun = true
+	}
+}
+
+// WithCreateMissingHostGroups creates the host groups of the rows that don't
+// exist, instead of failing the rows.
+func WithCreateMissingHostGroups() Option {
+	return func(i *Importer) {
+		i.createGroups = true
+	}
+}
+
+// NewImporter returns an Importer using client.
+func NewImporter(client zabbix.Client, opts ...Option) *Importer {
+	i := &Importer{client: client, chunkSize: DefaultChunkSize}
+	for _, opt := range opts {
+		opt(i)
+	}
+	if i.chunkSize < 1 {
+		i.chunkSize = 1
+	}
+	return i
+}
+
+// Report is the outcome of Import.
+type Report struct {
+	DryRun  bool
+	Results []Result // One per row, in order
+}
+
+// Result is the outcome of the import of one row.
+type Result struct {
+	Row    Row
+	Line   int         // Line of the row in the manifest
+	Host   zabbix.Host // Host built from the row, with resolved IDs
+	HostID string      // ID of the created host
+	Err    error       // Why the row was not imported; nil if it was, or would be in dry-run mode
+}
+
+// Created returns the number of hosts created.
+func (r *Report) Created() int {
+	n := 0
+	for _, result := range r.Results {
+		if result.HostID != "" {
+			n++
+		}
+	}
+	return n
+}
+
+// Failed returns the results of the rows that were not imported.
+func (r *Report) Failed() []Result {
+	var failed []Result
+	for _, result := range r.Results {
+		if result.Err != nil {
+			failed = append(failed, result)
+		}
+	}
+	return failed
+}
+
+// Err returns the errors of all failed rows, or nil.
+func (r *Report) Err() error {
+	var errs []error
+	for _, result := range r.Failed() {
+		errs = append(errs, fmt.Errorf("line %d: %s: %w", result.Line, result.Row.Host, result.Err))
+	}
+	return errors.Join(errs...)
+}
+
+// Import validates rows, resolves their names and creates their hosts. Row
+// errors are reported in the results; the returned error is for failures
+// affecting all rows, such as a failed lookup of names.
+func (i *Importer) Import(ctx context.Context, rows []Row) (*Report, error) {
+	report := &Report{DryRun: i.dryRun, Results: make([]Result, len(rows))}
+
+	seen := make(map[string]int)
+	for n, row := range rows {
+		result := &report.Results[n]
+		result.Row, result.Line = row, row.Line
+		result.Host, result.Err = Host(row)
+
+		if line, ok := seen[row.Host]; ok && result.Err == nil {
+			result.Err = fmt.Errorf("host already listed on line %d", line)
+		} else if !ok {
+			seen[row.Host] = row.Line
+		}
+	}
+
+	if err := i.resolve(ctx, report.Results); err != nil {
+		return nil, err
+	}
+	if i.dryRun {
+		return report, nil
+	}
+
+	var pending []*Result
+	for n := range report.Results {
+		if report.Results[n].Err == nil {
+			pending = append(pending, &report.Results[n])
+		}
+	}
+	for chunk := range slices.Chunk(pending, i.chunkSize) {
+		i.create(ctx, chunk)
+	}
+	return report, nil
+}
+
+// create creates the hosts of a chunk in one call. Since host.create fails
+// as a whole, the hosts of a failed chunk are retried one by one to find
+// the failing rows.
+func (i *Importer) create(ctx context.Context, chunk []*Result) {
+	hosts := make([]zabbix.Host, len(chunk))
+	for n, result := range chunk {
+		hosts[n] = result.Host
+	}
+
+	resp, err := i.client.HostCreate(ctx, hosts)
+	if err == nil && len(resp.HostIDs) != len(hosts) {
+		err = fmt.Errorf("got %d IDs for %d hosts", len(resp.HostIDs), len(hosts))
+	}
+	if err == nil {
+		for n, result := range chunk {
+			result.HostID = resp.HostIDs[n]
+		}
+		return
+	}
+
+	if len(chunk) == 1 || ctx.Err() != nil {
+		for _, result := range chunk {
+			result.Err = err
+		}
+		return
+	}
+	for _, result := range chunk {
+		i.create(ctx, []*Result{result})
+	}
+}
+
+// resolve sets the IDs of the groups, templates and proxies of the valid
+// results, failing the ones referring to missing objects.
+func (i *Importer) resolve(ctx context.Context, results []Result) error {
+	var groups, templates, proxies []string
+	for _, result := range results {
+		if result.Err != nil {
+			continue
+		}
+		for _, group := range result.Host.Groups {
+			groups = append(groups, group.Name)
+		}
+		for _, template := range result.Host.Templates {
+			templates = append(templates, template.Host)
+		}
+		if result.Row.Proxy != "" {
+			proxies = append(proxies, result.Row.Proxy)
+		}
+	}
+
+	var opts []zabbix.ResolverOption
+	if i.createGroups && !i.dryRun {
+		opts = append(opts, zabbix.WithCreateMissingHostGroups())
+	}
+	resolver := zabbix.NewResolver(i.client, opts...)
+
+	groupIDs, missingGroups, err := lookup(resolver.HostGroupIDs(ctx, compact(groups)...))
+	if err != nil {
+		return err
+	}
+	templateIDs, missingTemplates, err := lookup(resolver.TemplateIDs(ctx, compact(templates)...))
+	if err != nil {
+		return err
+	}
+	proxyIDs, missingProxies, err := lookup(resolver.ProxyIDs(ctx, compact(proxies)...))
+	if err != nil {
+		return err
+	}
+	// Groups to be created are missing only in dry-run mode
+	if i.createGroups {
+		missingGroups = nil
+	}
+
+	for n := range results {
+		result := &results[n]
+		if result.Err != nil {
+			continue
+		}
+
+		var errs []error
+		host := &result.Host
+		host.Groups, errs = setIDs(host.Groups, "host group", missingGroups, errs,
+			func(g zabbix.HostGroup) string { return g.Name },
+			func(name string) zabbix.HostGroup { return zabbix.HostGroup{GroupID: groupIDs[name]} })
+		host.Templates, errs = setIDs(host.Templates, "template", missingTemplates, errs,
+			func(t zabbix.Template) string { return t.Host },
+			func(name string) zabbix.Template { return zabbix.Template{TemplateID: templateIDs[name]} })
+		if proxy := result.Row.Proxy; proxy != "" {
+			if slices.Contains(missingProxies, proxy) {
+				errs = append(errs, &zabbix.NotFoundError{Kind: "proxy", Names: []string{proxy}})
+			}
+			host.MonitoredBy = zabbix.MonitoredByProxy
+			host.ProxyID = proxyIDs[proxy]
+		}
+		result.Err = errors.Join(errs...)
+	}
+	return nil
+}
+
+// lookup splits the result of a Resolver into the IDs found and the names
+// missing.
+func lookup(ids map[string]string, err error) (map[string]string, []string, error) {
+	var notFound *zabbix.NotFoundError
+	if errors.As(err, &notFound) {
+		return ids, notFound.Names, nil
+	}
+	return ids, nil, err
+}
+
+// setIDs replaces the objects referred to by name with the ones referring
+// to their IDs, adding a *zabbix.NotFoundError to errs for the missing
+// names.
+func setIDs[T any](objects []T, kind string, missing []string, errs []error, name func(T) string, withID func(string) T) ([]T, []error) {
+	var notFound []string
+	for n, object := range objects {
+		if name := name(object); slices.Contains(missing, name) {
+			notFound = append(notFound, name)
+		} else {
+			objects[n] = withID(name)
+		}
+	}
+	if len(notFound) > 0 {
+		errs = append(errs, &zabbix.NotFoundError{Kind: kind, Names: notFound})
+	}
+	return objects, errs
+}
+
+// compact returns the distinct names, sorted.
+func compact(names []string) []string {
+	slices.Sort(names)
+	return slices.Compact(names)
+}
+
+var (
+	hostNamePattern  = regexp.MustCompile(`^[0-9A-Za-z _.-]+$`)
+	macroNamePattern = regexp.MustCompile(`^\{\$[A-Z0-9_.]+(:.*)?\}$`)
+)
+
+// Default ports of the interface types.
+var defaultPorts = map[zabbix.InterfaceType]string{
+	zabbix.InterfaceTypeAgent: "10050",
+	zabbix.InterfaceTypeSNMP:  "161",
+	zabbix.InterfaceTypeIPMI:  "623",
+	zabbix.InterfaceTypeJMX:   "12345",
+}
+
+// Host validates row and returns its host, with groups and templates
+// referred to by name and without its proxy, which are set on import.
+func Host(row Row) (zabbix.Host, error) {
+	var errs []error
+	fail := func(format string, args ...any) {
+		errs = append(errs, fmt.Errorf(format, args...))
+	}
+
+	host := zabbix.Host{Host: row.Host, Name: row.Name}
+	switch {
+	case row.Host == "":
+		fail("host name is missing")
+	case !hostNamePattern.MatchString(row.Host):
+		fail("invalid host name %q: only letters, digits, spaces, dots, dashes and underscores are allowed", row.Host)
+	}
+
+	if len(row.Groups) == 0 {
+		fail("no host group")
+	}
+	for _, name := range row.Groups {
+		host.Groups = append(host.Groups, zabbix.HostGroup{Name: name})
+	}
+	for _, name := range row.Templates {
+		host.Templates = append(host.Templates, zabbix.Template{Host: name})
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(row.Tags)) {
+		if name == "" {
+			fail("tag without name")
+		}
+		host.Tags = append(host.Tags, zabbix.Tag{Tag: name, Value: row.Tags[name]})
+	}
+	for _, name := range slices.Sorted(maps.Keys(row.Macros)) {
+		if !macroNamePattern.MatchString(name) {
+			fail("invalid macro %q", name)
+		}
+		host.Macros = append(host.Macros, zabbix.Macro{Macro: name, Value: row.Macros[name]})
+	}
+
+	iface, err := hostInterface(row)
+	if err != nil {
+		errs = append(errs, err)
+	} else if iface != nil {
+		host.Interfaces = []zabbix.HostInterface{*iface}
+	}
+
+	return host, errors.Join(errs...)
+}
+
+// hostInterface returns the interface of row, or nil if it has none.
+func hostInterface(row Row) (*zabbix.HostInterface, error) {
+	snmp := row.SNMPVersion != "" || row.SNMPCommunity != "" || row.SNMPSecurityName != "" || row.SNMPSecurityLevel != "" ||
+		row.SNMPAuthProtocol != "" || row.SNMPAuthPassphrase != "" || row.SNMPPrivProtocol != "" ||
+		row.SNMPPrivPassphrase != "" || row.SNMPContextName != ""
+	if row.IP == "" && row.DNS == "" {
+		if row.InterfaceType != "" || row.Port != "" || snmp {
+			return nil, errors.New("interface without IP address or DNS name")
+		}
+		return nil, nil
+	}
+
+	iface := &zabbix.HostInterface{Main: zabbix.MainInterfaceYes, IP: row.IP, DNS: row.DNS, Port: row.Port}
+	switch strings.ToLower(row.InterfaceType) {
+	case "", "agent":
+		iface.Type = zabbix.InterfaceTypeAgent
+	case "snmp":
+		iface.Type = zabbix.InterfaceTypeSNMP
+	case "ipmi":
+		iface.Type = zabbix.InterfaceTypeIPMI
+	case "jmx":
+		iface.Type = zabbix.InterfaceTypeJMX
+	default:
+		return nil, fmt.Errorf("unknown interface type %q", row.InterfaceType)
+	}
+
+	if row.IP != "" {
+		if net.ParseIP(row.IP) == nil {
+			return nil, fmt.Errorf("invalid IP address %q", row.IP)
+		}
+		iface.UseIP = zabbix.UseIPOptionIP
+	}
+	if iface.Port == "" {
+		iface.Port = defaultPorts[iface.Type]
+	}
+
+	if iface.Type != zabbix.InterfaceTypeSNMP {
+		if snmp {
+			return nil, errors.New("SNMP details given for a non-SNMP interface")
+		}
+		return iface, nil
+	}
+
+	details, err := snmpDetails(row)
+	if err != nil {
+		return nil, err
+	}
+	iface.Details = details
+	return iface, nil
+}
+
+var (
+	securityLevels = map[string]zabbix.SecurityLevel{
+		"noauthnopriv": zabbix.SecurityLevelNoAuthNoPriv,
+		"authnopriv":   zabbix.SecurityLevelAuthNoPriv,
+		"authpriv":     zabbix.SecurityLevelAuthPriv,
+	}
+	authProtocols = map[string]zabbix.AuthProtocol{
+		"md5":    zabbix.AuthProtocolMD5,
+		"sha1":   zabbix.AuthProtocolSHA1,
+		"sha224": zabbix.AuthProtocolSHA224,
+		"sha256": zabbix.AuthProtocolSHA256,
+		"sha384": zabbix.AuthProtocolSHA384,
+		"sha512": zabbix.AuthProtocolSHA512,
+	}
+	privProtocols = map[string]zabbix.PrivProtocol{
+		"des":     zabbix.PrivProtocolDES,
+		"aes128":  zabbix.PrivProtocolAES128,
+		"aes192":  zabbix.PrivProtocolAES192,
+		"aes256":  zabbix.PrivProtocolAES256,
+		"aes192c": zabbix.PrivProtocolAES192C,
+		"aes256c": zabbix.PrivProtocolAES256C,
+	}
+)
+
+func snmpDetails(row Row) (zabbix.InterfaceDetails, error) {
+	details := zabbix.InterfaceDetails{Bulk: zabbix.BulkEnabled}
+	switch strings.ToLower(row.SNMPVersion) {
+	case "1", "v1":
+		details.Version = zabbix.SNMPv1
+	case "", "2", "2c", "v2c":
+		details.Version = zabbix.SNMPv2c
+	case "3", "v3":
+		details.Version = zabbix.SNMPv3
+	default:
+		return details, fmt.Errorf("unknown SNMP version %q", row.SNMPVersion)
+	}
+
+	if details.Version != zabbix.SNMPv3 {
+		if row.SNMPSecurityName != "" || row.SNMPSecurityLevel != "" || row.SNMPAuthProtocol != "" || row.SNMPAuthPassphrase != "" ||
+			row.SNMPPrivProtocol != "" || row.SNMPPrivPassphrase != "" || row.SNMPContextName != "" {
+			return details, errors.New("SNMPv3 details given for an SNMPv1 or v2c interface")
+		}
+		details.Community = row.SNMPCommunity
+		if details.Community == "" {
+			details.Community = "{$SNMP_COMMUNITY}"
+		}
+		return details, nil
+	}
+
+	if row.SNMPCommunity != "" {
+		return details, errors.New("SNMP community given for an SNMPv3 interface")
+	}
+	details.SecurityName = row.SNMPSecurityName
+	details.ContextName = row.SNMPContextName
+
+	var ok bool
+	if row.SNMPSecurityLevel != "" {
+		if details.SecurityLevel, ok = securityLevels[strings.ToLower(row.SNMPSecurityLevel)]; !ok {
+			return details, fmt.Errorf("unknown SNMP security level %q", row.SNMPSecurityLevel)
+		}
+	}
+	if row.SNMPAuthProtocol != "" {
+		if details.AuthProtocol, ok = authProtocols[strings.ToLower(row.SNMPAuthProtocol)]; !ok {
+			return details, fmt.Errorf("unknown SNMP authentication protocol %q", row.SNMPAuthProtocol)
+		}
+	}
+	if row.SNMPPrivProtocol != "" {
+		if details.PrivProtocol, ok = privProtocols[strings.ToLower(row.SNMPPrivProtocol)]; !ok {
+			return details, fmt.Errorf("unknown SNMP privacy protocol %q", row.SNMPPrivProtocol)
+		}
+	}
+
+	if details.SecurityLevel != zabbix.SecurityLevelNoAuthNoPriv && row.SNMPAuthPassphrase == "" {
+		return details, errors.New("SNMP authentication passphrase is missing")
+	}
+	if details.SecurityLevel == zabbix.SecurityLevelAuthPriv && row.SNMPPrivPassphrase == "" {
+		return details, errors.New("SNMP privacy passphrase is missing")
+	}
+	details.AuthPassphrase = row.SNMPAuthPassphrase
+	details.PrivPassphrase = row.SNMPPrivPassphrase
+	return details, nil
+}
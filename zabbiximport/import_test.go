@@ -0,0 +1,159 @@
+package zabbiximport_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	zabbix "github.com/nimok/nim-go-zabbix"
+	"github.com/nimok/nim-go-zabbix/zabbiximport"
+	"github.com/nimok/nim-go-zabbix/zabbixtest"
+)
+
+func setup(t *testing.T) (*zabbixtest.Server, zabbix.Client) {
+	t.Helper()
+
+	srv := zabbixtest.NewServer()
+	t.Cleanup(srv.Close)
+
+	client, err := zabbix.NewClient(srv.URL, zabbix.WithUserPass(zabbixtest.DefaultUsername, zabbixtest.DefaultPassword))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := client.Authenticate(); err != nil {
+		t.Fatal(err)
+	}
+	return srv, client
+}
+
+const manifest = `host,ip,groups,templates,tags,proxy,interface_type,snmp_version,snmp_securitylevel,snmp_authpassphrase
+web-01,10.0.0.1,Linux servers,ICMP Ping,env=prod;team=web,,,,,
+web-02,10.0.0.2,Linux servers,Missing template,,,,,,
+Zabbix server,127.0.0.1,Linux servers,,,,,,,
+switch-01,10.0.1.1,Linux servers,Generic by SNMP,,proxy-01,snmp,3,authNoPriv,secret
+web 03!,10.0.0.3,Linux servers,,,,,,,
+web-01,10.0.0.4,Linux servers,,,,,,,
+`
+
+func TestImport(t *testing.T) {
+	srv, client := setup(t)
+	proxyID, err := srv.AddProxy(zabbix.Proxy{Name: "proxy-01"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rows, err := zabbiximport.ParseCSV(strings.NewReader(manifest))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+	report, err := zabbiximport.NewImporter(client, zabbiximport.WithChunkSize(3)).Import(ctx, rows)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if report.Created() != 2 {
+		t.Errorf("created %d hosts, want 2", report.Created())
+	}
+	var failed []int
+	for _, result := range report.Failed() {
+		failed = append(failed, result.Line)
+	}
+	if len(failed) != 4 || failed[0] != 3 || failed[1] != 4 || failed[2] != 6 || failed[3] != 7 {
+		t.Errorf("failed lines %v, want [3 4 6 7]", failed)
+	}
+	var notFound *zabbix.NotFoundError
+	if !errors.As(report.Results[1].Err, &notFound) || notFound.Kind != "template" {
+		t.Errorf("got error %v for a missing template", report.Results[1].Err)
+	}
+	if err := report.Err(); err == nil || !strings.Contains(err.Error(), "line 4: Zabbix server: ") {
+		t.Errorf("got report error %v", err)
+	}
+
+	hosts, err := client.HostGet(ctx, zabbix.HostGetParameters{
+		GetParameters:    zabbix.GetParameters{Filter: map[string]any{"host": []string{"web-01", "switch-01"}}},
+		SelectInterfaces: "extend",
+		SelectTags:       "extend",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(hosts) != 2 {
+		t.Fatalf("got %d hosts, want 2", len(hosts))
+	}
+	for _, host := range hosts {
+		switch host.Host {
+		case "web-01":
+			if len(host.Tags) != 2 || len(host.Interfaces) != 1 || host.Interfaces[0].Port != "10050" {
+				t.Errorf("unexpected host %+v", host)
+			}
+		case "switch-01":
+			if host.ProxyID != proxyID || len(host.Interfaces) != 1 || host.Interfaces[0].Port != "161" ||
+				host.Interfaces[0].Details.SecurityLevel != zabbix.SecurityLevelAuthNoPriv {
+				t.Errorf("unexpected host %+v", host)
+			}
+		}
+	}
+}
+
+func TestImportDryRun(t *testing.T) {
+	_, client := setup(t)
+
+	rows := []zabbiximport.Row{
+		{Line: 1, Host: "web-01", Groups: []string{"Web servers"}},
+		{Line: 2, Host: "web-02", Groups: []string{"Linux servers"}, Proxy: "missing"},
+	}
+	ctx := context.Background()
+	report, err := zabbiximport.NewImporter(client, zabbiximport.WithDryRun(), zabbiximport.WithCreateMissingHostGroups()).Import(ctx, rows)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !report.DryRun || report.Created() != 0 {
+		t.Errorf("unexpected report %+v", report)
+	}
+	if report.Results[0].Err != nil || report.Results[1].Err == nil {
+		t.Errorf("got errors %v and %v", report.Results[0].Err, report.Results[1].Err)
+	}
+
+	groups, err := client.HostgroupGet(ctx, zabbix.HostGroupGetParameters{
+		GetParameters: zabbix.GetParameters{Filter: map[string]any{"name": "Web servers"}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(groups) != 0 {
+		t.Error("host group created in dry-run mode")
+	}
+}
+
+func TestHost(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		row  zabbiximport.Row
+		err  string
+	}{
+		{"no group", zabbiximport.Row{Host: "a"}, "no host group"},
+		{"bad IP", zabbiximport.Row{Host: "a", Groups: []string{"g"}, IP: "10.0.0"}, `invalid IP address "10.0.0"`},
+		{"bad macro", zabbiximport.Row{Host: "a", Groups: []string{"g"}, Macros: map[string]string{"PORT": "1"}}, `invalid macro "PORT"`},
+		{"SNMP on agent", zabbiximport.Row{Host: "a", Groups: []string{"g"}, IP: "10.0.0.1", SNMPCommunity: "public"}, "SNMP details given for a non-SNMP interface"},
+		{"no passphrase", zabbiximport.Row{Host: "a", Groups: []string{"g"}, IP: "10.0.0.1", InterfaceType: "snmp", SNMPVersion: "3", SNMPSecurityLevel: "authPriv"}, "SNMP authentication passphrase is missing"},
+		{"unknown type", zabbiximport.Row{Host: "a", Groups: []string{"g"}, DNS: "a.example.com", InterfaceType: "ssh"}, `unknown interface type "ssh"`},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := zabbiximport.Host(tc.row); err == nil || err.Error() != tc.err {
+				t.Errorf("got error %v, want %q", err, tc.err)
+			}
+		})
+	}
+
+	host, err := zabbiximport.Host(zabbiximport.Row{Host: "a", Groups: []string{"g"}, DNS: "a.example.com", InterfaceType: "snmp"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	iface := host.Interfaces[0]
+	if iface.UseIP != zabbix.UseIPOptionDNS || iface.Port != "161" || iface.Details.Version != zabbix.SNMPv2c ||
+		iface.Details.Community != "{$SNMP_COMMUNITY}" {
+		t.Errorf("unexpected interface %+v", iface)
+	}
+}
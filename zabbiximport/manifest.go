@@ -0,0 +1,192 @@
+package zabbiximport
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Row is a host of a manifest. All values are kept as written; they are
+// validated when the row is converted to a host.
+type Row struct {
+	Line int `yaml:"-"` // Line of the row in the manifest, starting at 1
+
+	Host          string            `yaml:"host"`           // Technical name; required
+	Name          string            `yaml:"name"`           // Visible name
+	IP            string            `yaml:"ip"`             // IP address of the interface
+	DNS           string            `yaml:"dns"`            // DNS name of the interface
+	Port          string            `yaml:"port"`           // Port of the interface; defaults to the one of its type
+	InterfaceType string            `yaml:"interface_type"` // agent (default), snmp, ipmi or jmx
+	Groups        []string          `yaml:"groups"`         // Host group names; at least one is required
+	Templates     []string          `yaml:"templates"`      // Template technical names
+	Tags          map[string]string `yaml:"tags"`           // Tag values by tag name
+	Macros        map[string]string `yaml:"macros"`         // Macro values by macro, e.g. {$SNMP_PORT}
+	Proxy         string            `yaml:"proxy"`          // Name of the proxy monitoring the host
+
+	SNMPVersion        string `yaml:"snmp_version"`        // 1, 2c (default) or 3
+	SNMPCommunity      string `yaml:"snmp_community"`      // SNMPv1 and v2c; defaults to {$SNMP_COMMUNITY}
+	SNMPSecurityName   string `yaml:"snmp_securityname"`   // SNMPv3
+	SNMPSecurityLevel  string `yaml:"snmp_securitylevel"`  // SNMPv3: noAuthNoPriv (default), authNoPriv or authPriv
+	SNMPAuthProtocol   string `yaml:"snmp_authprotocol"`   // SNMPv3: MD5 (default), SHA1, SHA224, SHA256, SHA384 or SHA512
+	SNMPAuthPassphrase string `yaml:"snmp_authpassphrase"` // SNMPv3
+	SNMPPrivProtocol   string `yaml:"snmp_privprotocol"`   // SNMPv3: DES (default), AES128, AES192, AES256, AES192C or AES256C
+	SNMPPrivPassphrase string `yaml:"snmp_privpassphrase"` // SNMPv3
+	SNMPContextName    string `yaml:"snmp_contextname"`    // SNMPv3
+}
+
+// columns are the CSV columns, and YAML keys, of a row. List columns hold
+// values separated by ListSeparator, and map columns name=value pairs.
+var columns = map[string]func(r *Row, value string){
+	"host":                func(r *Row, v string) { r.Host = v },
+	"name":                func(r *Row, v string) { r.Name = v },
+	"ip":                  func(r *Row, v string) { r.IP = v },
+	"dns":                 func(r *Row, v string) { r.DNS = v },
+	"port":                func(r *Row, v string) { r.Port = v },
+	"interface_type":      func(r *Row, v string) { r.InterfaceType = v },
+	"groups":              func(r *Row, v string) { r.Groups = splitList(v) },
+	"templates":           func(r *Row, v string) { r.Templates = splitList(v) },
+	"tags":                func(r *Row, v string) { r.Tags = splitPairs(v) },
+	"macros":              func(r *Row, v string) { r.Macros = splitPairs(v) },
+	"proxy":               func(r *Row, v string) { r.Proxy = v },
+	"snmp_version":        func(r *Row, v string) { r.SNMPVersion = v },
+	"snmp_community":      func(r *Row, v string) { r.SNMPCommunity = v },
+	"snmp_securityname":   func(r *Row, v string) { r.SNMPSecurityName = v },
+	"snmp_securitylevel":  func(r *Row, v string) { r.SNMPSecurityLevel = v },
+	"snmp_authprotocol":   func(r *Row, v string) { r.SNMPAuthProtocol = v },
+	"snmp_authpassphrase": func(r *Row, v string) { r.SNMPAuthPassphrase = v },
+	"snmp_privprotocol":   func(r *Row, v string) { r.SNMPPrivProtocol = v },
+	"snmp_privpassphrase": func(r *Row, v string) { r.SNMPPrivPassphrase = v },
+	"snmp_contextname":    func(r *Row, v string) { r.SNMPContextName = v },
+}
+
+// ListSeparator separates the values of the list and map columns of a CSV
+// manifest, e.g. "Linux servers;Web servers" or "env=prod;team=web".
+const ListSeparator = ";"
+
+// ParseCSV parses a CSV manifest. Its first line names the columns, in any
+// order, e.g.:
+//
+//	host,ip,groups,templates,tags
+//	web-01,10.0.0.1,Linux servers;Web servers,ICMP Ping,env=prod;team=web
+//
+// Empty lines are skipped. An unknown column or a malformed line fails the
+// whole manifest.
+func ParseCSV(r io.Reader) ([]Row, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	for i, name := range header {
+		header[i] = strings.ToLower(strings.TrimSpace(name))
+		if _, ok := columns[header[i]]; !ok {
+			return nil, fmt.Errorf("unknown column %q", name)
+		}
+	}
+	if !slices.Contains(header, "host") {
+		return nil, errors.New(`missing column "host"`)
+	}
+
+	var rows []Row
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return rows, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		line, _ := reader.FieldPos(0)
+		row := Row{Line: line}
+		for i, value := range record {
+			columns[header[i]](&row, strings.TrimSpace(value))
+		}
+		rows = append(rows, row)
+	}
+}
+
+// ParseYAML parses a YAML manifest, a list of rows with the keys of the CSV
+// columns, e.g.:
+//
+//	# hosts.yaml
+//	- host: web-01
+//	  ip: 10.0.0.1
+//	  groups: [Linux servers, Web servers]
+//	  templates: [ICMP Ping]
+//	  tags: {env: prod, team: web}
+//
+// An unknown key or a malformed row fails the whole manifest.
+func ParseYAML(r io.Reader) ([]Row, error) {
+	var doc yaml.Node
+	if err := yaml.NewDecoder(r).Decode(&doc); err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	list := &doc
+	if list.Kind == yaml.DocumentNode && len(list.Content) > 0 {
+		list = list.Content[0]
+	}
+	if list.Kind != yaml.SequenceNode {
+		return nil, fmt.Errorf("line %d: manifest is not a list of hosts", list.Line)
+	}
+
+	rows := make([]Row, 0, len(list.Content))
+	for _, node := range list.Content {
+		if node.Kind != yaml.MappingNode {
+			return nil, fmt.Errorf("line %d: host is not a mapping", node.Line)
+		}
+		for i := 0; i < len(node.Content); i += 2 {
+			if key := node.Content[i]; columns[key.Value] == nil {
+				return nil, fmt.Errorf("line %d: unknown key %q", key.Line, key.Value)
+			}
+		}
+
+		var row Row
+		if err := node.Decode(&row); err != nil {
+			return nil, err
+		}
+		row.Line = node.Line
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+func splitList(s string) []string {
+	var values []string
+	for _, v := range strings.Split(s, ListSeparator) {
+		if v = strings.TrimSpace(v); v != "" {
+			values = append(values, v)
+		}
+	}
+	return values
+}
+
+// splitPairs splits name=value pairs. A pair without "=" has an empty
+// value.
+func splitPairs(s string) map[string]string {
+	pairs := splitList(s)
+	if len(pairs) == 0 {
+		return nil
+	}
+
+	m := make(map[string]string, len(pairs))
+	for _, pair := range pairs {
+		name, value, _ := strings.Cut(pair, "=")
+		m[strings.TrimSpace(name)] = strings.TrimSpace(value)
+	}
+	return m
+}
@@ -0,0 +1,101 @@
+package zabbiximport_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/nimok/nim-go-zabbix/zabbiximport"
+)
+
+func TestParseCSV(t *testing.T) {
+	manifest := `host,ip,groups,templates,tags,macros
+web-01,10.0.0.1,Linux servers;Web servers,ICMP Ping,env=prod;team=web,{$PORT}=8080
+
+"web-02",10.0.0.2,Linux servers,,,
+`
+	rows, err := zabbiximport.ParseCSV(strings.NewReader(manifest))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []zabbiximport.Row{
+		{
+			Line:      2,
+			Host:      "web-01",
+			IP:        "10.0.0.1",
+			Groups:    []string{"Linux servers", "Web servers"},
+			Templates: []string{"ICMP Ping"},
+			Tags:      map[string]string{"env": "prod", "team": "web"},
+			Macros:    map[string]string{"{$PORT}": "8080"},
+		},
+		{
+			Line:   4,
+			Host:   "web-02",
+			IP:     "10.0.0.2",
+			Groups: []string{"Linux servers"},
+		},
+	}
+	if !reflect.DeepEqual(rows, want) {
+		t.Errorf("got %+v, want %+v", rows, want)
+	}
+
+	for _, manifest := range []string{
+		"host,color\nweb-01,red\n",
+		"ip\n10.0.0.1\n",
+		"host,ip\nweb-01\n",
+	} {
+		if _, err := zabbiximport.ParseCSV(strings.NewReader(manifest)); err == nil {
+			t.Errorf("want an error for %q", manifest)
+		}
+	}
+}
+
+func TestParseYAML(t *testing.T) {
+	manifest := `
+- host: web-01
+  ip: 10.0.0.1
+  groups: [Linux servers, Web servers]
+  tags: {env: prod}
+- host: switch-01
+  ip: 10.0.1.1
+  groups: [Switches]
+  interface_type: snmp
+  snmp_version: 3
+  snmp_securitylevel: authPriv
+`
+	rows, err := zabbiximport.ParseYAML(strings.NewReader(manifest))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []zabbiximport.Row{
+		{
+			Line:   2,
+			Host:   "web-01",
+			IP:     "10.0.0.1",
+			Groups: []string{"Linux servers", "Web servers"},
+			Tags:   map[string]string{"env": "prod"},
+		},
+		{
+			Line:              6,
+			Host:              "switch-01",
+			IP:                "10.0.1.1",
+			Groups:            []string{"Switches"},
+			InterfaceType:     "snmp",
+			SNMPVersion:       "3",
+			SNMPSecurityLevel: "authPriv",
+		},
+	}
+	if !reflect.DeepEqual(rows, want) {
+		t.Errorf("got %+v, want %+v", rows, want)
+	}
+
+	for _, manifest := range []string{
+		"host: web-01\n",
+		"- web-01\n",
+		"- host: web-01\n  color: red\n",
+	} {
+		if _, err := zabbiximport.ParseYAML(strings.NewReader(manifest)); err == nil {
+			t.Errorf("want an error for %q", manifest)
+		}
+	}
+}
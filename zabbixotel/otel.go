@@ -0,0 +1,157 @@
+// Package zabbixotel instruments a zabbix.Client with OpenTelemetry.
+//
+// Interceptor returns a zabbix.Interceptor that creates a client span per
+// JSON-RPC call, propagates the trace context in the HTTP headers of the
+// request and records per-method metrics:
+//
+//	client, err := zabbix.NewClient(url, zabbix.WithAPIToken(token),
+//		zabbix.WithInterceptors(zabbixotel.Interceptor()))
+//
+// Spans are named after the JSON-RPC method, e.g. "host.get", and follow the
+// semantic conventions for JSON-RPC. The metrics are:
+//
+//   - zabbix.client.requests, a counter of calls;
+//   - zabbix.client.duration, a histogram of call durations in seconds;
+//   - zabbix.client.errors, a counter of failed calls.
+//
+// All carry the rpc.method attribute; failed calls also carry error.type,
+// the Zabbix error code for API errors and "transport" otherwise.
+//
+// The global tracer provider, meter provider and propagator are used unless
+// the corresponding options are given.
+package zabbixotel
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+
+	zabbix "github.com/nimok/nim-go-zabbix"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/metric/noop"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// ScopeName is the instrumentation scope of the tracer and meter.
+const ScopeName = "github.com/nimok/nim-go-zabbix/zabbixotel"
+
+// Attribute keys set on spans and metrics.
+const (
+	RPCSystemKey           = attribute.Key("rpc.system")
+	RPCMethodKey           = attribute.Key("rpc.method")
+	JSONRPCVersionKey      = attribute.Key("rpc.jsonrpc.version")
+	JSONRPCErrorCodeKey    = attribute.Key("rpc.jsonrpc.error_code")
+	JSONRPCErrorMessageKey = attribute.Key("rpc.jsonrpc.error_message")
+	ErrorTypeKey           = attribute.Key("error.type")
+)
+
+type config struct {
+	tracerProvider trace.TracerProvider
+	meterProvider  metric.MeterProvider
+	propagator     propagation.TextMapPropagator
+}
+
+// Option configures the instrumentation.
+type Option func(*config)
+
+// WithTracerProvider sets the tracer provider used to create spans.
+func WithTracerProvider(provider trace.TracerProvider) Option {
+	return func(c *config) {
+		c.tracerProvider = provider
+	}
+}
+
+// WithMeterProvider sets the meter provider used to record metrics.
+func WithMeterProvider(provider metric.MeterProvider) Option {
+	return func(c *config) {
+		c.meterProvider = provider
+	}
+}
+
+// WithPropagator sets the propagator injecting the trace context into the
+// HTTP headers of requests.
+func WithPropagator(propagator propagation.TextMapPropagator) Option {
+	return func(c *config) {
+		c.propagator = propagator
+	}
+}
+
+// Interceptor returns an interceptor tracing and measuring every call of a
+// client. Instruments that fail to be created are reported to the global
+// error handler and replaced by no-ops.
+func Interceptor(opts ...Option) zabbix.Interceptor {
+	cfg := config{
+		tracerProvider: otel.GetTracerProvider(),
+		meterProvider:  otel.GetMeterProvider(),
+		propagator:     otel.GetTextMapPropagator(),
+	}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	tracer := cfg.tracerProvider.Tracer(ScopeName)
+	meter := cfg.meterProvider.Meter(ScopeName)
+
+	requests, err := meter.Int64Counter("zabbix.client.requests",
+		metric.WithDescription("Number of Zabbix API calls."),
+		metric.WithUnit("{call}"))
+	if err != nil {
+		otel.Handle(err)
+		requests = noop.Int64Counter{}
+	}
+	duration, err := meter.Float64Histogram("zabbix.client.duration",
+		metric.WithDescription("Duration of Zabbix API calls."),
+		metric.WithUnit("s"))
+	if err != nil {
+		otel.Handle(err)
+		duration = noop.Float64Histogram{}
+	}
+	failures, err := meter.Int64Counter("zabbix.client.errors",
+		metric.WithDescription("Number of failed Zabbix API calls."),
+		metric.WithUnit("{call}"))
+	if err != nil {
+		otel.Handle(err)
+		failures = noop.Int64Counter{}
+	}
+
+	return func(ctx context.Context, call *zabbix.Call, next zabbix.Invoker) error {
+		method := RPCMethodKey.String(call.Method)
+
+		ctx, span := tracer.Start(ctx, call.Method,
+			trace.WithSpanKind(trace.SpanKindClient),
+			trace.WithAttributes(RPCSystemKey.String("jsonrpc"), method, JSONRPCVersionKey.String("2.0")))
+		defer span.End()
+
+		cfg.propagator.Inject(ctx, propagation.HeaderCarrier(call.Header))
+
+		start := time.Now()
+		err := next(ctx, call)
+		elapsed := time.Since(start).Seconds()
+
+		attrs := []attribute.KeyValue{method}
+		if err != nil {
+			var apiErr *zabbix.APIError
+			if errors.As(err, &apiErr) {
+				span.SetAttributes(JSONRPCErrorCodeKey.Int(apiErr.Code), JSONRPCErrorMessageKey.String(apiErr.Message))
+				attrs = append(attrs, ErrorTypeKey.String(strconv.Itoa(apiErr.Code)))
+			} else {
+				attrs = append(attrs, ErrorTypeKey.String("transport"))
+			}
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+
+		set := metric.WithAttributes(attrs...)
+		requests.Add(ctx, 1, set)
+		duration.Record(ctx, elapsed, set)
+		if err != nil {
+			failures.Add(ctx, 1, set)
+		}
+		return err
+	}
+}
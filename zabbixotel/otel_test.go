@@ -0,0 +1,171 @@
+package zabbixotel_test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	zabbix "github.com/nimok/nim-go-zabbix"
+	"github.com/nimok/nim-go-zabbix/zabbixotel"
+	"github.com/nimok/nim-go-zabbix/zabbixtest"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+// headerTransport records the traceparent header of each request.
+type headerTransport struct {
+	traceparents []string
+}
+
+func (h *headerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	h.traceparents = append(h.traceparents, req.Header.Get("traceparent"))
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+func TestInterceptor(t *testing.T) {
+	ctx := context.Background()
+	srv := zabbixtest.NewServer()
+	defer srv.Close()
+
+	spans := tracetest.NewSpanRecorder()
+	reader := sdkmetric.NewManualReader()
+	transport := &headerTransport{}
+
+	client, err := zabbix.NewClient(srv.URL,
+		zabbix.WithUserPass(zabbixtest.DefaultUsername, zabbixtest.DefaultPassword),
+		zabbix.WithHTTPClient(&http.Client{Transport: transport}),
+		zabbix.WithInterceptors(zabbixotel.Interceptor(
+			zabbixotel.WithTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(spans))),
+			zabbixotel.WithMeterProvider(sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))),
+			zabbixotel.WithPropagator(propagation.TraceContext{}),
+		)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := client.Authenticate(); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := client.HostgroupGet(ctx, zabbix.HostGroupGetParameters{}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.HostDelete(ctx, []string{"999999"}); err == nil {
+		t.Fatal("want an error")
+	}
+
+	ended := spans.Ended()
+	if len(ended) != 3 {
+		t.Fatalf("got %d spans, want 3", len(ended))
+	}
+	for i, name := range []string{"user.login", "hostgroup.get", "host.delete"} {
+		if ended[i].Name() != name {
+			t.Errorf("span %d is %q, want %q", i, ended[i].Name(), name)
+		}
+		if got := ended[i].SpanContext().TraceID().String(); len(transport.traceparents[i]) < 35 || transport.traceparents[i][3:35] != got {
+			t.Errorf("request %d has traceparent %q, want trace %s", i, transport.traceparents[i], got)
+		}
+	}
+
+	failed := ended[2]
+	if failed.Status().Code != codes.Error || !hasAttribute(failed.Attributes(), zabbixotel.JSONRPCErrorCodeKey.Int(zabbixtest.CodeInvalidParams)) {
+		t.Errorf("unexpected failed span: status %v, attributes %v", failed.Status(), failed.Attributes())
+	}
+	if !hasAttribute(ended[1].Attributes(), zabbixotel.RPCMethodKey.String("hostgroup.get")) {
+		t.Errorf("unexpected attributes %v", ended[1].Attributes())
+	}
+
+	var metrics metricdata.ResourceMetrics
+	if err := reader.Collect(ctx, &metrics); err != nil {
+		t.Fatal(err)
+	}
+
+	counts := make(map[string]int64)
+	for _, scope := range metrics.ScopeMetrics {
+		for _, m := range scope.Metrics {
+			switch data := m.Data.(type) {
+			case metricdata.Sum[int64]:
+				for _, point := range data.DataPoints {
+					counts[m.Name] += point.Value
+				}
+			case metricdata.Histogram[float64]:
+				for _, point := range data.DataPoints {
+					counts[m.Name] += int64(point.Count)
+				}
+			}
+		}
+	}
+	if counts["zabbix.client.requests"] != 3 || counts["zabbix.client.duration"] != 3 || counts["zabbix.client.errors"] != 1 {
+		t.Errorf("unexpected metric counts %v", counts)
+	}
+}
+
+func hasAttribute(attrs []attribute.KeyValue, want attribute.KeyValue) bool {
+	for _, attr := range attrs {
+		if attr == want {
+			return true
+		}
+	}
+	return false
+}
+
+// BenchmarkHostGet50k measures HostGet calls of 50000 hosts through the
+// interceptor, with the hosts of the BenchmarkHostGet50k of the zabbix
+// package.
+func BenchmarkHostGet50k(b *testing.B) {
+	var body bytes.Buffer
+	body.WriteString(`{"jsonrpc":"2.0","id":1,"result":[`)
+	for i := 0; i < 50000; i++ {
+		if i > 0 {
+			body.WriteByte(',')
+		}
+		fmt.Fprintf(&body, `{"hostid":"%[1]d","host":"host-%[1]d","name":"Host %[1]d","description":"","status":"0",
+			"flags":"0","inventory_mode":"1","ipmi_authtype":"-1","ipmi_privilege":"2","maintenance_status":"0",
+			"maintenance_type":"0","maintenance_from":"0","monitored_by":"1","proxyid":"7","proxy_groupid":"0",
+			"tls_connect":"1","tls_accept":"1","active_available":"1","assigned_proxyid":"0",
+			"interfaces":[{"interfaceid":"%[1]d","hostid":"%[1]d","type":"1","ip":"10.0.0.1","dns":"","port":"10050",
+				"useip":"1","main":"1","available":"1","error":"","errors_from":"0","disable_until":"0","details":{}},
+				{"interfaceid":"%[1]d1","hostid":"%[1]d","type":"2","ip":"10.0.0.1","dns":"","port":"161","useip":"1",
+				"main":"1","available":"1","error":"","errors_from":"0","disable_until":"0",
+				"details":{"version":"2","bulk":"1","community":"{$SNMP_COMMUNITY}","max_repetitions":"10"}}],
+			"groups":[{"groupid":"2","name":"Linux servers","flags":"0","uuid":"dc579cd7a1a34222933f24f52a68bcd8"}],
+			"tags":[{"tag":"env","value":"prod"},{"tag":"team","value":"platform"}],
+			"macros":[{"macro":"{$SNMP_COMMUNITY}","value":"public","description":""}],
+			"inventory":{"macaddress_a":"00:11:22:33:44:55","macaddress_b":""}}`, i+10000)
+	}
+	body.WriteString(`]}`)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write(body.Bytes())
+	}))
+	defer server.Close()
+
+	client, err := zabbix.NewClient(server.URL, zabbix.WithAPIToken("token"),
+		zabbix.WithInterceptors(zabbixotel.Interceptor(
+			zabbixotel.WithTracerProvider(sdktrace.NewTracerProvider()),
+			zabbixotel.WithMeterProvider(sdkmetric.NewMeterProvider(sdkmetric.WithReader(sdkmetric.NewManualReader()))),
+		)))
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.SetBytes(int64(body.Len()))
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		hosts, err := client.HostGet(context.Background(), zabbix.HostGetParameters{})
+		if err != nil {
+			b.Fatal(err)
+		}
+		if len(hosts) != 50000 {
+			b.Fatalf("expected 50000 hosts, got %d", len(hosts))
+		}
+	}
+}
@@ -0,0 +1,252 @@
+// Package zabbixprom exposes Prometheus metrics about a zabbix.Client.
+//
+// A Collector is fed by an interceptor and hooks set on the client, and
+// registered like any other collector:
+//
+//	metrics := zabbixprom.NewCollector()
+//	prometheus.MustRegister(metrics)
+//
+//	client, err := zabbix.NewClient(url, zabbix.WithUserPass(user, password),
+//		zabbix.WithInterceptors(metrics.Interceptor()),
+//		zabbix.WithHooks(metrics.Hooks()))
+//
+// The metrics, all prefixed with the namespace ("zabbix" by default), are:
+//
+//   - client_requests_total{method,outcome}: API calls, where outcome is
+//     "success", "api_error" or "transport_error";
+//   - client_request_duration_seconds{method}: duration of API calls;
+//   - client_limit_wait_seconds{method}: time waited for the rate and
+//     concurrency limits of the client;
+//   - client_logins_total{outcome}: user.login calls, including the ones of
+//     the token refresher;
+//   - client_retries_total{method}: retries of API calls reported with
+//     Retried;
+//   - client_token_refreshes_total{outcome}: refreshes of StartTokenRefresher,
+//     where outcome is "success" or "failure";
+//   - cache_hits_total, cache_misses_total and cache_hit_ratio: statistics of
+//     the zabbixcache.Client given to WatchCache, if any.
+//
+// The client never retries a failed call, so every attempt is one
+// client_requests_total. Callers that retry report it with Retried:
+//
+//	hosts, err := client.HostGet(ctx, params)
+//	for attempt := 1; err != nil && attempt < 3; attempt++ {
+//		metrics.Retried("host.get")
+//		hosts, err = client.HostGet(ctx, params)
+//	}
+package zabbixprom
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	zabbix "github.com/nimok/nim-go-zabbix"
+	"github.com/nimok/nim-go-zabbix/zabbixcache"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Outcomes of API calls and token refreshes.
+const (
+	OutcomeSuccess        = "success"
+	OutcomeAPIError       = "api_error"
+	OutcomeTransportError = "transport_error"
+	OutcomeFailure        = "failure"
+)
+
+// Collector is a prometheus.Collector of the metrics of a client.
+type Collector struct {
+	requests      *prometheus.CounterVec
+	duration      *prometheus.HistogramVec
+	limitWait     *prometheus.HistogramVec
+	logins        *prometheus.CounterVec
+	retries       *prometheus.CounterVec
+	refreshes     *prometheus.CounterVec
+	cacheHits     prometheus.CounterFunc
+	cacheMisses   prometheus.CounterFunc
+	cacheHitRatio prometheus.GaugeFunc
+
+	mu    sync.Mutex
+	cache *zabbixcache.Client
+}
+
+var _ prometheus.Collector = (*Collector)(nil)
+
+type config struct {
+	namespace   string
+	constLabels prometheus.Labels
+	buckets     []float64
+}
+
+// Option configures a Collector.
+type Option func(*config)
+
+// WithNamespace sets the prefix of the metric names. The default is
+// "zabbix".
+func WithNamespace(namespace string) Option {
+	return func(c *config) {
+		c.namespace = namespace
+	}
+}
+
+// WithConstLabels adds labels with fixed values to all metrics, e.g. to tell
+// apart the clients of several Zabbix servers.
+func WithConstLabels(labels prometheus.Labels) Option {
+	return func(c *config) {
+		c.constLabels = labels
+	}
+}
+
+// WithBuckets sets the buckets of the duration histograms. The default is
+// prometheus.DefBuckets.
+func WithBuckets(buckets []float64) Option {
+	return func(c *config) {
+		c.buckets = buckets
+	}
+}
+
+// NewCollector returns a Collector. It must be registered to be exported.
+func NewCollector(opts ...Option) *Collector {
+	cfg := config{namespace: "zabbix", buckets: prometheus.DefBuckets}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	c := &Collector{}
+	c.requests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: cfg.namespace, Subsystem: "client", Name: "requests_total", ConstLabels: cfg.constLabels,
+		Help: "Number of Zabbix API calls by method and outcome.",
+	}, []string{"method", "outcome"})
+	c.duration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: cfg.namespace, Subsystem: "client", Name: "request_duration_seconds", ConstLabels: cfg.constLabels,
+		Help:    "Duration of Zabbix API calls by method.",
+		Buckets: cfg.buckets,
+	}, []string{"method"})
+	c.limitWait = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: cfg.namespace, Subsystem: "client", Name: "limit_wait_seconds", ConstLabels: cfg.constLabels,
+		Help:    "Time Zabbix API calls waited for the rate and concurrency limits of the client.",
+		Buckets: cfg.buckets,
+	}, []string{"method"})
+	c.logins = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: cfg.namespace, Subsystem: "client", Name: "logins_total", ConstLabels: cfg.constLabels,
+		Help: "Number of Zabbix logins by outcome.",
+	}, []string{"outcome"})
+	c.retries = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: cfg.namespace, Subsystem: "client", Name: "retries_total", ConstLabels: cfg.constLabels,
+		Help: "Number of retries of Zabbix API calls by method, as reported by the callers.",
+	}, []string{"method"})
+	c.refreshes = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: cfg.namespace, Subsystem: "client", Name: "token_refreshes_total", ConstLabels: cfg.constLabels,
+		Help: "Number of bearer token refreshes by outcome.",
+	}, []string{"outcome"})
+	c.cacheHits = prometheus.NewCounterFunc(prometheus.CounterOpts{
+		Namespace: cfg.namespace, Subsystem: "cache", Name: "hits_total", ConstLabels: cfg.constLabels,
+		Help: "Number of get calls answered from the cache.",
+	}, func() float64 { return float64(c.cacheStats().Hits) })
+	c.cacheMisses = prometheus.NewCounterFunc(prometheus.CounterOpts{
+		Namespace: cfg.namespace, Subsystem: "cache", Name: "misses_total", ConstLabels: cfg.constLabels,
+		Help: "Number of get calls not answered from the cache.",
+	}, func() float64 { return float64(c.cacheStats().Misses) })
+	c.cacheHitRatio = prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+		Namespace: cfg.namespace, Subsystem: "cache", Name: "hit_ratio", ConstLabels: cfg.constLabels,
+		Help: "Ratio of get calls answered from the cache since it was created.",
+	}, func() float64 {
+		stats := c.cacheStats()
+		if stats.Hits+stats.Misses == 0 {
+			return 0
+		}
+		return float64(stats.Hits) / float64(stats.Hits+stats.Misses)
+	})
+	return c
+}
+
+// WatchCache exports the statistics of cache.
+func (c *Collector) WatchCache(cache *zabbixcache.Client) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.cache = cache
+}
+
+func (c *Collector) cacheStats() zabbixcache.Stats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.cache == nil {
+		return zabbixcache.Stats{}
+	}
+	return c.cache.Stats()
+}
+
+func (c *Collector) collectors() []prometheus.Collector {
+	collectors := []prometheus.Collector{c.requests, c.duration, c.limitWait, c.logins, c.retries, c.refreshes}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.cache != nil {
+		collectors = append(collectors, c.cacheHits, c.cacheMisses, c.cacheHitRatio)
+	}
+	return collectors
+}
+
+func (c *Collector) Describe(ch chan<- *prometheus.Desc) {
+	for _, collector := range []prometheus.Collector{c.requests, c.duration, c.limitWait, c.logins, c.retries, c.refreshes, c.cacheHits, c.cacheMisses, c.cacheHitRatio} {
+		collector.Describe(ch)
+	}
+}
+
+func (c *Collector) Collect(ch chan<- prometheus.Metric) {
+	for _, collector := range c.collectors() {
+		collector.Collect(ch)
+	}
+}
+
+// Interceptor returns the interceptor counting and timing the API calls of a
+// client.
+func (c *Collector) Interceptor() zabbix.Interceptor {
+	return func(ctx context.Context, call *zabbix.Call, next zabbix.Invoker) error {
+		start := time.Now()
+		err := next(ctx, call)
+		c.duration.WithLabelValues(call.Method).Observe(time.Since(start).Seconds())
+
+		var apiErr *zabbix.APIError
+		outcome := OutcomeSuccess
+		switch {
+		case errors.As(err, &apiErr):
+			outcome = OutcomeAPIError
+		case err != nil:
+			outcome = OutcomeTransportError
+		}
+		c.requests.WithLabelValues(call.Method, outcome).Inc()
+
+		if call.Method == "user.login" {
+			c.logins.WithLabelValues(outcome).Inc()
+		}
+		return err
+	}
+}
+
+// Retried counts a retry of a call of method, e.g. "host.get", made by the
+// caller after the call failed.
+func (c *Collector) Retried(method string) {
+	c.retries.WithLabelValues(method).Inc()
+}
+
+// Hooks returns the hooks measuring the limit waits and token refreshes of
+// a client.
+func (c *Collector) Hooks() zabbix.Hooks {
+	return zabbix.Hooks{
+		LimitWait: func(ctx context.Context, method string, wait time.Duration) {
+			c.limitWait.WithLabelValues(method).Observe(wait.Seconds())
+		},
+		TokenRefresh: func(err error) {
+			if err != nil {
+				c.refreshes.WithLabelValues(OutcomeFailure).Inc()
+			} else {
+				c.refreshes.WithLabelValues(OutcomeSuccess).Inc()
+			}
+		},
+	}
+}
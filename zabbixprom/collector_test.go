@@ -0,0 +1,151 @@
+package zabbixprom_test
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	zabbix "github.com/nimok/nim-go-zabbix"
+	"github.com/nimok/nim-go-zabbix/zabbixcache"
+	"github.com/nimok/nim-go-zabbix/zabbixprom"
+	"github.com/nimok/nim-go-zabbix/zabbixtest"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	dto "github.com/prometheus/client_model/go"
+)
+
+func TestCollector(t *testing.T) {
+	ctx := context.Background()
+	srv := zabbixtest.NewServer()
+	defer srv.Close()
+
+	metrics := zabbixprom.NewCollector()
+	registry := prometheus.NewPedanticRegistry()
+	registry.MustRegister(metrics)
+
+	client, err := zabbix.NewClient(srv.URL,
+		zabbix.WithUserPass(zabbixtest.DefaultUsername, zabbixtest.DefaultPassword),
+		zabbix.WithMaxConcurrentRequests(4),
+		zabbix.WithInterceptors(metrics.Interceptor()),
+		zabbix.WithHooks(metrics.Hooks()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := client.Authenticate(); err != nil {
+		t.Fatal(err)
+	}
+
+	cached := zabbixcache.New(client)
+	metrics.WatchCache(cached)
+
+	for range 3 {
+		if _, err := cached.HostgroupGet(ctx, zabbix.HostGroupGetParameters{}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := cached.HostDelete(ctx, []string{"999999"}); err == nil {
+		t.Fatal("want an error")
+	}
+
+	if err := client.StartTokenRefresher(10 * time.Millisecond); err != nil {
+		t.Fatal(err)
+	}
+	deadline := time.Now().Add(5 * time.Second)
+	for value(t, registry, "zabbix_client_token_refreshes_total", "outcome", "success") == 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("no token refresh recorded")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	client.StopTokenRefresher()
+
+	expected := `
+# HELP zabbix_cache_hit_ratio Ratio of get calls answered from the cache since it was created.
+# TYPE zabbix_cache_hit_ratio gauge
+zabbix_cache_hit_ratio 0.6666666666666666
+# HELP zabbix_cache_hits_total Number of get calls answered from the cache.
+# TYPE zabbix_cache_hits_total counter
+zabbix_cache_hits_total 2
+# HELP zabbix_cache_misses_total Number of get calls not answered from the cache.
+# TYPE zabbix_cache_misses_total counter
+zabbix_cache_misses_total 1
+`
+	if err := testutil.GatherAndCompare(registry, strings.NewReader(expected),
+		"zabbix_cache_hit_ratio", "zabbix_cache_hits_total", "zabbix_cache_misses_total"); err != nil {
+		t.Error(err)
+	}
+
+	for _, want := range []struct {
+		name   string
+		labels []string
+		value  float64
+	}{
+		{"zabbix_client_requests_total", []string{"method", "hostgroup.get", "outcome", "success"}, 1},
+		{"zabbix_client_requests_total", []string{"method", "host.delete", "outcome", "api_error"}, 1},
+		{"zabbix_client_request_duration_seconds", []string{"method", "hostgroup.get"}, 1},
+		{"zabbix_client_limit_wait_seconds", []string{"method", "hostgroup.get"}, 1},
+	} {
+		if got := value(t, registry, want.name, want.labels...); got != want.value {
+			t.Errorf("%s%v is %v, want %v", want.name, want.labels, got, want.value)
+		}
+	}
+
+	// The first login and at least one refresh
+	if logins := value(t, registry, "zabbix_client_logins_total", "outcome", "success"); logins < 2 {
+		t.Errorf("got %v logins", logins)
+	}
+}
+
+func TestCollectorRetried(t *testing.T) {
+	metrics := zabbixprom.NewCollector()
+	registry := prometheus.NewPedanticRegistry()
+	registry.MustRegister(metrics)
+
+	metrics.Retried("host.get")
+	metrics.Retried("host.get")
+	metrics.Retried("item.get")
+
+	if got := value(t, registry, "zabbix_client_retries_total", "method", "host.get"); got != 2 {
+		t.Errorf("got %v retries of host.get, want 2", got)
+	}
+	if got := value(t, registry, "zabbix_client_retries_total", "method", "item.get"); got != 1 {
+		t.Errorf("got %v retries of item.get, want 1", got)
+	}
+}
+
+// value returns the value of the counter or gauge, or the sample count of
+// the histogram, with the given name and label pairs.
+func value(t *testing.T, registry *prometheus.Registry, name string, labels ...string) float64 {
+	t.Helper()
+
+	families, err := registry.Gather()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, family := range families {
+		if family.GetName() != name {
+			continue
+		}
+	metrics:
+		for _, m := range family.GetMetric() {
+			for i := 0; i < len(labels); i += 2 {
+				if !slices.ContainsFunc(m.GetLabel(), func(l *dto.LabelPair) bool {
+					return l.GetName() == labels[i] && l.GetValue() == labels[i+1]
+				}) {
+					continue metrics
+				}
+			}
+			switch {
+			case m.Counter != nil:
+				return m.GetCounter().GetValue()
+			case m.Gauge != nil:
+				return m.GetGauge().GetValue()
+			case m.Histogram != nil:
+				return float64(m.GetHistogram().GetSampleCount())
+			}
+		}
+	}
+	return 0
+}
@@ -0,0 +1,335 @@
+// Package zabbixreport builds reports on the state of a Zabbix installation,
+// as text for people, e.g. in a daily email, and as JSON for other systems,
+// e.g. to open tickets.
+//
+// Availability lists the interfaces of monitored hosts that Zabbix can't
+// reach, grouped by the proxy polling them and by error:
+//
+//	report, err := zabbixreport.Availability(ctx, client, zabbixreport.WithMinDuration(time.Hour))
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	report.WriteText(os.Stdout)
+//
+// which prints e.g.:
+//
+//	2 unavailable interfaces on 2 of 120 hosts at 2026-10-19 07:00 UTC
+//
+//	Zabbix server
+//	  Get value from agent failed: cannot connect to [[<address>]:10050]: [111] Connection refused
+//	    web-01  agent  10.0.0.1:10050  76h0m0s  since 2026-10-16 03:00 UTC
+//	    web-02  agent  10.0.0.2:10050  2h15m0s  since 2026-10-19 04:45 UTC
+package zabbixreport
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"slices"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	zabbix "github.com/nimok/nim-go-zabbix"
+)
+
+// AvailabilityReport lists the unavailable interfaces of monitored hosts.
+type AvailabilityReport struct {
+	Time   time.Time           `json:"time"`   // When the report was built
+	Hosts  int                 `json:"hosts"`  // Number of monitored hosts checked
+	Groups []AvailabilityGroup `json:"groups"` // By proxy, the server first, then by error
+}
+
+// AvailabilityGroup are the unavailable interfaces polled by the same proxy
+// and failing with the same error.
+type AvailabilityGroup struct {
+	ProxyID    string                 `json:"proxyid,omitempty"` // Empty for the server
+	Proxy      string                 `json:"proxy"`             // Name of the proxy, or "Zabbix server"
+	Error      string                 `json:"error"`             // Error, with the addresses of the interfaces replaced by <address>
+	Interfaces []UnavailableInterface `json:"interfaces"`        // Unavailable for the longest first
+}
+
+// UnavailableInterface is an interface Zabbix can't reach.
+type UnavailableInterface struct {
+	HostID      string        `json:"hostid"`
+	Host        string        `json:"host"`                  // Technical name of the host
+	Name        string        `json:"name"`                  // Visible name of the host
+	InterfaceID string        `json:"interfaceid,omitempty"` // Empty for active checks
+	Type        string        `json:"type"`                  // agent, snmp, ipmi, jmx, or active for active checks
+	Address     string        `json:"address,omitempty"`     // Address and port polled; empty for active checks
+	Error       string        `json:"error"`                 // Error as reported by Zabbix
+	Since       time.Time     `json:"-"`                     // When the errors started; zero if unknown
+	Duration    time.Duration `json:"-"`                     // Time since Since at the time of the report; 0 if unknown
+}
+
+// MarshalJSON encodes Since as null if unknown, and Duration in seconds.
+func (i UnavailableInterface) MarshalJSON() ([]byte, error) {
+	type unavailableInterface UnavailableInterface
+	aux := struct {
+		unavailableInterface
+		Since    *time.Time `json:"since"`
+		Duration int64      `json:"duration"`
+	}{unavailableInterface: unavailableInterface(i), Duration: int64(i.Duration.Seconds())}
+	if !i.Since.IsZero() {
+		aux.Since = &i.Since
+	}
+	return json.Marshal(aux)
+}
+
+// activeError is the error of hosts whose active checks are unavailable, for
+// which Zabbix reports none.
+const activeError = "Active checks are not available"
+
+// ServerName is the name of the group of the hosts polled by the server.
+const ServerName = "Zabbix server"
+
+// Option configures a report.
+type Option func(*options)
+
+type options struct {
+	groupIDs    []string
+	minDuration time.Duration
+	now         func() time.Time
+}
+
+// WithHostGroups limits the report to the hosts of the given host groups.
+func WithHostGroups(groupIDs ...string) Option {
+	return func(o *options) {
+		o.groupIDs = groupIDs
+	}
+}
+
+// WithMinDuration leaves out the interfaces unavailable for less than d, to
+// ignore short outages. Interfaces unavailable since an unknown time are
+// kept.
+func WithMinDuration(d time.Duration) Option {
+	return func(o *options) {
+		o.minDuration = d
+	}
+}
+
+// WithClock sets the function returning the time of the report, time.Now by
+// default.
+func WithClock(now func() time.Time) Option {
+	return func(o *options) {
+		o.now = now
+	}
+}
+
+// Availability returns the report of the unavailable interfaces of the
+// monitored hosts, including the hosts whose active checks are unavailable.
+func Availability(ctx context.Context, client zabbix.Client, opts ...Option) (*AvailabilityReport, error) {
+	o := options{now: time.Now}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	report := &AvailabilityReport{Time: o.now(), Groups: []AvailabilityGroup{}}
+
+	params := zabbix.HostGetParameters{
+		GetParameters: zabbix.GetParameters{
+			Output: []string{"hostid", "host", "name", "monitored_by", "proxyid", "assigned_proxyid", "active_available"},
+		},
+		GroupIDs:         o.groupIDs,
+		MonitoredHosts:   true,
+		SelectInterfaces: []string{"interfaceid", "type", "useip", "ip", "dns", "port", "available", "error", "errors_from"},
+	}
+
+	groups := make(map[groupKey][]UnavailableInterface)
+	for host, err := range zabbix.HostsAll(ctx, client, params) {
+		if err != nil {
+			return nil, err
+		}
+		report.Hosts++
+
+		var proxyID string
+		switch host.MonitoredBy {
+		case zabbix.MonitoredByProxy:
+			proxyID = host.ProxyID
+		case zabbix.MonitoredByProxyGroup:
+			proxyID = host.AssignedProxyID
+		}
+		if proxyID == "0" {
+			proxyID = ""
+		}
+
+		for _, iface := range host.Interfaces {
+			if iface.Available != zabbix.InterfaceStateUnavailable {
+				continue
+			}
+			unavailable := UnavailableInterface{
+				HostID:      host.HostID,
+				Host:        host.Host,
+				Name:        host.Name,
+				InterfaceID: iface.InterfaceID,
+				Type:        typeName(iface.Type),
+				Address:     address(iface),
+				Error:       iface.Error,
+			}
+			if iface.ErrorsFrom > 0 {
+				unavailable.Since = time.Unix(int64(iface.ErrorsFrom), 0)
+				unavailable.Duration = report.Time.Sub(unavailable.Since)
+			}
+			if unavailable.Duration < o.minDuration && !unavailable.Since.IsZero() {
+				continue
+			}
+			k := groupKey{proxyID, normalize(iface.Error, iface)}
+			groups[k] = append(groups[k], unavailable)
+		}
+
+		if host.ActiveAvailable == zabbix.FlexInt(zabbix.InterfaceStateUnavailable) {
+			k := groupKey{proxyID, activeError}
+			groups[k] = append(groups[k], UnavailableInterface{
+				HostID: host.HostID,
+				Host:   host.Host,
+				Name:   host.Name,
+				Type:   "active",
+				Error:  activeError,
+			})
+		}
+	}
+
+	var proxyIDs []string
+	for k := range groups {
+		if k.proxyID != "" && !slices.Contains(proxyIDs, k.proxyID) {
+			proxyIDs = append(proxyIDs, k.proxyID)
+		}
+	}
+	names, err := proxyNames(ctx, client, proxyIDs)
+	if err != nil {
+		return nil, err
+	}
+	for k, interfaces := range groups {
+		slices.SortFunc(interfaces, func(a, b UnavailableInterface) int {
+			return cmp.Or(-cmp.Compare(a.Duration, b.Duration), cmp.Compare(a.Host, b.Host), cmp.Compare(a.InterfaceID, b.InterfaceID))
+		})
+		name := ServerName
+		if k.proxyID != "" {
+			name = cmp.Or(names[k.proxyID], "proxy "+k.proxyID)
+		}
+		report.Groups = append(report.Groups, AvailabilityGroup{ProxyID: k.proxyID, Proxy: name, Error: k.err, Interfaces: interfaces})
+	}
+	slices.SortFunc(report.Groups, func(a, b AvailabilityGroup) int {
+		return cmp.Or(
+			cmp.Compare(rank(a), rank(b)),
+			cmp.Compare(a.Proxy, b.Proxy),
+			cmp.Compare(a.ProxyID, b.ProxyID),
+			cmp.Compare(len(b.Interfaces), len(a.Interfaces)),
+			cmp.Compare(a.Error, b.Error),
+		)
+	})
+	return report, nil
+}
+
+// Interfaces returns the number of unavailable interfaces.
+func (r *AvailabilityReport) Interfaces() int {
+	n := 0
+	for _, group := range r.Groups {
+		n += len(group.Interfaces)
+	}
+	return n
+}
+
+// WriteText writes the report as text, e.g. for an email.
+func (r *AvailabilityReport) WriteText(w io.Writer) error {
+	hosts := make(map[string]bool)
+	for _, group := range r.Groups {
+		for _, iface := range group.Interfaces {
+			hosts[iface.HostID] = true
+		}
+	}
+
+	const layout = "2006-01-02 15:04 MST"
+	if _, err := fmt.Fprintf(w, "%d unavailable interfaces on %d of %d hosts at %s\n",
+		r.Interfaces(), len(hosts), r.Hosts, r.Time.Format(layout)); err != nil {
+		return err
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	proxy := ""
+	for i, group := range r.Groups {
+		if i == 0 || group.Proxy != proxy {
+			fmt.Fprintf(tw, "\n%s\n", group.Proxy)
+			proxy = group.Proxy
+		}
+		fmt.Fprintf(tw, "  %s\n", group.Error)
+		for _, iface := range group.Interfaces {
+			fmt.Fprintf(tw, "    %s\t%s\t%s\t", iface.Host, iface.Type, iface.Address)
+			if iface.Since.IsZero() {
+				fmt.Fprintln(tw, "unknown")
+			} else {
+				fmt.Fprintf(tw, "%s\tsince %s\n", iface.Duration.Truncate(time.Minute), iface.Since.In(r.Time.Location()).Format(layout))
+			}
+		}
+	}
+	return tw.Flush()
+}
+
+// rank sorts the group of the server first.
+func rank(g AvailabilityGroup) int {
+	if g.ProxyID == "" {
+		return 0
+	}
+	return 1
+}
+
+// groupKey identifies an AvailabilityGroup.
+type groupKey struct {
+	proxyID string
+	err     string // Normalized error
+}
+
+// proxyNames returns the names of the proxies with ids by ID.
+func proxyNames(ctx context.Context, client zabbix.Client, ids []string) (map[string]string, error) {
+	names := make(map[string]string, len(ids))
+	if len(ids) == 0 {
+		return names, nil
+	}
+
+	proxies, err := client.ProxyGet(ctx, zabbix.ProxyGetParameters{
+		GetParameters: zabbix.GetParameters{Output: []string{"proxyid", "name"}},
+		ProxyIDs:      ids,
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, proxy := range proxies {
+		names[proxy.ProxyID] = proxy.Name
+	}
+	return names, nil
+}
+
+func typeName(t zabbix.InterfaceType) string {
+	switch t {
+	case zabbix.InterfaceTypeAgent:
+		return "agent"
+	case zabbix.InterfaceTypeSNMP:
+		return "snmp"
+	case zabbix.InterfaceTypeIPMI:
+		return "ipmi"
+	case zabbix.InterfaceTypeJMX:
+		return "jmx"
+	}
+	return fmt.Sprint(int(t))
+}
+
+func address(iface zabbix.HostInterface) string {
+	host := iface.DNS
+	if iface.UseIP == zabbix.UseIPOptionIP {
+		host = iface.IP
+	}
+	return net.JoinHostPort(host, iface.Port)
+}
+
+// normalize replaces the addresses of iface in message, so that the same
+// error on different hosts is grouped.
+func normalize(message string, iface zabbix.HostInterface) string {
+	for _, addr := range []string{iface.IP, iface.DNS} {
+		if addr != "" {
+			message = strings.ReplaceAll(message, addr, "<address>")
+		}
+	}
+	return message
+}
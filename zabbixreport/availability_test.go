@@ -0,0 +1,126 @@
+package zabbixreport_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	zabbix "github.com/nimok/nim-go-zabbix"
+	"github.com/nimok/nim-go-zabbix/zabbixreport"
+	"github.com/nimok/nim-go-zabbix/zabbixtest"
+)
+
+const refused = "Get value from agent failed: cannot connect to [[%s]:10050]: [111] Connection refused"
+
+func TestAvailability(t *testing.T) {
+	srv := zabbixtest.NewServer()
+	defer srv.Close()
+
+	client, err := zabbix.NewClient(srv.URL, zabbix.WithUserPass(zabbixtest.DefaultUsername, zabbixtest.DefaultPassword))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := client.Authenticate(); err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+
+	proxyID, err := srv.AddProxy(zabbix.Proxy{Name: "proxy-dc2"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	now := time.Date(2026, 10, 19, 7, 0, 0, 0, time.UTC)
+	for _, h := range []struct {
+		name    string
+		ip      string
+		proxy   bool
+		since   time.Duration // 0 if available
+		message string
+	}{
+		{"web-01", "10.0.0.1", false, 76 * time.Hour, refused},
+		{"web-02", "10.0.0.2", false, 2 * time.Hour, refused},
+		{"web-03", "10.0.0.3", false, 10 * time.Minute, refused},
+		{"web-04", "10.0.0.4", false, 0, ""},
+		{"db-01", "10.0.2.1", true, 5 * time.Hour, "Timeout while connecting to \"%s:10050\"."},
+	} {
+		host := zabbix.Host{
+			Host:   h.name,
+			Groups: []zabbix.HostGroup{{GroupID: "2"}},
+			Interfaces: []zabbix.HostInterface{{
+				Type: zabbix.InterfaceTypeAgent, Main: zabbix.MainInterfaceYes, UseIP: zabbix.UseIPOptionIP, IP: h.ip, Port: "10050",
+			}},
+		}
+		if h.proxy {
+			host.MonitoredBy, host.ProxyID = zabbix.MonitoredByProxy, proxyID
+		}
+		hostID, err := srv.AddHost(host)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if h.since == 0 {
+			continue
+		}
+
+		ifaces, err := client.HostInterfaceGet(ctx, zabbix.HostInterfaceGetParams{HostIDs: []string{hostID}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		message := strings.Replace(h.message, "%s", h.ip, 1)
+		if err := srv.SetInterfaceAvailability(ifaces[0].InterfaceID, zabbix.InterfaceStateUnavailable, message, now.Add(-h.since)); err != nil {
+			t.Fatal(err)
+		}
+		if h.proxy {
+			if err := srv.SetActiveAvailability(hostID, zabbix.InterfaceStateUnavailable); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+
+	report, err := zabbixreport.Availability(ctx, client,
+		zabbixreport.WithMinDuration(time.Hour), zabbixreport.WithClock(func() time.Time { return now }))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if report.Hosts != 6 || report.Interfaces() != 4 || len(report.Groups) != 3 {
+		t.Fatalf("got %d hosts, %d interfaces and %d groups, want 6, 4 and 3", report.Hosts, report.Interfaces(), len(report.Groups))
+	}
+	server := report.Groups[0]
+	if server.Proxy != zabbixreport.ServerName || server.Error != strings.Replace(refused, "%s", "<address>", 1) ||
+		len(server.Interfaces) != 2 || server.Interfaces[0].Host != "web-01" || server.Interfaces[0].Duration != 76*time.Hour ||
+		server.Interfaces[1].Address != "10.0.0.2:10050" {
+		t.Errorf("unexpected server group %+v", server)
+	}
+	for _, group := range report.Groups[1:] {
+		if group.ProxyID != proxyID || group.Proxy != "proxy-dc2" || len(group.Interfaces) != 1 || group.Interfaces[0].Host != "db-01" {
+			t.Errorf("unexpected proxy group %+v", group)
+		}
+	}
+
+	var text bytes.Buffer
+	if err := report.WriteText(&text); err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		"4 unavailable interfaces on 3 of 6 hosts at 2026-10-19 07:00 UTC\n",
+		"\nproxy-dc2\n",
+		"    db-01  active    unknown\n",
+		"    web-01  agent  10.0.0.1:10050  76h0m0s  since 2026-10-16 03:00 UTC\n",
+	} {
+		if !strings.Contains(text.String(), want) {
+			t.Errorf("text report misses %q:\n%s", want, text.String())
+		}
+	}
+
+	data, err := json.Marshal(report)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), `"duration":273600`) || !strings.Contains(string(data), `"since":null`) {
+		t.Errorf("unexpected JSON report %s", data)
+	}
+}
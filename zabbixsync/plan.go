@@ -0,0 +1,399 @@
+package zabbixsync
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+
+	zabbix "github.com/nimok/nim-go-zabbix"
+)
+
+// Action is what a plan does to a host.
+type Action string
+
+const (
+	ActionCreate Action = "create"
+	ActionUpdate Action = "update"
+	ActionDelete Action = "delete"
+)
+
+// Plan is the list of changes that make Zabbix match the desired hosts.
+// Hosts that already match are not part of the plan.
+type Plan struct {
+	Changes []HostChange
+}
+
+// Empty reports whether the plan has no changes.
+func (p *Plan) Empty() bool {
+	return len(p.Changes) == 0
+}
+
+// Count returns the number of changes with the given action.
+func (p *Plan) Count(action Action) int {
+	n := 0
+	for _, c := range p.Changes {
+		if c.Action == action {
+			n++
+		}
+	}
+	return n
+}
+
+// String formats the plan for humans, one host per line followed by its
+// changes.
+func (p *Plan) String() string {
+	var b strings.Builder
+	for _, c := range p.Changes {
+		c.format(&b)
+	}
+	return b.String()
+}
+
+// HostChange is the change planned for one host.
+type HostChange struct {
+	Action  Action
+	Host    string      // Technical name of the host
+	HostID  string      // ID of the existing host; empty for creates
+	Desired zabbix.Host // Desired state; zero for deletes
+
+	Fields          []FieldChange // Changed properties, groups, tags and inventory fields
+	LinkTemplates   []string      // IDs of the templates to link
+	UnlinkTemplates []string      // IDs of the templates to unlink
+	Interfaces      InterfaceChanges
+	Macros          MacroChanges
+}
+
+// FieldChange is a changed property of a host. Lists such as groups and tags
+// are formatted as comma-separated values.
+type FieldChange struct {
+	Field string // API property name, e.g. "status" or "inventory.os"
+	From  string
+	To    string
+}
+
+// InterfaceChanges are the interface changes of a host. Updated interfaces
+// keep their ID, and thus the items bound to them.
+type InterfaceChanges struct {
+	Create []zabbix.HostInterface
+	Update []zabbix.HostInterface
+	Delete []zabbix.HostInterface
+}
+
+// Empty reports whether no interface changes.
+func (c InterfaceChanges) Empty() bool {
+	return len(c.Create)+len(c.Update)+len(c.Delete) == 0
+}
+
+// MacroChanges are the user macro changes of a host.
+type MacroChanges struct {
+	Create []zabbix.Macro
+	Update []zabbix.Macro
+	Delete []zabbix.Macro
+}
+
+// Empty reports whether no macro changes.
+func (c MacroChanges) Empty() bool {
+	return len(c.Create)+len(c.Update)+len(c.Delete) == 0
+}
+
+// hostUpdate reports whether the change needs a host.update call, as
+// opposed to interface changes only.
+func (c HostChange) hostUpdate() bool {
+	return len(c.Fields) > 0 || len(c.LinkTemplates) > 0 || len(c.UnlinkTemplates) > 0 || !c.Macros.Empty()
+}
+
+func (c HostChange) empty() bool {
+	return !c.hostUpdate() && c.Interfaces.Empty()
+}
+
+func (c HostChange) format(b *strings.Builder) {
+	switch c.Action {
+	case ActionCreate:
+		fmt.Fprintf(b, "+ %s\n", c.Host)
+		return
+	case ActionDelete:
+		fmt.Fprintf(b, "- %s (%s)\n", c.Host, c.HostID)
+		return
+	}
+
+	fmt.Fprintf(b, "~ %s (%s)\n", c.Host, c.HostID)
+	for _, f := range c.Fields {
+		fmt.Fprintf(b, "    %s: %q -> %q\n", f.Field, f.From, f.To)
+	}
+	if len(c.LinkTemplates) > 0 {
+		fmt.Fprintf(b, "    templates: link %s\n", strings.Join(c.LinkTemplates, ", "))
+	}
+	if len(c.UnlinkTemplates) > 0 {
+		fmt.Fprintf(b, "    templates: unlink %s\n", strings.Join(c.UnlinkTemplates, ", "))
+	}
+	for _, i := range c.Interfaces.Create {
+		fmt.Fprintf(b, "    interfaces: + %s\n", formatInterface(i))
+	}
+	for _, i := range c.Interfaces.Update {
+		fmt.Fprintf(b, "    interfaces: ~ %s (%s)\n", formatInterface(i), i.InterfaceID)
+	}
+	for _, i := range c.Interfaces.Delete {
+		fmt.Fprintf(b, "    interfaces: - %s (%s)\n", formatInterface(i), i.InterfaceID)
+	}
+	for _, m := range c.Macros.Create {
+		fmt.Fprintf(b, "    macros: + %s\n", m.Macro)
+	}
+	for _, m := range c.Macros.Update {
+		fmt.Fprintf(b, "    macros: ~ %s\n", m.Macro)
+	}
+	for _, m := range c.Macros.Delete {
+		fmt.Fprintf(b, "    macros: - %s\n", m.Macro)
+	}
+}
+
+func formatInterface(i zabbix.HostInterface) string {
+	addr := i.DNS
+	if i.UseIP == zabbix.UseIPOptionIP {
+		addr = i.IP
+	}
+	return fmt.Sprintf("type %d %s:%s", i.Type, addr, i.Port)
+}
+
+// Properties of zabbix.Host that are never compared: read-only ones, and
+// write-only ones that host.get does not return.
+var unmanagedFields = []string{
+	"hostid", "host", "flags", "maintenance_from", "maintenance_status", "maintenance_type",
+	"maintenanceid", "active_available", "assigned_proxyid", "tls_psk", "tls_psk_identity",
+}
+
+// scalarFields returns the struct field indexes and API names of the
+// comparable scalar properties of zabbix.Host.
+func scalarFields() (fields []int, names []string) {
+	t := reflect.TypeFor[zabbix.Host]()
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if slices.Contains(unmanagedFields, name) {
+			continue
+		}
+		switch f.Type.Kind() {
+		case reflect.String, reflect.Int, reflect.Int64:
+		case reflect.Pointer:
+			if f.Type.Elem().Kind() != reflect.Int {
+				continue
+			}
+		default:
+			continue
+		}
+		fields = append(fields, i)
+		names = append(names, name)
+	}
+	return fields, names
+}
+
+// setField copies the scalar property name from src to dst.
+func setField(dst *zabbix.Host, src zabbix.Host, name string) {
+	fields, names := scalarFields()
+	if i := slices.Index(names, name); i >= 0 {
+		reflect.ValueOf(dst).Elem().Field(fields[i]).Set(reflect.ValueOf(src).Field(fields[i]))
+	}
+}
+
+// scalarValue returns the value of a scalar field and whether it is set.
+func scalarValue(v reflect.Value) (string, bool) {
+	switch v.Kind() {
+	case reflect.String:
+		return v.String(), v.String() != ""
+	case reflect.Int, reflect.Int64:
+		return fmt.Sprint(v.Int()), v.Int() != 0
+	case reflect.Pointer:
+		if v.IsNil() {
+			return "", false
+		}
+		return fmt.Sprint(v.Elem().Int()), true
+	}
+	return "", false
+}
+
+// diffHost computes the changes from current to desired. Only the
+// properties set in desired are managed, see Reconciler.
+func diffHost(desired, current zabbix.Host) HostChange {
+	change := HostChange{
+		Action:  ActionUpdate,
+		Host:    desired.Host,
+		HostID:  current.HostID,
+		Desired: desired,
+	}
+
+	d, c := reflect.ValueOf(desired), reflect.ValueOf(current)
+	fields, names := scalarFields()
+	for i, index := range fields {
+		to, ok := scalarValue(d.Field(index))
+		if !ok {
+			continue
+		}
+		if from, _ := scalarValue(c.Field(index)); from != to {
+			change.Fields = append(change.Fields, FieldChange{Field: names[i], From: from, To: to})
+		}
+	}
+
+	if len(desired.Groups) > 0 {
+		from := groupIDs(current.HostGroups)
+		if to := groupIDs(desired.Groups); !sameSet(from, to) {
+			change.Fields = append(change.Fields, FieldChange{Field: "groups", From: join(from), To: join(to)})
+		}
+	}
+
+	if len(desired.Tags) > 0 {
+		from, to := tagStrings(current.Tags), tagStrings(desired.Tags)
+		if !sameSet(from, to) {
+			change.Fields = append(change.Fields, FieldChange{Field: "tags", From: join(from), To: join(to)})
+		}
+	}
+
+	if desired.Inventory != nil {
+		inventory := current.Inventory
+		if inventory == nil {
+			inventory = &zabbix.Inventory{}
+		}
+		for _, field := range zabbix.InventoryFields() {
+			to := desired.Inventory.Get(field)
+			if from := inventory.Get(field); to != "" && from != to {
+				change.Fields = append(change.Fields, FieldChange{Field: "inventory." + string(field), From: from, To: to})
+			}
+		}
+	}
+
+	if len(desired.Templates) > 0 {
+		from, to := templateIDs(current.ParentTemplates), templateIDs(desired.Templates)
+		for _, id := range to {
+			if !slices.Contains(from, id) {
+				change.LinkTemplates = append(change.LinkTemplates, id)
+			}
+		}
+		for _, id := range from {
+			if !slices.Contains(to, id) {
+				change.UnlinkTemplates = append(change.UnlinkTemplates, id)
+			}
+		}
+	}
+
+	if len(desired.Interfaces) > 0 {
+		change.Interfaces = diffInterfaces(desired.Interfaces, current.Interfaces)
+	}
+	if len(desired.Macros) > 0 {
+		change.Macros = diffMacros(desired.Macros, current.Macros)
+	}
+	return change
+}
+
+// diffInterfaces matches the desired interfaces to the current ones, so
+// that as many interfaces as possible are kept or updated in place: first
+// identical interfaces, then interfaces of the same type and main flag,
+// then of the same type. The rest are created or deleted.
+func diffInterfaces(desired, current []zabbix.HostInterface) InterfaceChanges {
+	var changes InterfaceChanges
+
+	remaining := slices.Clone(current)
+	var unmatched []zabbix.HostInterface
+	for _, d := range desired {
+		i := slices.IndexFunc(remaining, func(c zabbix.HostInterface) bool { return sameInterface(d, c) })
+		if i < 0 {
+			unmatched = append(unmatched, d)
+			continue
+		}
+		remaining = slices.Delete(remaining, i, i+1)
+	}
+
+	for _, match := range []func(d, c zabbix.HostInterface) bool{
+		func(d, c zabbix.HostInterface) bool { return d.Type == c.Type && d.Main == c.Main },
+		func(d, c zabbix.HostInterface) bool { return d.Type == c.Type },
+	} {
+		var rest []zabbix.HostInterface
+		for _, d := range unmatched {
+			i := slices.IndexFunc(remaining, func(c zabbix.HostInterface) bool { return match(d, c) })
+			if i < 0 {
+				rest = append(rest, d)
+				continue
+			}
+			d.InterfaceID = remaining[i].InterfaceID
+			d.HostID = remaining[i].HostID
+			changes.Update = append(changes.Update, d)
+			remaining = slices.Delete(remaining, i, i+1)
+		}
+		unmatched = rest
+	}
+
+	changes.Create = unmatched
+	changes.Delete = remaining
+	return changes
+}
+
+func sameInterface(d, c zabbix.HostInterface) bool {
+	if d.Type != c.Type || d.Main != c.Main || d.UseIP != c.UseIP || d.IP != c.IP || d.DNS != c.DNS || d.Port != c.Port {
+		return false
+	}
+	if d.Type != zabbix.InterfaceTypeSNMP {
+		return true
+	}
+
+	// Compare the details that are set, as Zabbix fills in defaults.
+	dd, cd := reflect.ValueOf(d.Details), reflect.ValueOf(c.Details)
+	for i := 0; i < dd.NumField(); i++ {
+		if !dd.Field(i).IsZero() && !dd.Field(i).Equal(cd.Field(i)) {
+			return false
+		}
+	}
+	return true
+}
+
+// diffMacros compares macros by type, value and description. The values of
+// secret macros are not compared, since host.get doesn't return them.
+func diffMacros(desired, current []zabbix.Macro) MacroChanges {
+	var changes MacroChanges
+	for _, d := range desired {
+		i := slices.IndexFunc(current, func(c zabbix.Macro) bool { return c.Macro == d.Macro })
+		switch {
+		case i < 0:
+			changes.Create = append(changes.Create, d)
+		case current[i].Type != d.Type || current[i].Description != d.Description,
+			d.Type != zabbix.MacroTypeSecret && current[i].Value != d.Value:
+			changes.Update = append(changes.Update, d)
+		}
+	}
+	for _, c := range current {
+		if !slices.ContainsFunc(desired, func(d zabbix.Macro) bool { return d.Macro == c.Macro }) {
+			changes.Delete = append(changes.Delete, c)
+		}
+	}
+	return changes
+}
+
+func groupIDs(groups []zabbix.HostGroup) []string {
+	ids := make([]string, 0, len(groups))
+	for _, g := range groups {
+		ids = append(ids, g.GroupID)
+	}
+	return ids
+}
+
+func templateIDs(templates []zabbix.Template) []string {
+	ids := make([]string, 0, len(templates))
+	for _, t := range templates {
+		ids = append(ids, t.TemplateID)
+	}
+	return ids
+}
+
+func tagStrings(tags []zabbix.Tag) []string {
+	s := make([]string, 0, len(tags))
+	for _, t := range tags {
+		s = append(s, t.Tag+"="+t.Value)
+	}
+	return s
+}
+
+func sameSet(a, b []string) bool {
+	a, b = slices.Sorted(slices.Values(a)), slices.Sorted(slices.Values(b))
+	return slices.Equal(slices.Compact(a), slices.Compact(b))
+}
+
+func join(values []string) string {
+	return strings.Join(slices.Sorted(slices.Values(values)), ",")
+}
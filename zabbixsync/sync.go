@@ -0,0 +1,367 @@
+// Package zabbixsync reconciles Zabbix hosts with a desired state.
+//
+// The desired hosts are keyed by technical name (Host.Host). A Reconciler
+// fetches the current hosts, computes a Plan of creates, updates and
+// deletes, and applies it:
+//
+//	r := zabbixsync.NewReconciler(client, zabbixsync.WithPrune("22"))
+//	report, err := r.Sync(ctx, desired)
+//
+// Only what the desired hosts set is managed, so that properties maintained
+// by hand or by other tools are left alone:
+//
+//   - scalar properties left at their zero value, and a nil Status, are not
+//     compared;
+//   - groups, tags, templates, interfaces and macros are managed only if
+//     the desired list is non-empty, and then replace the current ones;
+//   - the values of secret macros are not compared, since Zabbix never
+//     returns them: change their type or description to rewrite them;
+//   - only the non-empty fields of a desired Inventory are compared.
+//
+// Groups and templates may be given by ID or by name. Interfaces are changed
+// through the hostinterface API, so that interfaces updated in place keep
+// their ID and items. Hosts are only deleted with WithPrune.
+package zabbixsync
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	zabbix "github.com/nimok/nim-go-zabbix"
+)
+
+// Reconciler makes Zabbix hosts match a desired state.
+type Reconciler struct {
+	client      zabbix.Client
+	dryRun      bool
+	pruneGroups []string
+}
+
+// Option configures a Reconciler.
+type Option func(*Reconciler)
+
+// WithDryRun makes Apply and Sync report the plan without changing
+// anything.
+func WithDryRun() Option {
+	return func(r *Reconciler) {
+		r.dryRun = true
+	}
+}
+
+// WithPrune deletes the hosts of the given host groups that are not among
+// the desired hosts. Without it no host is deleted.
+func WithPrune(groupIDs ...string) Option {
+	return func(r *Reconciler) {
+		r.pruneGroups = append(r.pruneGroups, groupIDs...)
+	}
+}
+
+// NewReconciler returns a Reconciler using client.
+func NewReconciler(client zabbix.Client, opts ...Option) *Reconciler {
+	r := &Reconciler{client: client}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r
+}
+
+// Report is the outcome of Apply.
+type Report struct {
+	DryRun  bool
+	Plan    *Plan
+	Results []Result // One per change of the plan, in order
+}
+
+// Result is the outcome of one change of the plan.
+type Result struct {
+	Action Action
+	Host   string
+	HostID string // ID of the host, including created ones
+	Err    error  // Nil if the change was applied, or skipped in dry-run mode
+}
+
+// Err returns the errors of all failed changes, or nil.
+func (r *Report) Err() error {
+	var errs []error
+	for _, result := range r.Results {
+		if result.Err != nil {
+			errs = append(errs, fmt.Errorf("%s %s: %w", result.Action, result.Host, result.Err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Sync plans and applies the changes that make Zabbix match desired.
+func (r *Reconciler) Sync(ctx context.Context, desired []zabbix.Host) (*Report, error) {
+	plan, err := r.Plan(ctx, desired)
+	if err != nil {
+		return nil, err
+	}
+	return r.Apply(ctx, plan)
+}
+
+// Plan computes the changes that make Zabbix match desired, without
+// applying them.
+func (r *Reconciler) Plan(ctx context.Context, desired []zabbix.Host) (*Plan, error) {
+	names := make([]string, 0, len(desired))
+	for i, host := range desired {
+		if host.Host == "" {
+			return nil, fmt.Errorf("desired host %d has no technical name", i)
+		}
+		if slices.Contains(names, host.Host) {
+			return nil, fmt.Errorf("desired host %q is listed twice", host.Host)
+		}
+		names = append(names, host.Host)
+	}
+
+	desired, err := r.resolve(ctx, desired)
+	if err != nil {
+		return nil, err
+	}
+
+	current := make(map[string]zabbix.Host)
+	if len(names) > 0 {
+		if err := r.fetch(ctx, zabbix.HostGetParameters{
+			GetParameters: zabbix.GetParameters{Filter: map[string]any{"host": names}},
+		}, current); err != nil {
+			return nil, err
+		}
+	}
+
+	plan := &Plan{}
+	for _, host := range desired {
+		existing, ok := current[host.Host]
+		if !ok {
+			plan.Changes = append(plan.Changes, HostChange{Action: ActionCreate, Host: host.Host, Desired: host})
+			continue
+		}
+		if change := diffHost(host, existing); !change.empty() {
+			plan.Changes = append(plan.Changes, change)
+		}
+	}
+
+	if len(r.pruneGroups) > 0 {
+		managed := make(map[string]zabbix.Host)
+		if err := r.fetch(ctx, zabbix.HostGetParameters{GroupIDs: r.pruneGroups}, managed); err != nil {
+			return nil, err
+		}
+		for _, name := range slices.Sorted(maps.Keys(managed)) {
+			if !slices.Contains(names, name) {
+				host := managed[name]
+				plan.Changes = append(plan.Changes, HostChange{Action: ActionDelete, Host: name, HostID: host.HostID})
+			}
+		}
+	}
+	return plan, nil
+}
+
+// fetch adds the hosts matching params, with everything diffHost compares,
+// to hosts by technical name.
+func (r *Reconciler) fetch(ctx context.Context, params zabbix.HostGetParameters, hosts map[string]zabbix.Host) error {
+	params.Output = zabbix.SelectExtendedOutput
+	params.SelectHostGroups = []string{"groupid", "name"}
+	params.SelectParentTemplates = []string{"templateid", "host"}
+	params.SelectInterfaces = zabbix.SelectExtendedOutput
+	params.SelectMacros = zabbix.SelectExtendedOutput
+	params.SelectTags = zabbix.SelectExtendedOutput
+	params.SelectInventory = zabbix.SelectExtendedOutput
+
+	for host, err := range zabbix.HostsAll(ctx, r.client, params) {
+		if err != nil {
+			return fmt.Errorf("fetching hosts: %w", err)
+		}
+		hosts[host.Host] = host
+	}
+	return nil
+}
+
+// resolve returns desired with the groups and templates given by name
+// replaced by their IDs.
+func (r *Reconciler) resolve(ctx context.Context, desired []zabbix.Host) ([]zabbix.Host, error) {
+	var groupNames, templateNames []string
+	for _, host := range desired {
+		for _, g := range host.Groups {
+			if g.GroupID == "" {
+				groupNames = append(groupNames, g.Name)
+			}
+		}
+		for _, t := range host.Templates {
+			if t.TemplateID == "" {
+				templateNames = append(templateNames, t.Host)
+			}
+		}
+	}
+
+	groupIDs := make(map[string]string)
+	if len(groupNames) > 0 {
+		groups, err := r.client.HostgroupGet(ctx, zabbix.HostGroupGetParameters{
+			GetParameters: zabbix.GetParameters{Filter: map[string]any{"name": groupNames}},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("resolving host groups: %w", err)
+		}
+		for _, g := range groups {
+			groupIDs[g.Name] = g.GroupID
+		}
+	}
+
+	templateIDs := make(map[string]string)
+	if len(templateNames) > 0 {
+		templates, err := r.client.TemplateGet(ctx, zabbix.TemplateGetParameters{
+			GetParameters: zabbix.GetParameters{Filter: map[string]any{"host": templateNames}},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("resolving templates: %w", err)
+		}
+		for _, t := range templates {
+			templateIDs[t.Host] = t.TemplateID
+		}
+	}
+
+	resolved := make([]zabbix.Host, len(desired))
+	for i, host := range desired {
+		host.Groups = slices.Clone(host.Groups)
+		for j, g := range host.Groups {
+			if g.GroupID != "" {
+				continue
+			}
+			id, ok := groupIDs[g.Name]
+			if !ok {
+				return nil, fmt.Errorf("host %q: unknown host group %q", host.Host, g.Name)
+			}
+			host.Groups[j] = zabbix.HostGroup{GroupID: id}
+		}
+
+		host.Templates = slices.Clone(host.Templates)
+		for j, t := range host.Templates {
+			if t.TemplateID != "" {
+				continue
+			}
+			id, ok := templateIDs[t.Host]
+			if !ok {
+				return nil, fmt.Errorf("host %q: unknown template %q", host.Host, t.Host)
+			}
+			host.Templates[j] = zabbix.Template{TemplateID: id}
+		}
+		resolved[i] = host
+	}
+	return resolved, nil
+}
+
+// Apply applies the changes of plan, or only reports them in dry-run mode.
+// A failed change does not stop the others; the returned error joins the
+// errors of all failed changes.
+func (r *Reconciler) Apply(ctx context.Context, plan *Plan) (*Report, error) {
+	report := &Report{DryRun: r.dryRun, Plan: plan}
+
+	var deletes []int
+	for _, change := range plan.Changes {
+		result := Result{Action: change.Action, Host: change.Host, HostID: change.HostID}
+		if !r.dryRun {
+			switch change.Action {
+			case ActionCreate:
+				result.HostID, result.Err = r.create(ctx, change)
+			case ActionUpdate:
+				result.Err = r.update(ctx, change)
+			case ActionDelete:
+				deletes = append(deletes, len(report.Results))
+			}
+		}
+		report.Results = append(report.Results, result)
+	}
+
+	if len(deletes) > 0 {
+		hostIDs := make([]string, 0, len(deletes))
+		for _, i := range deletes {
+			hostIDs = append(hostIDs, report.Results[i].HostID)
+		}
+		if _, err := r.client.HostDelete(ctx, hostIDs); err != nil {
+			for _, i := range deletes {
+				report.Results[i].Err = err
+			}
+		}
+	}
+
+	return report, report.Err()
+}
+
+func (r *Reconciler) create(ctx context.Context, change HostChange) (string, error) {
+	resp, err := r.client.HostCreate(ctx, []zabbix.Host{change.Desired})
+	if err != nil {
+		return "", err
+	}
+	if len(resp.HostIDs) == 0 {
+		return "", errors.New("no host ID returned")
+	}
+	return resp.HostIDs[0], nil
+}
+
+func (r *Reconciler) update(ctx context.Context, change HostChange) error {
+	if change.hostUpdate() {
+		if _, err := r.client.HostUpdate(ctx, hostUpdate(change)); err != nil {
+			return err
+		}
+	}
+
+	// Creates first and deletes last, in a single call, so that the host
+	// always keeps a main interface of each type it uses.
+	for _, iface := range change.Interfaces.Create {
+		iface.HostID = change.HostID
+		if _, err := r.client.HostInterfaceCreate(ctx, iface); err != nil {
+			return fmt.Errorf("creating interface: %w", err)
+		}
+	}
+	for _, iface := range change.Interfaces.Update {
+		if _, err := r.client.HostInterfaceUpdate(ctx, iface); err != nil {
+			return fmt.Errorf("updating interface %s: %w", iface.InterfaceID, err)
+		}
+	}
+	if len(change.Interfaces.Delete) > 0 {
+		ids := make([]string, 0, len(change.Interfaces.Delete))
+		for _, iface := range change.Interfaces.Delete {
+			ids = append(ids, iface.InterfaceID)
+		}
+		if _, err := r.client.HostInterfaceDelete(ctx, ids); err != nil {
+			return fmt.Errorf("deleting interfaces: %w", err)
+		}
+	}
+	return nil
+}
+
+// hostUpdate returns the host.update params of change: the changed
+// properties only, with the desired lists replacing the current ones.
+func hostUpdate(change HostChange) zabbix.Host {
+	d := change.Desired
+	update := zabbix.Host{HostID: change.HostID}
+
+	for _, f := range change.Fields {
+		switch f.Field {
+		case "groups":
+			update.Groups = d.Groups
+		case "tags":
+			update.Tags = d.Tags
+		default:
+			if strings.HasPrefix(f.Field, "inventory.") {
+				update.Inventory = d.Inventory
+			} else {
+				setField(&update, d, f.Field)
+			}
+		}
+	}
+	if update.TlsConnect != 0 || update.TlsAccept != 0 {
+		update.TlsPSKIdentity = d.TlsPSKIdentity
+		update.TlsPSK = d.TlsPSK
+	}
+
+	if len(change.LinkTemplates) > 0 || len(change.UnlinkTemplates) > 0 {
+		update.Templates = d.Templates
+	}
+	if !change.Macros.Empty() {
+		update.Macros = d.Macros
+	}
+	return update
+}
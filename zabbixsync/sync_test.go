@@ -0,0 +1,209 @@
+package zabbixsync_test
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"testing"
+
+	zabbix "github.com/nimok/nim-go-zabbix"
+	"github.com/nimok/nim-go-zabbix/zabbixsync"
+	"github.com/nimok/nim-go-zabbix/zabbixtest"
+)
+
+func agentInterface(ip string) zabbix.HostInterface {
+	return zabbix.HostInterface{
+		Type:  zabbix.InterfaceTypeAgent,
+		Main:  zabbix.MainInterfaceYes,
+		UseIP: zabbix.UseIPOptionIP,
+		IP:    ip,
+		Port:  "10050",
+	}
+}
+
+// setup returns a server with a "Managed" host group holding the hosts
+// web-01 and old, and a client for it.
+func setup(t *testing.T) (*zabbixtest.Server, zabbix.Client, string) {
+	t.Helper()
+
+	srv := zabbixtest.NewServer()
+	t.Cleanup(srv.Close)
+
+	groupID := srv.AddHostGroup("Managed")
+	for _, host := range []zabbix.Host{
+		{
+			Host:       "web-01",
+			Groups:     []zabbix.HostGroup{{GroupID: groupID}},
+			Templates:  []zabbix.Template{{TemplateID: "10001"}},
+			Interfaces: []zabbix.HostInterface{agentInterface("10.0.0.1")},
+			Macros:     []zabbix.Macro{{Macro: "{$A}", Value: "1"}},
+			Tags:       []zabbix.Tag{{Tag: "env", Value: "dev"}},
+		},
+		{
+			Host:   "old",
+			Groups: []zabbix.HostGroup{{GroupID: groupID}},
+		},
+	} {
+		if _, err := srv.AddHost(host); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	client, err := zabbix.NewClient(srv.URL, zabbix.WithUserPass(zabbixtest.DefaultUsername, zabbixtest.DefaultPassword))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := client.Authenticate(); err != nil {
+		t.Fatal(err)
+	}
+	return srv, client, groupID
+}
+
+func desiredHosts() []zabbix.Host {
+	status := 1
+	return []zabbix.Host{
+		{
+			Host:       "web-01",
+			Status:     &status,
+			Groups:     []zabbix.HostGroup{{Name: "Managed"}},
+			Templates:  []zabbix.Template{{Host: "ICMP Ping"}},
+			Interfaces: []zabbix.HostInterface{agentInterface("10.0.0.2")},
+			Macros:     []zabbix.Macro{{Macro: "{$A}", Value: "2"}, {Macro: "{$B}", Value: "x"}},
+			Tags:       []zabbix.Tag{{Tag: "env", Value: "prod"}},
+		},
+		{
+			Host:       "web-02",
+			Groups:     []zabbix.HostGroup{{Name: "Managed"}},
+			Interfaces: []zabbix.HostInterface{agentInterface("10.0.0.3")},
+		},
+	}
+}
+
+func TestPlan(t *testing.T) {
+	_, client, groupID := setup(t)
+
+	plan, err := zabbixsync.NewReconciler(client, zabbixsync.WithPrune(groupID)).Plan(context.Background(), desiredHosts())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got := []int{plan.Count(zabbixsync.ActionCreate), plan.Count(zabbixsync.ActionUpdate), plan.Count(zabbixsync.ActionDelete)}; !slices.Equal(got, []int{1, 1, 1}) {
+		t.Fatalf("got create/update/delete counts %v, want [1 1 1]\n%s", got, plan)
+	}
+
+	update := plan.Changes[0]
+	if update.Action != zabbixsync.ActionUpdate || update.Host != "web-01" {
+		t.Fatalf("unexpected first change: %+v", update)
+	}
+
+	var fields []string
+	for _, f := range update.Fields {
+		fields = append(fields, f.Field)
+	}
+	if !slices.Equal(fields, []string{"status", "tags"}) {
+		t.Errorf("got changed fields %v, want [status tags]", fields)
+	}
+	if !slices.Equal(update.LinkTemplates, []string{"10186"}) || !slices.Equal(update.UnlinkTemplates, []string{"10001"}) {
+		t.Errorf("got link %v and unlink %v, want link [10186] and unlink [10001]", update.LinkTemplates, update.UnlinkTemplates)
+	}
+	if len(update.Interfaces.Update) != 1 || len(update.Interfaces.Create)+len(update.Interfaces.Delete) != 0 {
+		t.Errorf("want the interface to be updated in place: %+v", update.Interfaces)
+	}
+	if len(update.Macros.Create) != 1 || len(update.Macros.Update) != 1 || len(update.Macros.Delete) != 0 {
+		t.Errorf("unexpected macro changes: %+v", update.Macros)
+	}
+
+	if s := plan.String(); !strings.Contains(s, "+ web-02") || !strings.Contains(s, "- old") {
+		t.Errorf("unexpected plan:\n%s", s)
+	}
+}
+
+func TestSyncDryRun(t *testing.T) {
+	srv, client, groupID := setup(t)
+
+	report, err := zabbixsync.NewReconciler(client, zabbixsync.WithPrune(groupID), zabbixsync.WithDryRun()).Sync(context.Background(), desiredHosts())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !report.DryRun || len(report.Results) != 3 {
+		t.Errorf("unexpected report: %+v", report)
+	}
+
+	for _, method := range srv.Calls() {
+		if !strings.HasSuffix(method, ".get") && method != "user.login" {
+			t.Errorf("dry run called %s", method)
+		}
+	}
+}
+
+func TestSync(t *testing.T) {
+	ctx := context.Background()
+	_, client, groupID := setup(t)
+
+	before, err := client.HostInterfaceGet(ctx, zabbix.HostInterfaceGetParams{
+		GetParameters: zabbix.GetParameters{Filter: map[string]any{"ip": "10.0.0.1"}},
+	})
+	if err != nil || len(before) != 1 {
+		t.Fatalf("got %v, %v; want the interface of web-01", before, err)
+	}
+
+	r := zabbixsync.NewReconciler(client, zabbixsync.WithPrune(groupID))
+	report, err := r.Sync(ctx, desiredHosts())
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, result := range report.Results {
+		if result.HostID == "" {
+			t.Errorf("no host ID for %s", result.Host)
+		}
+	}
+
+	plan, err := r.Plan(ctx, desiredHosts())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !plan.Empty() {
+		t.Errorf("want an empty plan after sync, got:\n%s", plan)
+	}
+
+	after, err := client.HostInterfaceGet(ctx, zabbix.HostInterfaceGetParams{
+		GetParameters: zabbix.GetParameters{Filter: map[string]any{"ip": "10.0.0.2"}},
+	})
+	if err != nil || len(after) != 1 || after[0].InterfaceID != before[0].InterfaceID {
+		t.Errorf("got %+v, %v; want interface %s updated in place", after, err, before[0].InterfaceID)
+	}
+}
+
+func TestSyncSecretMacros(t *testing.T) {
+	ctx := context.Background()
+	_, client, _ := setup(t)
+
+	desired := func(macros ...zabbix.Macro) []zabbix.Host {
+		return []zabbix.Host{{Host: "old", Groups: []zabbix.HostGroup{{Name: "Managed"}}, Macros: macros}}
+	}
+	secret := zabbix.Macro{Macro: "{$PASSWORD}", Value: "s3cret", Type: zabbix.MacroTypeSecret}
+	vault := zabbix.Macro{Macro: "{$TOKEN}", Value: "secret/zabbix:token", Type: zabbix.MacroTypeVault}
+
+	r := zabbixsync.NewReconciler(client)
+	if _, err := r.Sync(ctx, desired(secret, vault)); err != nil {
+		t.Fatal(err)
+	}
+
+	// host.get doesn't return the value of the secret macro
+	plan, err := r.Plan(ctx, desired(secret, vault))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !plan.Empty() {
+		t.Errorf("want an empty plan after sync, got:\n%s", plan)
+	}
+
+	// A macro that is no longer secret is updated
+	secret.Type = zabbix.MacroTypeText
+	if plan, err = r.Plan(ctx, desired(secret, vault)); err != nil {
+		t.Fatal(err)
+	}
+	if plan.Count(zabbixsync.ActionUpdate) != 1 || len(plan.Changes[0].Macros.Update) != 1 {
+		t.Errorf("want the macro type to be updated, got:\n%s", plan)
+	}
+}
@@ -0,0 +1,281 @@
+package zabbixtest
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+)
+
+// Size of the dashboard grid in Zabbix 7.0.
+const (
+	dashboardColumns = 72
+	dashboardRows    = 64
+)
+
+func (s *Server) dashboardGet(req *request) (any, *apiError) {
+	var params struct {
+		getOptions
+		DashboardIDs     ids `json:"dashboardids"`
+		SelectPages      any `json:"selectPages"`
+		SelectUsers      any `json:"selectUsers"`
+		SelectUserGroups any `json:"selectUserGroups"`
+	}
+	if err := decodeParams(req.params, &params); err != nil {
+		return nil, err
+	}
+
+	dashboards := s.tables["dashboards"]
+	rows := dashboards.query(params.getOptions, func(dashboard object) bool {
+		return params.DashboardIDs == nil || slices.Contains(params.DashboardIDs, dashboard.str("dashboardid"))
+	})
+
+	return dashboards.result(rows, params.getOptions, func(out, dashboard object) {
+		if params.SelectPages != nil {
+			pages := []object{}
+			for _, page := range s.dashboardPages(dashboard.str("dashboardid")) {
+				p := s.tables["dashboard_pages"].project(page, params.SelectPages)
+				p["widgets"] = s.tables["widgets"].related("extend", s.pageWidgets(page.str("dashboard_pageid")))
+				pages = append(pages, p)
+			}
+			out["pages"] = pages
+		}
+		if params.SelectUsers != nil {
+			out["users"] = listOrEmpty(dashboard["users"])
+		}
+		if params.SelectUserGroups != nil {
+			out["userGroups"] = listOrEmpty(dashboard["userGroups"])
+		}
+	}), nil
+}
+
+func (s *Server) dashboardCreate(req *request) (any, *apiError) {
+	dashboards, err := objects(req.params)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make(map[string]bool)
+	for i, dashboard := range dashboards {
+		for _, param := range []string{"name", "pages"} {
+			if _, ok := dashboard[param]; !ok {
+				return nil, invalidParams(`Invalid parameter "/%d": the parameter "%s" is missing.`, i+1, param)
+			}
+		}
+		name := dashboard.str("name")
+		if names[name] || s.dashboardExists(name, "") {
+			return nil, invalidParams(`Dashboard "%s" already exists.`, name)
+		}
+		names[name] = true
+		if err := s.checkDashboard(i, dashboard); err != nil {
+			return nil, err
+		}
+	}
+
+	dashboardIDs := []string{}
+	for _, dashboard := range dashboards {
+		row := object{
+			"userid":         req.userID,
+			"private":        "1",
+			"display_period": "30",
+			"auto_start":     "1",
+			"users":          []any{},
+			"userGroups":     []any{},
+		}
+		for k, v := range dashboard {
+			if k != "dashboardid" && k != "pages" {
+				row[k] = v
+			}
+		}
+		id := s.tables["dashboards"].insert(row)
+		s.setDashboardPages(id, objectList(dashboard["pages"]))
+		dashboardIDs = append(dashboardIDs, id)
+	}
+	return object{"dashboardids": dashboardIDs}, nil
+}
+
+func (s *Server) dashboardUpdate(req *request) (any, *apiError) {
+	dashboards, err := objects(req.params)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, dashboard := range dashboards {
+		id := dashboard.str("dashboardid")
+		if id == "" {
+			return nil, invalidParams(`Invalid parameter "/%d": the parameter "dashboardid" is missing.`, i+1)
+		}
+		if s.tables["dashboards"].get(id) == nil {
+			return nil, invalidParams(errNoPermissions)
+		}
+		if _, ok := dashboard["name"]; ok {
+			name := dashboard.str("name")
+			if name == "" {
+				return nil, invalidParams(`Invalid parameter "/%d/name": cannot be empty.`, i+1)
+			}
+			if s.dashboardExists(name, id) {
+				return nil, invalidParams(`Dashboard "%s" already exists.`, name)
+			}
+		}
+		if err := s.checkDashboard(i, dashboard); err != nil {
+			return nil, err
+		}
+	}
+
+	dashboardIDs := []string{}
+	for _, dashboard := range dashboards {
+		id := dashboard.str("dashboardid")
+		current := s.tables["dashboards"].get(id)
+		for k, v := range dashboard {
+			if k != "pages" {
+				current[k] = v
+			}
+		}
+		if pages, ok := dashboard["pages"]; ok {
+			s.setDashboardPages(id, objectList(pages))
+		}
+		dashboardIDs = append(dashboardIDs, id)
+	}
+	return object{"dashboardids": dashboardIDs}, nil
+}
+
+func (s *Server) dashboardDelete(req *request) (any, *apiError) {
+	var dashboardIDs ids
+	if err := decodeParams(req.params, &dashboardIDs); err != nil {
+		return nil, err
+	}
+
+	for _, id := range dashboardIDs {
+		if s.tables["dashboards"].get(id) == nil {
+			return nil, invalidParams(errNoPermissions)
+		}
+	}
+
+	for _, id := range dashboardIDs {
+		s.setDashboardPages(id, nil)
+		s.tables["dashboards"].delete(id)
+	}
+	return object{"dashboardids": []string(dashboardIDs)}, nil
+}
+
+func (s *Server) dashboardExists(name, exceptID string) bool {
+	return slices.ContainsFunc(s.tables["dashboards"].rows, func(d object) bool {
+		return d.str("name") == name && d.str("dashboardid") != exceptID
+	})
+}
+
+// checkDashboard checks the pages, widgets and sharing of the i-th dashboard
+// of a create or update request.
+func (s *Server) checkDashboard(i int, dashboard object) *apiError {
+	if pages, ok := dashboard["pages"]; ok && len(objectList(pages)) == 0 {
+		return invalidParams(`Invalid parameter "/%d/pages": cannot be empty.`, i+1)
+	}
+	for p, page := range objectList(dashboard["pages"]) {
+		for w, widget := range objectList(page["widgets"]) {
+			path := fmt.Sprintf("/%d/pages/%d/widgets/%d", i+1, p+1, w+1)
+			if widget.str("type") == "" {
+				return invalidParams(`Invalid parameter "%s": the parameter "type" is missing.`, path)
+			}
+			x, y := intOr(widget, "x", 0), intOr(widget, "y", 0)
+			width, height := intOr(widget, "width", 1), intOr(widget, "height", 2)
+			if width < 1 || x < 0 || x+width > dashboardColumns {
+				return invalidParams(`Invalid parameter "%s": value must be within the range of 1-%d.`, path+"/width", dashboardColumns-x)
+			}
+			if height < 1 || y < 0 || y+height > dashboardRows {
+				return invalidParams(`Invalid parameter "%s": value must be within the range of 1-%d.`, path+"/height", dashboardRows-y)
+			}
+			for _, field := range objectList(widget["fields"]) {
+				if table := fieldTable(field.str("type")); table != "" && s.tables[table].get(field.str("value")) == nil {
+					return invalidParams(errNoPermissions)
+				}
+			}
+		}
+	}
+	for _, u := range objectList(dashboard["users"]) {
+		if !s.userExists(u.str("userid")) {
+			return invalidParams(errNoPermissions)
+		}
+	}
+	return nil
+}
+
+// fieldTable returns the table of the objects referenced by widget fields of
+// the given type, if the server stores them.
+func fieldTable(fieldType string) string {
+	switch fieldType {
+	case "2":
+		return "hostgroups"
+	case "3":
+		return "hosts"
+	case "8":
+		return "maps"
+	case "9":
+		return "services"
+	case "10":
+		return "slas"
+	}
+	return ""
+}
+
+// setDashboardPages replaces the pages of a dashboard, keeping the IDs of
+// its existing pages and widgets that are given again.
+func (s *Server) setDashboardPages(dashboardID string, pages []object) {
+	oldPages := s.dashboardPages(dashboardID)
+	var oldWidgets []object
+	for _, page := range oldPages {
+		oldWidgets = append(oldWidgets, s.pageWidgets(page.str("dashboard_pageid"))...)
+		s.deleteWhere("widgets", "dashboard_pageid", page.str("dashboard_pageid"))
+	}
+	s.deleteWhere("dashboard_pages", "dashboardid", dashboardID)
+
+	for _, page := range pages {
+		row := object{"name": "", "display_period": "0"}
+		for k, v := range page {
+			if k != "widgets" {
+				row[k] = v
+			}
+		}
+		if !containsID(oldPages, "dashboard_pageid", row.str("dashboard_pageid")) {
+			delete(row, "dashboard_pageid")
+		}
+		row["dashboardid"] = dashboardID
+		pageID := s.tables["dashboard_pages"].insert(row)
+
+		for _, widget := range objectList(page["widgets"]) {
+			w := object{"name": "", "x": "0", "y": "0", "width": "1", "height": "2", "view_mode": "0", "fields": []any{}}
+			for k, v := range widget {
+				w[k] = v
+			}
+			if !containsID(oldWidgets, "widgetid", w.str("widgetid")) {
+				delete(w, "widgetid")
+			}
+			w["dashboard_pageid"] = pageID
+			s.tables["widgets"].insert(w)
+		}
+	}
+}
+
+func (s *Server) dashboardPages(dashboardID string) []object {
+	return s.tables["dashboard_pages"].where(func(page object) bool {
+		return page.str("dashboardid") == dashboardID
+	})
+}
+
+func (s *Server) pageWidgets(pageID string) []object {
+	return s.tables["widgets"].where(func(widget object) bool {
+		return widget.str("dashboard_pageid") == pageID
+	})
+}
+
+// containsID reports whether one of rows has the ID id, which is not empty.
+func containsID(rows []object, idField, id string) bool {
+	return id != "" && slices.ContainsFunc(rows, func(row object) bool { return row.str(idField) == id })
+}
+
+// intOr returns the integer property key of o, or def if it is not set.
+func intOr(o object, key string, def int) int {
+	n, err := strconv.Atoi(o.str(key))
+	if err != nil {
+		return def
+	}
+	return n
+}
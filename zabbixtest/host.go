@@ -0,0 +1,509 @@
+package zabbixtest
+
+import (
+	"slices"
+)
+
+var hostDefaults = object{
+	"description":        "",
+	"status":             "0",
+	"flags":              "0",
+	"inventory_mode":     "-1",
+	"ipmi_authtype":      "-1",
+	"ipmi_privilege":     "2",
+	"ipmi_username":      "",
+	"ipmi_password":      "",
+	"maintenanceid":      "0",
+	"maintenance_status": "0",
+	"maintenance_type":   "0",
+	"maintenance_from":   "0",
+	"monitored_by":       "0",
+	"proxyid":            "0",
+	"proxy_groupid":      "0",
+	"tls_connect":        "1",
+	"tls_accept":         "1",
+	"tls_issuer":         "",
+	"tls_subject":        "",
+	"active_available":   "0",
+	"assigned_proxyid":   "0",
+}
+
+// Host properties holding related objects rather than scalar values.
+var hostRelations = []string{"groups", "templates", "tags", "macros", "inventory", "interfaces", "templates_clear"}
+
+func (s *Server) hostGet(req *request) (any, *apiError) {
+	var params struct {
+		getOptions
+		HostIDs               ids            `json:"hostids"`
+		GroupIDs              ids            `json:"groupids"`
+		TemplateIDs           ids            `json:"templateids"`
+		ProxyIDs              ids            `json:"proxyids"`
+		InterfaceIDs          ids            `json:"interfaceids"`
+		MonitoredHosts        bool           `json:"monitored_hosts"`
+		SearchInventory       map[string]any `json:"searchInventory"`
+		SelectGroups          any            `json:"selectGroups"`
+		SelectHostGroups      any            `json:"selectHostGroups"`
+		SelectInterfaces      any            `json:"selectInterfaces"`
+		SelectParentTemplates any            `json:"selectParentTemplates"`
+		SelectTags            any            `json:"selectTags"`
+		SelectMacros          any            `json:"selectMacros"`
+		SelectInventory       any            `json:"selectInventory"`
+	}
+	if err := decodeParams(req.params, &params); err != nil {
+		return nil, err
+	}
+
+	hosts := s.tables["hosts"]
+	rows := hosts.query(params.getOptions, func(host object) bool {
+		if params.HostIDs != nil && !slices.Contains(params.HostIDs, host.str("hostid")) {
+			return false
+		}
+		if params.GroupIDs != nil && !intersects(params.GroupIDs, host["groups"]) {
+			return false
+		}
+		if params.TemplateIDs != nil && !intersects(params.TemplateIDs, host["templates"]) {
+			return false
+		}
+		if params.ProxyIDs != nil && !slices.Contains(params.ProxyIDs, host.str("proxyid")) {
+			return false
+		}
+		if params.InterfaceIDs != nil && !slices.ContainsFunc(s.hostInterfaces(host), func(iface object) bool {
+			return slices.Contains(params.InterfaceIDs, iface.str("interfaceid"))
+		}) {
+			return false
+		}
+		if params.MonitoredHosts && host.str("status") != "0" {
+			return false
+		}
+		if params.SearchInventory != nil {
+			inventory, _ := host["inventory"].(object)
+			opts := getOptions{Search: params.SearchInventory}
+			if inventory == nil || !opts.matchSearch(inventory) {
+				return false
+			}
+		}
+		return true
+	})
+
+	return hosts.result(rows, params.getOptions, func(out, host object) {
+		if params.SelectGroups != nil {
+			out["groups"] = s.tables["hostgroups"].related(params.SelectGroups, s.hostGroups(host))
+		}
+		if params.SelectHostGroups != nil {
+			out["hostgroups"] = s.tables["hostgroups"].related(params.SelectHostGroups, s.hostGroups(host))
+		}
+		if params.SelectInterfaces != nil {
+			out["interfaces"] = s.tables["interfaces"].related(params.SelectInterfaces, s.hostInterfaces(host))
+		}
+		if params.SelectParentTemplates != nil {
+			out["parentTemplates"] = s.tables["templates"].related(params.SelectParentTemplates, s.hostTemplates(host))
+		}
+		if params.SelectTags != nil {
+			out["tags"] = listOrEmpty(host["tags"])
+		}
+		if params.SelectMacros != nil {
+			macros := s.tables["macros"].related(params.SelectMacros, s.hostMacros(host))
+			if list, ok := macros.([]object); ok {
+				for _, macro := range list {
+					if macro.str("type") == macroTypeSecret {
+						delete(macro, "value")
+					}
+				}
+			}
+			out["macros"] = macros
+		}
+		if params.SelectInventory != nil {
+			if inventory, ok := host["inventory"].(object); ok && host.str("inventory_mode") != "-1" {
+				out["inventory"] = (&table{}).project(inventory, params.SelectInventory)
+			} else {
+				out["inventory"] = []any{}
+			}
+		}
+	}), nil
+}
+
+func (s *Server) hostCreate(req *request) (any, *apiError) {
+	hosts, err := objects(req.params)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make(map[string]bool)
+	for i, host := range hosts {
+		if err := s.validateHost(host, nil, i+1); err != nil {
+			return nil, err
+		}
+		if names[host.str("host")] {
+			return nil, invalidParams(`Invalid parameter "/%d": value (host)=(%s) already exists.`, i+1, host.str("host"))
+		}
+		names[host.str("host")] = true
+	}
+
+	hostIDs := []string{}
+	for _, host := range hosts {
+		hostIDs = append(hostIDs, s.insertHost(host))
+	}
+	return object{"hostids": hostIDs}, nil
+}
+
+func (s *Server) hostUpdate(req *request) (any, *apiError) {
+	hosts, err := objects(req.params)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, host := range hosts {
+		if host.str("hostid") == "" {
+			return nil, invalidParams(`Invalid parameter "/%d": the parameter "hostid" is missing.`, i+1)
+		}
+		current := s.tables["hosts"].get(host.str("hostid"))
+		if current == nil {
+			return nil, invalidParams(errNoPermissions)
+		}
+		if err := s.validateHost(host, current, i+1); err != nil {
+			return nil, err
+		}
+	}
+
+	hostIDs := []string{}
+	for _, host := range hosts {
+		current := s.tables["hosts"].get(host.str("hostid"))
+		s.setHostProperties(current, host)
+		hostIDs = append(hostIDs, current.str("hostid"))
+	}
+	return object{"hostids": hostIDs}, nil
+}
+
+func (s *Server) hostDelete(req *request) (any, *apiError) {
+	var hostIDs ids
+	if err := decodeParams(req.params, &hostIDs); err != nil {
+		return nil, err
+	}
+
+	for _, id := range hostIDs {
+		if s.tables["hosts"].get(id) == nil {
+			return nil, invalidParams(errNoPermissions)
+		}
+	}
+
+	for _, id := range hostIDs {
+		s.tables["hosts"].delete(id)
+		s.deleteWhere("interfaces", "hostid", id)
+		s.deleteWhere("macros", "hostid", id)
+		s.deleteWhere("items", "hostid", id)
+	}
+	return object{"hostids": []string(hostIDs)}, nil
+}
+
+func (s *Server) hostMassAdd(req *request) (any, *apiError) {
+	var params struct {
+		Hosts      []object `json:"hosts"`
+		Groups     []object `json:"groups"`
+		Templates  []object `json:"templates"`
+		Macros     []object `json:"macros"`
+		Interfaces []object `json:"interfaces"`
+	}
+	if err := decodeParams(req.params, &params); err != nil {
+		return nil, err
+	}
+	if len(params.Hosts) == 0 {
+		return nil, invalidParams(`Invalid parameter "/hosts": cannot be empty.`)
+	}
+
+	var hosts []object
+	for _, h := range params.Hosts {
+		host := s.tables["hosts"].get(toString(h["hostid"]))
+		if host == nil {
+			return nil, invalidParams(errNoPermissions)
+		}
+		hosts = append(hosts, host)
+	}
+	if err := s.checkRefs("hostgroups", "groupid", params.Groups); err != nil {
+		return nil, err
+	}
+	if err := s.checkRefs("templates", "templateid", params.Templates); err != nil {
+		return nil, err
+	}
+	for _, host := range hosts {
+		for i, macro := range params.Macros {
+			if slices.ContainsFunc(s.hostMacros(host), func(m object) bool { return m.str("macro") == toString(macro["macro"]) }) {
+				return nil, invalidParams(`Invalid parameter "/%d/macros/%d": value (macro)=(%s) already exists.`, 1, i+1, toString(macro["macro"]))
+			}
+		}
+	}
+
+	hostIDs := []string{}
+	for _, host := range hosts {
+		hostID := host.str("hostid")
+		host["groups"] = appendRefs(host["groups"], params.Groups, "groupid")
+		host["templates"] = appendRefs(host["templates"], params.Templates, "templateid")
+		for _, macro := range params.Macros {
+			s.insertMacro(hostID, normalize(map[string]any(macro)).(object))
+		}
+		for _, iface := range params.Interfaces {
+			s.insertInterface(hostID, normalize(map[string]any(iface)).(object))
+		}
+		hostIDs = append(hostIDs, hostID)
+	}
+	return object{"hostids": hostIDs}, nil
+}
+
+// validateHost checks a host passed to host.create (current is nil) or
+// host.update.
+func (s *Server) validateHost(host, current object, index int) *apiError {
+	create := current == nil
+	hosts := s.tables["hosts"]
+
+	name := host.str("host")
+	if create && name == "" {
+		return invalidParams(`Invalid parameter "/%d": the parameter "host" is missing.`, index)
+	}
+	if name != "" {
+		for _, other := range hosts.rows {
+			if other.str("host") == name && (create || other.str("hostid") != current.str("hostid")) {
+				return invalidParams(`Host with the same name "%s" already exists.`, name)
+			}
+		}
+		if slices.ContainsFunc(s.tables["templates"].rows, func(t object) bool { return t.str("host") == name }) {
+			return invalidParams(`Template with the same name "%s" already exists.`, name)
+		}
+	}
+
+	groups, hasGroups := host["groups"]
+	if create && !hasGroups || hasGroups && len(objectList(groups)) == 0 {
+		return invalidParams(`Invalid parameter "/%d": the parameter "groups" is missing.`, index)
+	}
+	if err := s.checkRefs("hostgroups", "groupid", objectList(groups)); err != nil {
+		return err
+	}
+	if err := s.checkRefs("templates", "templateid", objectList(host["templates"])); err != nil {
+		return err
+	}
+
+	merged := object{}
+	if current != nil {
+		merged = current.clone()
+	}
+	for k, v := range host {
+		merged[k] = v
+	}
+	if create {
+		name = host.str("host")
+	} else {
+		name = merged.str("host")
+	}
+
+	switch merged.str("monitored_by") {
+	case "1":
+		proxyID := merged.str("proxyid")
+		if proxyID == "" || proxyID == "0" {
+			return invalidParams(`Invalid parameter "/%d": the parameter "proxyid" is missing.`, index)
+		}
+		if s.tables["proxies"].get(proxyID) == nil {
+			return invalidParams(errNoPermissions)
+		}
+	case "2":
+		if groupID := merged.str("proxy_groupid"); groupID == "" || groupID == "0" {
+			return invalidParams(`Invalid parameter "/%d": the parameter "proxy_groupid" is missing.`, index)
+		}
+	}
+
+	if usesPSK(merged) {
+		for _, field := range []string{"tls_psk_identity", "tls_psk"} {
+			if merged.str(field) == "" {
+				return invalidParams(`Invalid parameter "/%d/%s": cannot be empty.`, index, field)
+			}
+		}
+	}
+
+	if interfaces, ok := host["interfaces"]; ok {
+		if err := validateInterfaces(objectList(interfaces), name, index); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// insertHost stores a validated host with its related objects.
+func (s *Server) insertHost(host object) string {
+	row := hostDefaults.clone()
+	row["groups"] = []any{}
+	row["templates"] = []any{}
+	row["tags"] = []any{}
+	row["host"] = host.str("host")
+	if id := host.str("hostid"); id != "" {
+		row["hostid"] = id
+	}
+
+	hostID := s.tables["hosts"].insert(row)
+	s.setHostProperties(row, host)
+	if row.str("name") == "" {
+		row["name"] = row.str("host")
+	}
+	return hostID
+}
+
+// setHostProperties applies the properties of a host.create or host.update
+// request to row.
+func (s *Server) setHostProperties(row, host object) {
+	hostID := row.str("hostid")
+
+	for k, v := range host {
+		if !slices.Contains(hostRelations, k) && k != "hostid" {
+			row[k] = v
+		}
+	}
+
+	if groups, ok := host["groups"]; ok {
+		row["groups"] = appendRefs(nil, objectList(groups), "groupid")
+	}
+	if templates, ok := host["templates"]; ok {
+		row["templates"] = appendRefs(nil, objectList(templates), "templateid")
+	}
+	if templates, ok := host["templates_clear"]; ok {
+		row["templates"] = removeRefs(row["templates"], objectList(templates), "templateid")
+	}
+	if tags, ok := host["tags"]; ok {
+		row["tags"] = listOrEmpty(tags)
+	}
+	if inventory, ok := host["inventory"].(object); ok {
+		merged, _ := row["inventory"].(object)
+		if merged == nil {
+			merged = object{}
+		}
+		for k, v := range inventory {
+			merged[k] = v
+		}
+		row["inventory"] = merged
+	}
+	if macros, ok := host["macros"]; ok {
+		s.deleteWhere("macros", "hostid", hostID)
+		for _, macro := range objectList(macros) {
+			s.insertMacro(hostID, macro)
+		}
+	}
+	if interfaces, ok := host["interfaces"]; ok {
+		s.deleteWhere("interfaces", "hostid", hostID)
+		for _, iface := range objectList(interfaces) {
+			s.insertInterface(hostID, iface)
+		}
+	}
+}
+
+// The values of secret macros are never returned.
+const macroTypeSecret = "1"
+
+func (s *Server) insertMacro(hostID string, macro object) {
+	row := object{"description": "", "type": "0", "automatic": "0"}
+	for k, v := range macro {
+		row[k] = v
+	}
+	delete(row, "hostmacroid")
+	row["hostid"] = hostID
+	s.tables["macros"].insert(row)
+}
+
+func (s *Server) hostGroups(host object) []object {
+	return s.refs("hostgroups", host["groups"], "groupid")
+}
+
+func (s *Server) hostTemplates(host object) []object {
+	return s.refs("templates", host["templates"], "templateid")
+}
+
+func (s *Server) hostInterfaces(host object) []object {
+	return s.tables["interfaces"].where(func(iface object) bool {
+		return iface.str("hostid") == host.str("hostid")
+	})
+}
+
+func (s *Server) hostMacros(host object) []object {
+	return s.tables["macros"].where(func(macro object) bool {
+		return macro.str("hostid") == host.str("hostid")
+	})
+}
+
+// refs resolves a list of references such as [{"groupid": "2"}] to the
+// referenced rows.
+func (s *Server) refs(tableName string, list any, idField string) []object {
+	var rows []object
+	for _, ref := range objectList(list) {
+		if row := s.tables[tableName].get(ref.str(idField)); row != nil {
+			rows = append(rows, row)
+		}
+	}
+	return rows
+}
+
+// checkRefs fails with the usual permission error if a reference does not
+// exist.
+func (s *Server) checkRefs(tableName, idField string, refs []object) *apiError {
+	for _, ref := range refs {
+		if s.tables[tableName].get(toString(ref[idField])) == nil {
+			return invalidParams(errNoPermissions)
+		}
+	}
+	return nil
+}
+
+func (s *Server) deleteWhere(tableName, field, value string) {
+	t := s.tables[tableName]
+	t.rows = slices.DeleteFunc(t.rows, func(row object) bool {
+		return row.str(field) == value
+	})
+}
+
+// appendRefs adds the references of refs missing from list.
+func appendRefs(list any, refs []object, idField string) []any {
+	out := listOrEmpty(list)
+	for _, ref := range refs {
+		id := toString(ref[idField])
+		if !intersects([]string{id}, out) {
+			out = append(out, object{idField: id})
+		}
+	}
+	return out
+}
+
+func removeRefs(list any, refs []object, idField string) []any {
+	out := []any{}
+	for _, ref := range objectList(list) {
+		if !slices.ContainsFunc(refs, func(r object) bool { return toString(r[idField]) == ref.str(idField) }) {
+			out = append(out, ref)
+		}
+	}
+	return out
+}
+
+// intersects reports whether a list of references contains any of ids.
+func intersects(ids []string, list any) bool {
+	for _, ref := range objectList(list) {
+		for _, v := range ref {
+			if slices.Contains(ids, toString(v)) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func listOrEmpty(v any) []any {
+	l, _ := v.([]any)
+	return append([]any{}, l...)
+}
+
+func toString(v any) string {
+	if s, ok := normalize(v).(string); ok {
+		return s
+	}
+	return ""
+}
+
+// usesPSK reports whether PSK encryption is enabled in either direction.
+func usesPSK(o object) bool {
+	accept := 0
+	for _, c := range o.str("tls_accept") {
+		accept = accept*10 + int(c-'0')
+	}
+	return o.str("tls_connect") == "2" || accept&2 != 0
+}
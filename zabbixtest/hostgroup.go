@@ -0,0 +1,114 @@
+package zabbixtest
+
+import (
+	"slices"
+)
+
+func (s *Server) hostgroupGet(req *request) (any, *apiError) {
+	var params struct {
+		getOptions
+		GroupIDs           ids  `json:"groupids"`
+		HostIDs            ids  `json:"hostids"`
+		WithHosts          bool `json:"with_hosts"`
+		WithMonitoredHosts bool `json:"with_monitored_hosts"`
+		SelectHosts        any  `json:"selectHosts"`
+	}
+	if err := decodeParams(req.params, &params); err != nil {
+		return nil, err
+	}
+
+	groups := s.tables["hostgroups"]
+	rows := groups.query(params.getOptions, func(group object) bool {
+		if params.GroupIDs != nil && !slices.Contains(params.GroupIDs, group.str("groupid")) {
+			return false
+		}
+		hosts := s.groupHosts(group)
+		if params.HostIDs != nil && !slices.ContainsFunc(hosts, func(host object) bool {
+			return slices.Contains(params.HostIDs, host.str("hostid"))
+		}) {
+			return false
+		}
+		if params.WithHosts && len(hosts) == 0 {
+			return false
+		}
+		if params.WithMonitoredHosts && !slices.ContainsFunc(hosts, func(host object) bool {
+			return host.str("status") == "0"
+		}) {
+			return false
+		}
+		return true
+	})
+
+	return groups.result(rows, params.getOptions, func(out, group object) {
+		if params.SelectHosts != nil {
+			out["hosts"] = s.tables["hosts"].related(params.SelectHosts, s.groupHosts(group))
+		}
+	}), nil
+}
+
+func (s *Server) hostgroupCreate(req *request) (any, *apiError) {
+	groups, err := objects(req.params)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make(map[string]bool)
+	for i, group := range groups {
+		name := group.str("name")
+		if name == "" {
+			return nil, invalidParams(`Invalid parameter "/%d": the parameter "name" is missing.`, i+1)
+		}
+		if names[name] || slices.ContainsFunc(s.tables["hostgroups"].rows, func(g object) bool { return g.str("name") == name }) {
+			return nil, invalidParams(`Host group "%s" already exists.`, name)
+		}
+		names[name] = true
+	}
+
+	groupIDs := []string{}
+	for _, group := range groups {
+		delete(group, "groupid")
+		groupIDs = append(groupIDs, s.insertHostGroup(group))
+	}
+	return object{"groupids": groupIDs}, nil
+}
+
+func (s *Server) hostgroupDelete(req *request) (any, *apiError) {
+	var groupIDs ids
+	if err := decodeParams(req.params, &groupIDs); err != nil {
+		return nil, err
+	}
+
+	for _, id := range groupIDs {
+		group := s.tables["hostgroups"].get(id)
+		if group == nil {
+			return nil, invalidParams(errNoPermissions)
+		}
+		for _, host := range s.groupHosts(group) {
+			if !slices.ContainsFunc(objectList(host["groups"]), func(ref object) bool {
+				return !slices.Contains(groupIDs, ref.str("groupid"))
+			}) {
+				return nil, invalidParams(`Host "%s" cannot be without host group.`, host.str("host"))
+			}
+		}
+	}
+
+	for _, id := range groupIDs {
+		s.tables["hostgroups"].delete(id)
+	}
+	return object{"groupids": []string(groupIDs)}, nil
+}
+
+// insertHostGroup stores a host group with the defaults of hostgroup.create.
+func (s *Server) insertHostGroup(group object) string {
+	row := object{"flags": "0", "uuid": randomHex(16)}
+	for k, v := range group {
+		row[k] = v
+	}
+	return s.tables["hostgroups"].insert(row)
+}
+
+func (s *Server) groupHosts(group object) []object {
+	return s.tables["hosts"].where(func(host object) bool {
+		return intersects([]string{group.str("groupid")}, host["groups"])
+	})
+}
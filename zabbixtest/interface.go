@@ -0,0 +1,288 @@
+package zabbixtest
+
+import (
+	"slices"
+	"strconv"
+)
+
+var interfaceTypeNames = map[string]string{
+	"1": "Agent",
+	"2": "SNMP",
+	"3": "IPMI",
+	"4": "JMX",
+}
+
+func (s *Server) hostinterfaceGet(req *request) (any, *apiError) {
+	var params struct {
+		getOptions
+		HostIDs      ids `json:"hostids"`
+		InterfaceIDs ids `json:"interfaceids"`
+		SelectHosts  any `json:"selectHosts"`
+	}
+	if err := decodeParams(req.params, &params); err != nil {
+		return nil, err
+	}
+
+	interfaces := s.tables["interfaces"]
+	rows := interfaces.query(params.getOptions, func(iface object) bool {
+		if params.HostIDs != nil && !slices.Contains(params.HostIDs, iface.str("hostid")) {
+			return false
+		}
+		if params.InterfaceIDs != nil && !slices.Contains(params.InterfaceIDs, iface.str("interfaceid")) {
+			return false
+		}
+		return true
+	})
+
+	return interfaces.result(rows, params.getOptions, func(out, iface object) {
+		if params.SelectHosts != nil {
+			hosts := s.tables["hosts"].where(func(host object) bool {
+				return host.str("hostid") == iface.str("hostid")
+			})
+			out["hosts"] = s.tables["hosts"].related(params.SelectHosts, hosts)
+		}
+	}), nil
+}
+
+func (s *Server) hostinterfaceCreate(req *request) (any, *apiError) {
+	ifaces, err := objects(req.params)
+	if err != nil {
+		return nil, err
+	}
+
+	changed := make(map[string][]object)
+	for i, iface := range ifaces {
+		if err := validateInterface(iface, "/"+strconv.Itoa(i+1)); err != nil {
+			return nil, err
+		}
+		hostID := iface.str("hostid")
+		if s.tables["hosts"].get(hostID) == nil {
+			return nil, invalidParams(errNoPermissions)
+		}
+		if _, ok := changed[hostID]; !ok {
+			changed[hostID] = s.hostInterfaces(object{"hostid": hostID})
+		}
+		changed[hostID] = append(changed[hostID], iface)
+	}
+	if err := s.checkMainInterfaces(changed); err != nil {
+		return nil, err
+	}
+
+	interfaceIDs := []string{}
+	for _, iface := range ifaces {
+		interfaceIDs = append(interfaceIDs, s.insertInterface(iface.str("hostid"), iface))
+	}
+	return object{"interfaceids": interfaceIDs}, nil
+}
+
+func (s *Server) hostinterfaceUpdate(req *request) (any, *apiError) {
+	ifaces, err := objects(req.params)
+	if err != nil {
+		return nil, err
+	}
+
+	changed := make(map[string][]object)
+	updates := make(map[string]object)
+	for i, iface := range ifaces {
+		id := iface.str("interfaceid")
+		if id == "" {
+			return nil, invalidParams(`Invalid parameter "/%d": the parameter "interfaceid" is missing.`, i+1)
+		}
+		current := s.tables["interfaces"].get(id)
+		if current == nil {
+			return nil, invalidParams(errNoPermissions)
+		}
+
+		merged := current.clone()
+		for k, v := range iface {
+			if k != "hostid" || v != "" {
+				merged[k] = v
+			}
+		}
+		if merged.str("hostid") != current.str("hostid") {
+			return nil, invalidParams(`Invalid parameter "/%d/hostid": cannot be changed.`, i+1)
+		}
+		if err := validateInterface(merged, "/"+strconv.Itoa(i+1)); err != nil {
+			return nil, err
+		}
+
+		hostID := current.str("hostid")
+		if _, ok := changed[hostID]; !ok {
+			changed[hostID] = s.hostInterfaces(object{"hostid": hostID})
+		}
+		changed[hostID] = slices.DeleteFunc(changed[hostID], func(o object) bool { return o.str("interfaceid") == id })
+		changed[hostID] = append(changed[hostID], merged)
+		updates[id] = merged
+	}
+	if err := s.checkMainInterfaces(changed); err != nil {
+		return nil, err
+	}
+
+	interfaceIDs := []string{}
+	for _, iface := range ifaces {
+		id := iface.str("interfaceid")
+		current := s.tables["interfaces"].get(id)
+		for k, v := range interfaceRow(current.str("hostid"), updates[id]) {
+			current[k] = v
+		}
+		interfaceIDs = append(interfaceIDs, id)
+	}
+	return object{"interfaceids": interfaceIDs}, nil
+}
+
+func (s *Server) hostinterfaceDelete(req *request) (any, *apiError) {
+	var interfaceIDs ids
+	if err := decodeParams(req.params, &interfaceIDs); err != nil {
+		return nil, err
+	}
+
+	changed := make(map[string][]object)
+	for _, id := range interfaceIDs {
+		iface := s.tables["interfaces"].get(id)
+		if iface == nil {
+			return nil, invalidParams(errNoPermissions)
+		}
+		hostID := iface.str("hostid")
+		if _, ok := changed[hostID]; !ok {
+			changed[hostID] = s.hostInterfaces(object{"hostid": hostID})
+		}
+		changed[hostID] = slices.DeleteFunc(changed[hostID], func(o object) bool { return o.str("interfaceid") == id })
+	}
+	if err := s.checkMainInterfaces(changed); err != nil {
+		return nil, err
+	}
+
+	for _, id := range interfaceIDs {
+		s.tables["interfaces"].delete(id)
+	}
+	return object{"interfaceids": []string(interfaceIDs)}, nil
+}
+
+// validateInterfaces checks the interfaces passed with a host to
+// host.create or host.update.
+func validateInterfaces(ifaces []object, hostName string, index int) *apiError {
+	for i, iface := range ifaces {
+		if err := validateInterface(iface, "/"+strconv.Itoa(index)+"/interfaces/"+strconv.Itoa(i+1)); err != nil {
+			return err
+		}
+	}
+	return checkMain(ifaces, hostName)
+}
+
+// validateInterface checks the properties of a single interface; path is
+// the position of the interface in the request, used in error messages.
+func validateInterface(iface object, path string) *apiError {
+	for _, field := range []string{"type", "main", "useip"} {
+		if iface.str(field) == "" {
+			return invalidParams(`Invalid parameter "%s": the parameter "%s" is missing.`, path, field)
+		}
+	}
+	if _, ok := interfaceTypeNames[iface.str("type")]; !ok {
+		return invalidParams(`Invalid parameter "%s/type": value must be one of 1, 2, 3, 4.`, path)
+	}
+	if iface.str("port") == "" {
+		return invalidParams(`Invalid parameter "%s/port": cannot be empty.`, path)
+	}
+	if iface.str("useip") == "1" && iface.str("ip") == "" {
+		return invalidParams(`Invalid parameter "%s/ip": cannot be empty.`, path)
+	}
+	if iface.str("useip") == "0" && iface.str("dns") == "" {
+		return invalidParams(`Invalid parameter "%s/dns": cannot be empty.`, path)
+	}
+
+	if iface.str("type") == "2" {
+		details, _ := iface["details"].(object)
+		switch details.str("version") {
+		case "1", "2":
+			if details.str("community") == "" {
+				return invalidParams(`Invalid parameter "%s/details/community": cannot be empty.`, path)
+			}
+		case "3":
+		case "":
+			return invalidParams(`Invalid parameter "%s/details": the parameter "version" is missing.`, path)
+		default:
+			return invalidParams(`Invalid parameter "%s/details/version": value must be one of 1, 2, 3.`, path)
+		}
+	}
+	return nil
+}
+
+// checkMainInterfaces checks the resulting interfaces of every changed host.
+func (s *Server) checkMainInterfaces(changed map[string][]object) *apiError {
+	for hostID, ifaces := range changed {
+		if err := checkMain(ifaces, s.tables["hosts"].get(hostID).str("host")); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkMain checks that a host has exactly one main interface of each
+// interface type it uses.
+func checkMain(ifaces []object, hostName string) *apiError {
+	for _, typ := range []string{"1", "2", "3", "4"} {
+		count, main := 0, 0
+		for _, iface := range ifaces {
+			if iface.str("type") == typ {
+				count++
+				if iface.str("main") == "1" {
+					main++
+				}
+			}
+		}
+		if count > 0 && main == 0 {
+			return invalidParams(`No default interface for "%s" type on "%s".`, interfaceTypeNames[typ], hostName)
+		}
+		if main > 1 {
+			return invalidParams("Host cannot have more than one default interface of the same type.")
+		}
+	}
+	return nil
+}
+
+// insertInterface stores a validated interface of the given host.
+func (s *Server) insertInterface(hostID string, iface object) string {
+	return s.tables["interfaces"].insert(interfaceRow(hostID, iface))
+}
+
+// interfaceRow returns the stored representation of an interface, with the
+// defaults of hostinterface.create applied.
+func interfaceRow(hostID string, iface object) object {
+	row := object{
+		"ip":            "",
+		"dns":           "",
+		"available":     "0",
+		"error":         "",
+		"errors_from":   "0",
+		"disable_until": "0",
+	}
+	for k, v := range iface {
+		row[k] = v
+	}
+	row["hostid"] = hostID
+
+	if row.str("type") != "2" {
+		row["details"] = []any{}
+		return row
+	}
+
+	details := object{
+		"bulk":            "1",
+		"community":       "",
+		"max_repetitions": "10",
+		"securityname":    "",
+		"securitylevel":   "0",
+		"authpassphrase":  "",
+		"privpassphrase":  "",
+		"authprotocol":    "0",
+		"privprotocol":    "0",
+		"contextname":     "",
+	}
+	if d, ok := iface["details"].(object); ok {
+		for k, v := range d {
+			details[k] = v
+		}
+	}
+	row["details"] = details
+	return row
+}
@@ -0,0 +1,96 @@
+package zabbixtest
+
+import (
+	"slices"
+	"strconv"
+	"time"
+)
+
+// Items and LLD rules share the items table, as in the Zabbix database. LLD
+// rules have flags 1 and are not returned by item.get.
+const itemFlagsDiscoveryRule = "1"
+
+var itemDefaults = object{
+	"type":        "0",
+	"value_type":  "3",
+	"delay":       "1m",
+	"history":     "31d",
+	"trends":      "365d",
+	"units":       "",
+	"status":      "0",
+	"state":       "0",
+	"error":       "",
+	"flags":       "0",
+	"templateid":  "0",
+	"description": "",
+	"lastclock":   "0",
+	"lastvalue":   "",
+	"prevvalue":   "",
+	"tags":        []any{},
+}
+
+func (s *Server) itemGet(req *request) (any, *apiError) {
+	var params struct {
+		getOptions
+		ItemIDs    ids `json:"itemids"`
+		HostIDs    ids `json:"hostids"`
+		SelectTags any `json:"selectTags"`
+	}
+	if err := decodeParams(req.params, &params); err != nil {
+		return nil, err
+	}
+
+	items := s.tables["items"]
+	rows := items.query(params.getOptions, func(item object) bool {
+		if item.str("flags") == itemFlagsDiscoveryRule {
+			return false
+		}
+		if params.ItemIDs != nil && !slices.Contains(params.ItemIDs, item.str("itemid")) {
+			return false
+		}
+		if params.HostIDs != nil && !slices.Contains(params.HostIDs, item.str("hostid")) {
+			return false
+		}
+		return true
+	})
+
+	result := items.result(rows, params.getOptions, func(out, item object) {
+		if params.SelectTags != nil {
+			out["tags"] = listOrEmpty(item["tags"])
+		}
+	})
+
+	s.processChecks()
+	return result, nil
+}
+
+// processChecks processes the pending check now tasks of items, after the
+// item.get that follows them: the items get a new value, and the tasks are
+// deleted as by the Zabbix server.
+func (s *Server) processChecks() {
+	now := strconv.FormatInt(time.Now().Unix(), 10)
+	for _, task := range s.tables["tasks"].where(func(task object) bool {
+		return task.str("type") == taskTypeCheckNow
+	}) {
+		request, _ := task["request"].(object)
+		item := s.tables["items"].get(request.str("itemid"))
+		if item == nil || item.str("flags") == itemFlagsDiscoveryRule {
+			continue
+		}
+		item["prevvalue"] = item["lastvalue"]
+		item["lastclock"] = now
+		s.tables["tasks"].delete(task.str("taskid"))
+	}
+}
+
+// insertItem stores an item or LLD rule with the defaults of item.create.
+func (s *Server) insertItem(item object) string {
+	row := itemDefaults.clone()
+	for k, v := range item {
+		row[k] = v
+	}
+	if row.str("itemid") == "" {
+		delete(row, "itemid")
+	}
+	return s.tables["items"].insert(row)
+}
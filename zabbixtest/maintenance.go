@@ -0,0 +1,51 @@
+package zabbixtest
+
+import "slices"
+
+func (s *Server) maintenanceCreate(req *request) (any, *apiError) {
+	maintenances, err := objects(req.params)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make(map[string]bool)
+	for i, maintenance := range maintenances {
+		name := maintenance.str("name")
+		for _, param := range []string{"name", "active_since", "active_till", "timeperiods"} {
+			if _, ok := maintenance[param]; !ok {
+				return nil, invalidParams(`Invalid parameter "/%d": the parameter "%s" is missing.`, i+1, param)
+			}
+		}
+		if names[name] || slices.ContainsFunc(s.tables["maintenances"].rows, func(m object) bool { return m.str("name") == name }) {
+			return nil, invalidParams(`Maintenance "%s" already exists.`, name)
+		}
+		names[name] = true
+
+		hosts, groups := objectList(maintenance["hosts"]), objectList(maintenance["groups"])
+		if len(hosts) == 0 && len(groups) == 0 {
+			return nil, invalidParams("At least one host group or host must be selected.")
+		}
+		for _, host := range hosts {
+			if s.tables["hosts"].get(host.str("hostid")) == nil {
+				return nil, invalidParams(errNoPermissions)
+			}
+		}
+		for _, group := range groups {
+			if s.tables["hostgroups"].get(group.str("groupid")) == nil {
+				return nil, invalidParams(errNoPermissions)
+			}
+		}
+	}
+
+	maintenanceIDs := []string{}
+	for _, maintenance := range maintenances {
+		row := object{"description": "", "maintenance_type": "0", "tags_evaltype": "0"}
+		for k, v := range maintenance {
+			if k != "maintenanceid" {
+				row[k] = v
+			}
+		}
+		maintenanceIDs = append(maintenanceIDs, s.tables["maintenances"].insert(row))
+	}
+	return object{"maintenanceids": maintenanceIDs}, nil
+}
@@ -0,0 +1,318 @@
+package zabbixtest
+
+import (
+	"fmt"
+	"slices"
+)
+
+var mapDefaults = object{
+	"backgroundid":   "0",
+	"expand_macros":  "0",
+	"grid_show":      "1",
+	"grid_size":      "50",
+	"grid_align":     "1",
+	"highlight":      "1",
+	"label_type":     "2",
+	"label_location": "0",
+	"severity_min":   "0",
+	"show_unack":     "0",
+	"private":        "1",
+	"users":          []any{},
+	"userGroups":     []any{},
+}
+
+func (s *Server) mapGet(req *request) (any, *apiError) {
+	var params struct {
+		getOptions
+		SysmapIDs        ids `json:"sysmapids"`
+		UserIDs          ids `json:"userids"`
+		SelectSelements  any `json:"selectSelements"`
+		SelectLinks      any `json:"selectLinks"`
+		SelectShapes     any `json:"selectShapes"`
+		SelectUsers      any `json:"selectUsers"`
+		SelectUserGroups any `json:"selectUserGroups"`
+	}
+	if err := decodeParams(req.params, &params); err != nil {
+		return nil, err
+	}
+
+	maps := s.tables["maps"]
+	rows := maps.query(params.getOptions, func(m object) bool {
+		if params.SysmapIDs != nil && !slices.Contains(params.SysmapIDs, m.str("sysmapid")) {
+			return false
+		}
+		if params.UserIDs != nil && !slices.Contains(params.UserIDs, m.str("userid")) {
+			return false
+		}
+		return true
+	})
+
+	return maps.result(rows, params.getOptions, func(out, m object) {
+		id := m.str("sysmapid")
+		if params.SelectSelements != nil {
+			out["selements"] = s.tables["selements"].related(params.SelectSelements, s.mapRows("selements", id))
+		}
+		if params.SelectLinks != nil {
+			out["links"] = s.tables["links"].related(params.SelectLinks, s.mapRows("links", id))
+		}
+		if params.SelectShapes != nil {
+			out["shapes"] = s.tables["shapes"].related(params.SelectShapes, s.mapRows("shapes", id))
+		}
+		if params.SelectUsers != nil {
+			out["users"] = listOrEmpty(m["users"])
+		}
+		if params.SelectUserGroups != nil {
+			out["userGroups"] = listOrEmpty(m["userGroups"])
+		}
+	}), nil
+}
+
+func (s *Server) mapCreate(req *request) (any, *apiError) {
+	maps, err := objects(req.params)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make(map[string]bool)
+	for i, m := range maps {
+		for _, param := range []string{"name", "width", "height"} {
+			if _, ok := m[param]; !ok {
+				return nil, invalidParams(`Invalid parameter "/%d": the parameter "%s" is missing.`, i+1, param)
+			}
+		}
+		name := m.str("name")
+		if names[name] || s.mapExists(name, "") {
+			return nil, invalidParams(`Map "%s" already exists.`, name)
+		}
+		names[name] = true
+		if err := s.checkMap(i, m); err != nil {
+			return nil, err
+		}
+	}
+
+	sysmapIDs := []string{}
+	for _, m := range maps {
+		row := mapDefaults.clone()
+		row["userid"] = req.userID
+		for k, v := range m {
+			if k != "sysmapid" && k != "selements" && k != "links" && k != "shapes" {
+				row[k] = v
+			}
+		}
+		id := s.tables["maps"].insert(row)
+		s.setMapContents(id, m)
+		sysmapIDs = append(sysmapIDs, id)
+	}
+	return object{"sysmapids": sysmapIDs}, nil
+}
+
+func (s *Server) mapUpdate(req *request) (any, *apiError) {
+	maps, err := objects(req.params)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, m := range maps {
+		id := m.str("sysmapid")
+		if id == "" {
+			return nil, invalidParams(`Invalid parameter "/%d": the parameter "sysmapid" is missing.`, i+1)
+		}
+		if s.tables["maps"].get(id) == nil {
+			return nil, invalidParams(errNoPermissions)
+		}
+		if _, ok := m["name"]; ok {
+			name := m.str("name")
+			if name == "" {
+				return nil, invalidParams(`Invalid parameter "/%d/name": cannot be empty.`, i+1)
+			}
+			if s.mapExists(name, id) {
+				return nil, invalidParams(`Map "%s" already exists.`, name)
+			}
+		}
+		if err := s.checkMap(i, m); err != nil {
+			return nil, err
+		}
+	}
+
+	sysmapIDs := []string{}
+	for _, m := range maps {
+		id := m.str("sysmapid")
+		current := s.tables["maps"].get(id)
+		for k, v := range m {
+			if k != "selements" && k != "links" && k != "shapes" {
+				current[k] = v
+			}
+		}
+		s.setMapContents(id, m)
+		sysmapIDs = append(sysmapIDs, id)
+	}
+	return object{"sysmapids": sysmapIDs}, nil
+}
+
+func (s *Server) mapDelete(req *request) (any, *apiError) {
+	var sysmapIDs ids
+	if err := decodeParams(req.params, &sysmapIDs); err != nil {
+		return nil, err
+	}
+
+	for _, id := range sysmapIDs {
+		if s.tables["maps"].get(id) == nil {
+			return nil, invalidParams(errNoPermissions)
+		}
+	}
+
+	for _, id := range sysmapIDs {
+		for _, table := range []string{"selements", "links", "shapes"} {
+			s.deleteWhere(table, "sysmapid", id)
+		}
+		s.tables["maps"].delete(id)
+	}
+	return object{"sysmapids": []string(sysmapIDs)}, nil
+}
+
+func (s *Server) mapExists(name, exceptID string) bool {
+	return slices.ContainsFunc(s.tables["maps"].rows, func(m object) bool {
+		return m.str("name") == name && m.str("sysmapid") != exceptID
+	})
+}
+
+// elementTables are the tables of the objects map elements stand for, by
+// element type.
+var elementTables = map[string][2]string{
+	"0": {"hosts", "hostid"},
+	"1": {"maps", "sysmapid"},
+	"3": {"hostgroups", "groupid"},
+}
+
+// checkMap checks the elements, links and shapes of the i-th map of a create
+// or update request.
+func (s *Server) checkMap(i int, m object) *apiError {
+	elementIDs := make(map[string]bool)
+	if _, ok := m["selements"]; !ok && m.str("sysmapid") != "" {
+		for _, selement := range s.mapRows("selements", m.str("sysmapid")) {
+			elementIDs[selement.str("selementid")] = true
+		}
+	}
+
+	for e, selement := range objectList(m["selements"]) {
+		path := fmt.Sprintf("/%d/selements/%d", i+1, e+1)
+		for _, param := range []string{"elementtype", "iconid_off"} {
+			if _, ok := selement[param]; !ok {
+				return invalidParams(`Invalid parameter "%s": the parameter "%s" is missing.`, path, param)
+			}
+		}
+		elements := objectList(selement["elements"])
+		if selement.str("elementtype") != "4" && len(elements) == 0 {
+			return invalidParams(`Invalid parameter "%s/elements": cannot be empty.`, path)
+		}
+		if ref, ok := elementTables[selement.str("elementtype")]; ok {
+			for _, element := range elements {
+				if s.tables[ref[0]].get(element.str(ref[1])) == nil {
+					return invalidParams(errNoPermissions)
+				}
+			}
+		}
+		if id := selement.str("selementid"); id != "" {
+			elementIDs[id] = true
+		}
+	}
+
+	for l, link := range objectList(m["links"]) {
+		for _, param := range []string{"selementid1", "selementid2"} {
+			if !elementIDs[link.str(param)] {
+				return invalidParams(`Invalid parameter "/%d/links/%d/%s": map element "%s" does not exist.`, i+1, l+1, param, link.str(param))
+			}
+		}
+	}
+
+	for sh, shape := range objectList(m["shapes"]) {
+		if _, ok := shape["type"]; !ok {
+			return invalidParams(`Invalid parameter "/%d/shapes/%d": the parameter "type" is missing.`, i+1, sh+1)
+		}
+	}
+
+	for _, u := range objectList(m["users"]) {
+		if !s.userExists(u.str("userid")) {
+			return invalidParams(errNoPermissions)
+		}
+	}
+	return nil
+}
+
+// setMapContents replaces the elements, links and shapes of a map by those
+// given in m, if any. Elements keep their ID if it is an existing element of
+// the map, and get a new one otherwise, which links are updated to.
+func (s *Server) setMapContents(sysmapID string, m object) {
+	selementIDs := make(map[string]string) // Given ID to stored ID
+	for _, selement := range s.mapRows("selements", sysmapID) {
+		selementIDs[selement.str("selementid")] = selement.str("selementid")
+	}
+
+	if selements, ok := m["selements"]; ok {
+		existing := s.mapRows("selements", sysmapID)
+		s.deleteWhere("selements", "sysmapid", sysmapID)
+		clear(selementIDs)
+		for _, selement := range objectList(selements) {
+			row := object{"iconid_on": "0", "label": "", "label_location": "-1", "x": "0", "y": "0", "elements": []any{}}
+			for k, v := range selement {
+				row[k] = v
+			}
+			given := row.str("selementid")
+			if !containsID(existing, "selementid", given) {
+				delete(row, "selementid")
+			}
+			row["sysmapid"] = sysmapID
+			selementIDs[given] = s.tables["selements"].insert(row)
+		}
+		// Links to removed elements are removed too
+		s.tables["links"].rows = slices.DeleteFunc(s.tables["links"].rows, func(link object) bool {
+			return link.str("sysmapid") == sysmapID &&
+				(!containsID(s.tables["selements"].rows, "selementid", link.str("selementid1")) ||
+					!containsID(s.tables["selements"].rows, "selementid", link.str("selementid2")))
+		})
+	}
+
+	if links, ok := m["links"]; ok {
+		existing := s.mapRows("links", sysmapID)
+		s.deleteWhere("links", "sysmapid", sysmapID)
+		for _, link := range objectList(links) {
+			row := object{"drawtype": "0", "color": "000000", "label": "", "linktriggers": []any{}}
+			for k, v := range link {
+				row[k] = v
+			}
+			if !containsID(existing, "linkid", row.str("linkid")) {
+				delete(row, "linkid")
+			}
+			row["selementid1"] = selementIDs[link.str("selementid1")]
+			row["selementid2"] = selementIDs[link.str("selementid2")]
+			row["sysmapid"] = sysmapID
+			s.tables["links"].insert(row)
+		}
+	}
+
+	if shapes, ok := m["shapes"]; ok {
+		existing := s.mapRows("shapes", sysmapID)
+		s.deleteWhere("shapes", "sysmapid", sysmapID)
+		for _, shape := range objectList(shapes) {
+			row := object{
+				"x": "0", "y": "0", "width": "200", "height": "200", "text": "", "font_size": "11",
+				"font_color": "000000", "border_type": "1", "border_color": "000000", "background_color": "", "zindex": "0",
+			}
+			for k, v := range shape {
+				row[k] = v
+			}
+			if !containsID(existing, "sysmap_shapeid", row.str("sysmap_shapeid")) {
+				delete(row, "sysmap_shapeid")
+			}
+			row["sysmapid"] = sysmapID
+			s.tables["shapes"].insert(row)
+		}
+	}
+}
+
+// mapRows returns the rows of a table of map contents belonging to a map.
+func (s *Server) mapRows(table, sysmapID string) []object {
+	return s.tables[table].where(func(row object) bool {
+		return row.str("sysmapid") == sysmapID
+	})
+}
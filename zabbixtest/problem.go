@@ -0,0 +1,174 @@
+package zabbixtest
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// recentPeriod is how long resolved problems are returned with recent set,
+// the default ok_period of Zabbix.
+const recentPeriod = 5 * time.Minute
+
+func (s *Server) problemGet(req *request) (any, *apiError) {
+	var params struct {
+		getOptions
+		EventIDs              ids      `json:"eventids"`
+		GroupIDs              ids      `json:"groupids"`
+		HostIDs               ids      `json:"hostids"`
+		ObjectIDs             ids      `json:"objectids"`
+		Source                *int     `json:"source"`
+		Object                *int     `json:"object"`
+		Acknowledged          *bool    `json:"acknowledged"`
+		Suppressed            *bool    `json:"suppressed"`
+		Severities            ids      `json:"severities"`
+		EvalType              int      `json:"evaltype"`
+		Tags                  []object `json:"tags"`
+		Recent                bool     `json:"recent"`
+		EventIDFrom           string   `json:"eventid_from"`
+		EventIDTill           string   `json:"eventid_till"`
+		TimeFrom              *int64   `json:"time_from"`
+		TimeTill              *int64   `json:"time_till"`
+		SelectAcknowledges    any      `json:"selectAcknowledges"`
+		SelectTags            any      `json:"selectTags"`
+		SelectSuppressionData any      `json:"selectSuppressionData"`
+	}
+	if err := decodeParams(req.params, &params); err != nil {
+		return nil, err
+	}
+
+	now := time.Now().Unix()
+	problems := s.tables["problems"]
+	rows := problems.query(params.getOptions, func(problem object) bool {
+		if resolved := problem.str("r_clock") != "0"; resolved {
+			rclock, _ := strconv.ParseInt(problem.str("r_clock"), 10, 64)
+			if !params.Recent || now-rclock > int64(recentPeriod.Seconds()) {
+				return false
+			}
+		}
+		if params.EventIDs != nil && !slices.Contains(params.EventIDs, problem.str("eventid")) {
+			return false
+		}
+		if params.HostIDs != nil && !slices.Contains(params.HostIDs, problem.str("hostid")) {
+			return false
+		}
+		if params.GroupIDs != nil {
+			host := s.tables["hosts"].get(problem.str("hostid"))
+			if host == nil || !intersects(params.GroupIDs, host["groups"]) {
+				return false
+			}
+		}
+		if params.ObjectIDs != nil && !slices.Contains(params.ObjectIDs, problem.str("objectid")) {
+			return false
+		}
+		if params.Source != nil && problem.str("source") != strconv.Itoa(*params.Source) {
+			return false
+		}
+		if params.Object != nil && problem.str("object") != strconv.Itoa(*params.Object) {
+			return false
+		}
+		if params.Acknowledged != nil && (problem.str("acknowledged") == "1") != *params.Acknowledged {
+			return false
+		}
+		if params.Suppressed != nil && (problem.str("suppressed") == "1") != *params.Suppressed {
+			return false
+		}
+		if params.Severities != nil && !slices.Contains(params.Severities, problem.str("severity")) {
+			return false
+		}
+		if params.EventIDFrom != "" && compareValues(problem.str("eventid"), params.EventIDFrom) < 0 {
+			return false
+		}
+		if params.EventIDTill != "" && compareValues(problem.str("eventid"), params.EventIDTill) > 0 {
+			return false
+		}
+		clock, _ := strconv.ParseInt(problem.str("clock"), 10, 64)
+		if params.TimeFrom != nil && clock < *params.TimeFrom {
+			return false
+		}
+		if params.TimeTill != nil && clock > *params.TimeTill {
+			return false
+		}
+		if len(params.Tags) > 0 && !matchTags(objectList(problem["tags"]), params.Tags, params.EvalType == 2) {
+			return false
+		}
+		return true
+	})
+
+	return problems.result(rows, params.getOptions, func(out, problem object) {
+		if params.SelectTags != nil {
+			out["tags"] = listOrEmpty(problem["tags"])
+		}
+		if params.SelectAcknowledges != nil {
+			out["acknowledges"] = listOrEmpty(problem["acknowledges"])
+		}
+		if params.SelectSuppressionData != nil {
+			out["suppression_data"] = listOrEmpty(problem["suppression_data"])
+		}
+	}), nil
+}
+
+// Tag filter operators.
+const (
+	tagContains = iota
+	tagEquals
+	tagNotContains
+	tagNotEquals
+	tagExists
+	tagNotExists
+)
+
+// matchTags evaluates a tags filter. Conditions on the same tag name are
+// always combined with OR; different tag names are combined with AND, or
+// with OR if or is set.
+func matchTags(tags, conditions []object, or bool) bool {
+	byName := make(map[string][]object)
+	var names []string
+	for _, c := range conditions {
+		name := c.str("tag")
+		if _, ok := byName[name]; !ok {
+			names = append(names, name)
+		}
+		byName[name] = append(byName[name], c)
+	}
+
+	for _, name := range names {
+		ok := slices.ContainsFunc(byName[name], func(c object) bool {
+			return matchTag(tags, c)
+		})
+		if ok && or {
+			return true
+		}
+		if !ok && !or {
+			return false
+		}
+	}
+	return !or
+}
+
+func matchTag(tags []object, condition object) bool {
+	operator, _ := strconv.Atoi(condition.str("operator"))
+	name, value := condition.str("tag"), strings.ToLower(condition.str("value"))
+
+	var values []string
+	for _, tag := range tags {
+		if tag.str("tag") == name {
+			values = append(values, strings.ToLower(tag.str("value")))
+		}
+	}
+
+	switch operator {
+	case tagEquals:
+		return slices.Contains(values, value)
+	case tagNotContains:
+		return !slices.ContainsFunc(values, func(v string) bool { return strings.Contains(v, value) })
+	case tagNotEquals:
+		return !slices.Contains(values, value)
+	case tagExists:
+		return len(values) > 0
+	case tagNotExists:
+		return len(values) == 0
+	}
+	return slices.ContainsFunc(values, func(v string) bool { return strings.Contains(v, value) })
+}
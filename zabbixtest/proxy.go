@@ -0,0 +1,206 @@
+package zabbixtest
+
+import (
+	"slices"
+)
+
+var proxyDefaults = object{
+	"proxy_groupid":          "0",
+	"local_address":          "",
+	"local_port":             "10051",
+	"description":            "",
+	"lastaccess":             "0",
+	"address":                "127.0.0.1",
+	"port":                   "10051",
+	"allowed_addresses":      "",
+	"tls_connect":            "1",
+	"tls_accept":             "1",
+	"tls_issuer":             "",
+	"tls_subject":            "",
+	"custom_timeouts":        "0",
+	"timeout_zabbix_agent":   "",
+	"timeout_simple_check":   "",
+	"timeout_snmp_agent":     "",
+	"timeout_external_check": "",
+	"timeout_db_monitor":     "",
+	"timeout_http_agent":     "",
+	"timeout_ssh_agent":      "",
+	"timeout_telnet_agent":   "",
+	"timeout_script":         "",
+	"timeout_browser":        "",
+	"version":                "0",
+	"compatibility":          "0",
+	"state":                  "0",
+}
+
+func (s *Server) proxyGet(req *request) (any, *apiError) {
+	var params struct {
+		getOptions
+		ProxyIDs            ids `json:"proxyids"`
+		ProxyGroupIDs       ids `json:"proxy_groupids"`
+		SelectHosts         any `json:"selectHosts"`
+		SelectAssignedHosts any `json:"selectAssignedHosts"`
+	}
+	if err := decodeParams(req.params, &params); err != nil {
+		return nil, err
+	}
+
+	proxies := s.tables["proxies"]
+	rows := proxies.query(params.getOptions, func(proxy object) bool {
+		if params.ProxyIDs != nil && !slices.Contains(params.ProxyIDs, proxy.str("proxyid")) {
+			return false
+		}
+		if params.ProxyGroupIDs != nil && !slices.Contains(params.ProxyGroupIDs, proxy.str("proxy_groupid")) {
+			return false
+		}
+		return true
+	})
+
+	return proxies.result(rows, params.getOptions, func(out, proxy object) {
+		if params.SelectHosts != nil {
+			out["hosts"] = s.tables["hosts"].related(params.SelectHosts, s.proxyHosts(proxy))
+		}
+		if params.SelectAssignedHosts != nil {
+			assigned := s.tables["hosts"].where(func(host object) bool {
+				return host.str("assigned_proxyid") == proxy.str("proxyid")
+			})
+			out["assignedHosts"] = s.tables["hosts"].related(params.SelectAssignedHosts, assigned)
+		}
+	}), nil
+}
+
+func (s *Server) proxyCreate(req *request) (any, *apiError) {
+	proxies, err := objects(req.params)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make(map[string]bool)
+	for i, proxy := range proxies {
+		name := proxy.str("name")
+		if name == "" {
+			return nil, invalidParams(`Invalid parameter "/%d": the parameter "name" is missing.`, i+1)
+		}
+		if names[name] || slices.ContainsFunc(s.tables["proxies"].rows, func(p object) bool { return p.str("name") == name }) {
+			return nil, invalidParams(`Proxy "%s" already exists.`, name)
+		}
+		names[name] = true
+
+		switch proxy.str("operating_mode") {
+		case "0", "1":
+		case "":
+			return nil, invalidParams(`Invalid parameter "/%d": the parameter "operating_mode" is missing.`, i+1)
+		default:
+			return nil, invalidParams(`Invalid parameter "/%d/operating_mode": value must be one of 0, 1.`, i+1)
+		}
+		if usesPSK(proxy) {
+			for _, field := range []string{"tls_psk_identity", "tls_psk"} {
+				if proxy.str(field) == "" {
+					return nil, invalidParams(`Invalid parameter "/%d/%s": cannot be empty.`, i+1, field)
+				}
+			}
+		}
+		if err := s.checkRefs("hosts", "hostid", objectList(proxy["hosts"])); err != nil {
+			return nil, err
+		}
+	}
+
+	proxyIDs := []string{}
+	for _, proxy := range proxies {
+		row := proxyDefaults.clone()
+		for k, v := range proxy {
+			if k != "hosts" && k != "proxyid" {
+				row[k] = v
+			}
+		}
+		proxyID := s.tables["proxies"].insert(row)
+
+		for _, ref := range objectList(proxy["hosts"]) {
+			host := s.tables["hosts"].get(ref.str("hostid"))
+			host["monitored_by"] = "1"
+			host["proxyid"] = proxyID
+		}
+		proxyIDs = append(proxyIDs, proxyID)
+	}
+	return object{"proxyids": proxyIDs}, nil
+}
+
+func (s *Server) proxyUpdate(req *request) (any, *apiError) {
+	proxies, err := objects(req.params)
+	if err != nil {
+		return nil, err
+	}
+
+	merged := make([]object, len(proxies))
+	for i, proxy := range proxies {
+		if proxy.str("proxyid") == "" {
+			return nil, invalidParams(`Invalid parameter "/%d": the parameter "proxyid" is missing.`, i+1)
+		}
+		current := s.tables["proxies"].get(proxy.str("proxyid"))
+		if current == nil {
+			return nil, invalidParams(errNoPermissions)
+		}
+
+		merged[i] = current.clone()
+		for k, v := range proxy {
+			merged[i][k] = v
+		}
+		name := merged[i].str("name")
+		if name == "" {
+			return nil, invalidParams(`Invalid parameter "/%d/name": cannot be empty.`, i+1)
+		}
+		if slices.ContainsFunc(s.tables["proxies"].rows, func(p object) bool {
+			return p.str("name") == name && p.str("proxyid") != current.str("proxyid")
+		}) {
+			return nil, invalidParams(`Proxy "%s" already exists.`, name)
+		}
+		if mode := merged[i].str("operating_mode"); mode != "0" && mode != "1" {
+			return nil, invalidParams(`Invalid parameter "/%d/operating_mode": value must be one of 0, 1.`, i+1)
+		}
+		if usesPSK(merged[i]) {
+			for _, field := range []string{"tls_psk_identity", "tls_psk"} {
+				if merged[i].str(field) == "" {
+					return nil, invalidParams(`Invalid parameter "/%d/%s": cannot be empty.`, i+1, field)
+				}
+			}
+		}
+	}
+
+	proxyIDs := []string{}
+	for _, proxy := range merged {
+		current := s.tables["proxies"].get(proxy.str("proxyid"))
+		for k, v := range proxy {
+			current[k] = v
+		}
+		proxyIDs = append(proxyIDs, proxy.str("proxyid"))
+	}
+	return object{"proxyids": proxyIDs}, nil
+}
+
+func (s *Server) proxyDelete(req *request) (any, *apiError) {
+	var proxyIDs ids
+	if err := decodeParams(req.params, &proxyIDs); err != nil {
+		return nil, err
+	}
+
+	for _, id := range proxyIDs {
+		proxy := s.tables["proxies"].get(id)
+		if proxy == nil {
+			return nil, invalidParams(errNoPermissions)
+		}
+		if hosts := s.proxyHosts(proxy); len(hosts) > 0 {
+			return nil, invalidParams(`Proxy "%s" is used by host "%s".`, proxy.str("name"), hosts[0].str("host"))
+		}
+	}
+
+	for _, id := range proxyIDs {
+		s.tables["proxies"].delete(id)
+	}
+	return object{"proxyids": []string(proxyIDs)}, nil
+}
+
+func (s *Server) proxyHosts(proxy object) []object {
+	return s.tables["hosts"].where(func(host object) bool {
+		return host.str("monitored_by") == "1" && host.str("proxyid") == proxy.str("proxyid")
+	})
+}
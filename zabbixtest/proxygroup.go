@@ -0,0 +1,61 @@
+package zabbixtest
+
+import (
+	"slices"
+)
+
+var proxyGroupDefaults = object{
+	"failover_delay": "1m",
+	"min_online":     "1",
+	"description":    "",
+	"state":          "0",
+}
+
+func (s *Server) proxygroupGet(req *request) (any, *apiError) {
+	var params struct {
+		getOptions
+		ProxyGroupIDs ids `json:"proxy_groupids"`
+		ProxyIDs      ids `json:"proxyids"`
+		SelectProxies any `json:"selectProxies"`
+	}
+	if err := decodeParams(req.params, &params); err != nil {
+		return nil, err
+	}
+
+	groups := s.tables["proxygroups"]
+	rows := groups.query(params.getOptions, func(group object) bool {
+		if params.ProxyGroupIDs != nil && !slices.Contains(params.ProxyGroupIDs, group.str("proxy_groupid")) {
+			return false
+		}
+		if params.ProxyIDs != nil && !slices.ContainsFunc(s.groupProxies(group), func(proxy object) bool {
+			return slices.Contains(params.ProxyIDs, proxy.str("proxyid"))
+		}) {
+			return false
+		}
+		return true
+	})
+
+	return groups.result(rows, params.getOptions, func(out, group object) {
+		if params.SelectProxies != nil {
+			out["proxies"] = s.tables["proxies"].related(params.SelectProxies, s.groupProxies(group))
+		}
+	}), nil
+}
+
+// insertProxyGroup stores a proxy group with the defaults of
+// proxygroup.create.
+func (s *Server) insertProxyGroup(group object) string {
+	row := proxyGroupDefaults.clone()
+	for k, v := range group {
+		if k != "proxy_groupid" {
+			row[k] = v
+		}
+	}
+	return s.tables["proxygroups"].insert(row)
+}
+
+func (s *Server) groupProxies(group object) []object {
+	return s.tables["proxies"].where(func(proxy object) bool {
+		return proxy.str("proxy_groupid") == group.str("proxy_groupid")
+	})
+}
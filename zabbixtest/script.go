@@ -0,0 +1,295 @@
+package zabbixtest
+
+import (
+	"slices"
+)
+
+var scriptDefaults = object{
+	"scope":        "1",
+	"command":      "",
+	"menu_path":    "",
+	"description":  "",
+	"groupid":      "0",
+	"usrgrpid":     "0",
+	"host_access":  "2",
+	"confirmation": "",
+	"parameters":   []any{},
+	"manualinput":  "0",
+}
+
+// scriptTypes are the valid script types, and scriptScopes the valid scopes.
+var (
+	scriptTypes  = []string{"0", "1", "2", "3", "5", "6"}
+	scriptScopes = []string{"1", "2", "4"}
+)
+
+func (s *Server) scriptGet(req *request) (any, *apiError) {
+	var params struct {
+		getOptions
+		ScriptIDs    ids `json:"scriptids"`
+		HostIDs      ids `json:"hostids"`
+		GroupIDs     ids `json:"groupids"`
+		UserGroupIDs ids `json:"usrgrpids"`
+	}
+	if err := decodeParams(req.params, &params); err != nil {
+		return nil, err
+	}
+
+	scripts := s.tables["scripts"]
+	rows := scripts.query(params.getOptions, func(script object) bool {
+		if params.ScriptIDs != nil && !slices.Contains(params.ScriptIDs, script.str("scriptid")) {
+			return false
+		}
+		if params.GroupIDs != nil && !slices.Contains(params.GroupIDs, script.str("groupid")) {
+			return false
+		}
+		if params.UserGroupIDs != nil && !slices.Contains(params.UserGroupIDs, script.str("usrgrpid")) {
+			return false
+		}
+		if params.HostIDs != nil && !slices.ContainsFunc(params.HostIDs, func(hostID string) bool {
+			return s.scriptAppliesTo(script, s.tables["hosts"].get(hostID))
+		}) {
+			return false
+		}
+		return true
+	})
+
+	return scripts.result(rows, params.getOptions, nil), nil
+}
+
+func (s *Server) scriptCreate(req *request) (any, *apiError) {
+	scripts, err := objects(req.params)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make(map[string]bool)
+	for i, script := range scripts {
+		for _, param := range []string{"name", "type"} {
+			if _, ok := script[param]; !ok {
+				return nil, invalidParams(`Invalid parameter "/%d": the parameter "%s" is missing.`, i+1, param)
+			}
+		}
+		name := script.str("name")
+		if names[name] || s.scriptExists(name, "") {
+			return nil, invalidParams(`Script "%s" already exists.`, name)
+		}
+		names[name] = true
+		if err := s.checkScript(i, script, script.str("type")); err != nil {
+			return nil, err
+		}
+	}
+
+	scriptIDs := []string{}
+	for _, script := range scripts {
+		scriptIDs = append(scriptIDs, s.insertScript(script))
+	}
+	return object{"scriptids": scriptIDs}, nil
+}
+
+func (s *Server) scriptUpdate(req *request) (any, *apiError) {
+	scripts, err := objects(req.params)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, script := range scripts {
+		id := script.str("scriptid")
+		if id == "" {
+			return nil, invalidParams(`Invalid parameter "/%d": the parameter "scriptid" is missing.`, i+1)
+		}
+		current := s.tables["scripts"].get(id)
+		if current == nil {
+			return nil, invalidParams(errNoPermissions)
+		}
+		if _, ok := script["name"]; ok && s.scriptExists(script.str("name"), id) {
+			return nil, invalidParams(`Script "%s" already exists.`, script.str("name"))
+		}
+		scriptType := current.str("type")
+		if _, ok := script["type"]; ok {
+			scriptType = script.str("type")
+		}
+		if err := s.checkScript(i, script, scriptType); err != nil {
+			return nil, err
+		}
+	}
+
+	scriptIDs := []string{}
+	for _, script := range scripts {
+		current := s.tables["scripts"].get(script.str("scriptid"))
+		for k, v := range script {
+			current[k] = v
+		}
+		scriptIDs = append(scriptIDs, script.str("scriptid"))
+	}
+	return object{"scriptids": scriptIDs}, nil
+}
+
+func (s *Server) scriptDelete(req *request) (any, *apiError) {
+	var scriptIDs ids
+	if err := decodeParams(req.params, &scriptIDs); err != nil {
+		return nil, err
+	}
+
+	for _, id := range scriptIDs {
+		if s.tables["scripts"].get(id) == nil {
+			return nil, invalidParams(errNoPermissions)
+		}
+	}
+
+	for _, id := range scriptIDs {
+		s.tables["scripts"].delete(id)
+	}
+	return object{"scriptids": []string(scriptIDs)}, nil
+}
+
+func (s *Server) scriptExecute(req *request) (any, *apiError) {
+	var params struct {
+		ScriptID    string  `json:"scriptid"`
+		HostID      string  `json:"hostid"`
+		EventID     string  `json:"eventid"`
+		ManualInput *string `json:"manualinput"`
+	}
+	if err := decodeParams(req.params, &params); err != nil {
+		return nil, err
+	}
+
+	if params.ScriptID == "" {
+		return nil, invalidParams(`Invalid parameter "/": the parameter "scriptid" is missing.`)
+	}
+	if (params.HostID == "") == (params.EventID == "") {
+		return nil, invalidParams(`Invalid parameter "/": either "hostid" or "eventid" is expected.`)
+	}
+	script := s.tables["scripts"].get(params.ScriptID)
+	if script == nil {
+		return nil, invalidParams(errNoPermissions)
+	}
+
+	// Scripts run on hosts are manual host actions, scripts run for events
+	// manual event actions, and they must be available to the host.
+	scope, hostID := "2", params.HostID
+	if params.EventID != "" {
+		event := s.tables["problems"].get(params.EventID)
+		if event == nil {
+			return nil, invalidParams(errNoPermissions)
+		}
+		scope, hostID = "4", event.str("hostid")
+	}
+	if script.str("scope") != scope || !s.scriptAppliesTo(script, s.tables["hosts"].get(hostID)) {
+		return nil, invalidParams(errNoPermissions)
+	}
+	if script.str("manualinput") == "1" && params.ManualInput == nil {
+		return nil, invalidParams(`Invalid parameter "/": the parameter "manualinput" is missing.`)
+	}
+	if script.str("type") == "6" {
+		return nil, invalidParams(`Cannot execute URL type script "%s".`, script.str("name"))
+	}
+
+	if message := script.str("error"); message != "" {
+		return nil, newError(CodeApplication, "%s", message)
+	}
+	result := object{"response": "success", "value": script.str("output")}
+	if script.str("type") == "5" {
+		result["debug"] = object{"logs": []any{}, "ms": "0"}
+	}
+	return result, nil
+}
+
+func (s *Server) scriptGetscriptsbyhosts(req *request) (any, *apiError) {
+	var hostIDs ids
+	if err := decodeParams(req.params, &hostIDs); err != nil {
+		return nil, err
+	}
+
+	result := make(map[string]any, len(hostIDs))
+	for _, id := range hostIDs {
+		if host := s.tables["hosts"].get(id); host != nil {
+			result[id] = s.availableScripts("2", host)
+		}
+	}
+	return result, nil
+}
+
+func (s *Server) scriptGetscriptsbyevents(req *request) (any, *apiError) {
+	var eventIDs ids
+	if err := decodeParams(req.params, &eventIDs); err != nil {
+		return nil, err
+	}
+
+	result := make(map[string]any, len(eventIDs))
+	for _, id := range eventIDs {
+		if event := s.tables["problems"].get(id); event != nil {
+			result[id] = s.availableScripts("4", s.tables["hosts"].get(event.str("hostid")))
+		}
+	}
+	return result, nil
+}
+
+func (s *Server) scriptExists(name, exceptID string) bool {
+	return slices.ContainsFunc(s.tables["scripts"].rows, func(script object) bool {
+		return script.str("name") == name && script.str("scriptid") != exceptID
+	})
+}
+
+// checkScript checks the i-th script of a create or update request, which is
+// of type scriptType once stored.
+func (s *Server) checkScript(i int, script object, scriptType string) *apiError {
+	if !slices.Contains(scriptTypes, scriptType) {
+		return invalidParams(`Invalid parameter "/%d/type": value must be one of 0, 1, 2, 3, 5, 6.`, i+1)
+	}
+	if _, ok := script["scope"]; ok && !slices.Contains(scriptScopes, script.str("scope")) {
+		return invalidParams(`Invalid parameter "/%d/scope": value must be one of 1, 2, 4.`, i+1)
+	}
+	if _, ok := script["name"]; ok && script.str("name") == "" {
+		return invalidParams(`Invalid parameter "/%d/name": cannot be empty.`, i+1)
+	}
+	if scriptType == "6" {
+		if _, ok := script["url"]; ok && script.str("url") == "" {
+			return invalidParams(`Invalid parameter "/%d/url": cannot be empty.`, i+1)
+		}
+	} else if _, ok := script["command"]; ok && script.str("command") == "" {
+		return invalidParams(`Invalid parameter "/%d/command": cannot be empty.`, i+1)
+	}
+	if id := script.str("groupid"); id != "" && id != "0" && s.tables["hostgroups"].get(id) == nil {
+		return invalidParams(errNoPermissions)
+	}
+	return nil
+}
+
+// insertScript stores a script with the defaults of script.create.
+func (s *Server) insertScript(script object) string {
+	row := scriptDefaults.clone()
+	if script.str("type") == "0" {
+		row["execute_on"] = "2"
+	}
+	for k, v := range script {
+		if k != "scriptid" || script.str("scriptid") != "" {
+			row[k] = v
+		}
+	}
+	return s.tables["scripts"].insert(row)
+}
+
+// scriptAppliesTo reports whether script can be run on host: whether the
+// script is limited to no host group or to one of the host.
+func (s *Server) scriptAppliesTo(script, host object) bool {
+	if host == nil {
+		return false
+	}
+	groupID := script.str("groupid")
+	return groupID == "0" || slices.ContainsFunc(s.hostGroups(host), func(group object) bool {
+		return group.str("groupid") == groupID
+	})
+}
+
+// availableScripts returns the scripts of the given scope that can be run on
+// host.
+func (s *Server) availableScripts(scope string, host object) []object {
+	scripts := []object{}
+	for _, script := range s.tables["scripts"].rows {
+		if script.str("scope") == scope && s.scriptAppliesTo(script, host) {
+			scripts = append(scripts, s.tables["scripts"].project(script, nil))
+		}
+	}
+	return scripts
+}
@@ -0,0 +1,337 @@
+package zabbixtest
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strconv"
+	"time"
+
+	zabbix "github.com/nimok/nim-go-zabbix"
+)
+
+func newTables() map[string]*table {
+	// Hosts and templates share their ID sequence, as in the Zabbix database.
+	hostIDs := 10700
+	groupIDs := 22
+	interfaceIDs := 2
+	macroIDs := 1
+	proxyIDs := 1
+	proxyGroupIDs := 1
+	eventIDs := 1
+	tokenIDs := 1
+	maintenanceIDs := 1
+	serviceIDs := 1
+	slaIDs := 1
+	dashboardIDs := 1
+	dashboardPageIDs := 1
+	widgetIDs := 1
+	sysmapIDs := 1
+	selementIDs := 1
+	linkIDs := 1
+	shapeIDs := 1
+	itemIDs := 45000
+	scriptIDs := 4
+	taskIDs := 1
+
+	return map[string]*table{
+		"hosts":           newTable("hostid", &hostIDs, "groups", "templates", "tags", "macros", "inventory", "tls_psk", "tls_psk_identity"),
+		"templates":       newTable("templateid", &hostIDs, "templates", "tags"),
+		"hostgroups":      newTable("groupid", &groupIDs),
+		"interfaces":      newTable("interfaceid", &interfaceIDs),
+		"macros":          newTable("hostmacroid", &macroIDs),
+		"proxies":         newTable("proxyid", &proxyIDs, "tls_psk", "tls_psk_identity"),
+		"proxygroups":     newTable("proxy_groupid", &proxyGroupIDs),
+		"problems":        newTable("eventid", &eventIDs, "hostid", "tags", "acknowledges", "suppression_data"),
+		"tokens":          newTable("tokenid", &tokenIDs, "token"),
+		"maintenances":    newTable("maintenanceid", &maintenanceIDs, "hosts", "groups", "timeperiods", "tags"),
+		"services":        newTable("serviceid", &serviceIDs, "parents", "tags", "problem_tags", "status_rules", "alarms"),
+		"slas":            newTable("slaid", &slaIDs, "schedule", "excluded_downtimes", "service_tags"),
+		"dashboards":      newTable("dashboardid", &dashboardIDs, "users", "userGroups"),
+		"dashboard_pages": newTable("dashboard_pageid", &dashboardPageIDs, "dashboardid"),
+		"widgets":         newTable("widgetid", &widgetIDs, "dashboard_pageid"),
+		"maps":            newTable("sysmapid", &sysmapIDs, "users", "userGroups"),
+		"selements":       newTable("selementid", &selementIDs),
+		"links":           newTable("linkid", &linkIDs),
+		"shapes":          newTable("sysmap_shapeid", &shapeIDs),
+		"items":           newTable("itemid", &itemIDs),
+		"scripts":         newTable("scriptid", &scriptIDs, "output", "error"),
+		"tasks":           newTable("taskid", &taskIDs),
+	}
+}
+
+// seed adds the objects of a fresh Zabbix install.
+func (s *Server) seed() {
+	for _, group := range []object{
+		{"groupid": "1", "name": "Templates"},
+		{"groupid": "2", "name": "Linux servers"},
+		{"groupid": "4", "name": "Zabbix servers"},
+		{"groupid": "5", "name": "Discovered hosts"},
+		{"groupid": "6", "name": "Virtual machines"},
+		{"groupid": "7", "name": "Hypervisors"},
+	} {
+		s.insertHostGroup(group)
+	}
+
+	for _, template := range []object{
+		{"templateid": "10001", "host": "Linux by Zabbix agent"},
+		{"templateid": "10047", "host": "Zabbix server health"},
+		{"templateid": "10048", "host": "Zabbix proxy health"},
+		{"templateid": "10186", "host": "ICMP Ping"},
+		{"templateid": "10395", "host": "Generic by SNMP"},
+	} {
+		s.insertTemplate(template)
+	}
+
+	s.insertHost(object{
+		"hostid": "10084",
+		"host":   "Zabbix server",
+		"groups": []any{object{"groupid": "4"}},
+		"templates": []any{
+			object{"templateid": "10001"},
+			object{"templateid": "10047"},
+		},
+		"interfaces": []any{object{
+			"interfaceid": "1", "type": "1", "main": "1", "useip": "1",
+			"ip": "127.0.0.1", "dns": "", "port": "10050",
+		}},
+	})
+
+	for _, item := range []object{
+		{"itemid": "42237", "name": "Zabbix agent ping", "key_": "agent.ping", "interfaceid": "1"},
+		{"itemid": "42243", "name": "CPU utilization", "key_": "system.cpu.util", "value_type": "0", "units": "%", "interfaceid": "1"},
+		{"itemid": "42271", "name": "Mounted filesystem discovery", "key_": "vfs.fs.discovery", "value_type": "4", "flags": "1", "interfaceid": "1"},
+	} {
+		item["hostid"] = "10084"
+		s.insertItem(item)
+	}
+
+	for _, script := range []object{
+		{"scriptid": "1", "name": "Ping", "type": "0", "scope": "2", "command": "ping -c 3 {HOST.CONN}; case $? in [01]) true;; *) false;; esac"},
+		{"scriptid": "2", "name": "Traceroute", "type": "0", "scope": "2", "command": "/usr/bin/traceroute {HOST.CONN}"},
+		{"scriptid": "3", "name": "Detect operating system", "type": "0", "scope": "2", "command": "sudo /usr/bin/nmap -O {HOST.CONN}", "usrgrpid": "7"},
+	} {
+		s.insertScript(script)
+	}
+}
+
+// AddHost creates a host the same way host.create does and returns its ID.
+func (s *Server) AddHost(host zabbix.Host) (string, error) {
+	return s.add("host.create", host, "hostids")
+}
+
+// AddHostGroup creates a host group and returns its ID.
+func (s *Server) AddHostGroup(name string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.insertHostGroup(object{"name": name})
+}
+
+// AddTemplate creates a template and returns its ID.
+func (s *Server) AddTemplate(template zabbix.Template) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	o, _ := toObject(template)
+	return s.insertTemplate(o)
+}
+
+// AddProxy creates a proxy the same way proxy.create does and returns its
+// ID.
+func (s *Server) AddProxy(proxy zabbix.Proxy) (string, error) {
+	return s.add("proxy.create", proxy, "proxyids")
+}
+
+// AddProxyGroup creates a proxy group and returns its ID.
+func (s *Server) AddProxyGroup(group zabbix.ProxyGroup) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	o, _ := toObject(group)
+	return s.insertProxyGroup(o)
+}
+
+// SetInterfaceAvailability sets the availability of an interface as the
+// server does when polling it: its state, its last error and since when
+// polling fails, which are cleared if state is not unavailable.
+func (s *Server) SetInterfaceAvailability(interfaceID string, state zabbix.InterfaceState, message string, since time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	iface := s.tables["interfaces"].get(interfaceID)
+	if iface == nil {
+		return fmt.Errorf("no interface %s", interfaceID)
+	}
+	iface["available"] = strconv.Itoa(int(state))
+	iface["error"], iface["errors_from"] = "", "0"
+	if state == zabbix.InterfaceStateUnavailable {
+		iface["error"], iface["errors_from"] = message, strconv.FormatInt(since.Unix(), 10)
+	}
+	return nil
+}
+
+// SetActiveAvailability sets the availability of the active checks of a
+// host.
+func (s *Server) SetActiveAvailability(hostID string, state zabbix.InterfaceState) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	host := s.tables["hosts"].get(hostID)
+	if host == nil {
+		return fmt.Errorf("no host %s", hostID)
+	}
+	host["active_available"] = strconv.Itoa(int(state))
+	return nil
+}
+
+// AddService creates a service the same way service.create does and returns
+// its ID.
+func (s *Server) AddService(service zabbix.Service) (string, error) {
+	o, err := toObject(service)
+	if err != nil {
+		return "", err
+	}
+	o["algorithm"], o["sortorder"] = strconv.Itoa(int(service.Algorithm)), strconv.Itoa(int(service.SortOrder))
+	return s.add("service.create", o, "serviceids")
+}
+
+// AddSLA creates an SLA the same way sla.create does and returns its ID.
+func (s *Server) AddSLA(sla zabbix.SLA) (string, error) {
+	o, err := toObject(sla)
+	if err != nil {
+		return "", err
+	}
+	o["period"], o["slo"] = strconv.Itoa(int(sla.Period)), strconv.FormatFloat(float64(sla.SLO), 'f', -1, 64)
+	return s.add("sla.create", o, "slaids")
+}
+
+// SetServiceStatus sets the status of a service from since on, as the
+// server does when problems affecting it start or end: zabbix.ServiceStatusOK
+// or the severity of its problems. sla.getsli counts the time a service is
+// not OK as downtime.
+func (s *Server) SetServiceStatus(serviceID string, status int, since time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	service := s.tables["services"].get(serviceID)
+	if service == nil {
+		return fmt.Errorf("no service %s", serviceID)
+	}
+	value := strconv.Itoa(status)
+	alarms := append(listOrEmpty(service["alarms"]), object{"clock": strconv.FormatInt(since.Unix(), 10), "value": value})
+	slices.SortStableFunc(alarms, func(a, b any) int {
+		return compareValues(a.(object).str("clock"), b.(object).str("clock"))
+	})
+	service["alarms"] = alarms
+	service["status"] = alarms[len(alarms)-1].(object).str("value")
+	return nil
+}
+
+// AddProblem adds a problem on the given host and returns its event ID.
+// Unresolved problems should leave REventID empty.
+func (s *Server) AddProblem(hostID string, problem zabbix.Problem) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	o, _ := toObject(problem)
+	o["hostid"] = hostID
+	if o.str("eventid") == "" {
+		delete(o, "eventid")
+	}
+	if o.str("ns") == "" {
+		o["ns"] = strconv.Itoa(problem.Clock.Nanosecond())
+	}
+	if o.str("r_ns") == "" {
+		o["r_ns"] = strconv.Itoa(problem.RClock.Nanosecond())
+	}
+	for _, field := range []string{"source", "object", "objectid", "r_eventid", "cause_eventid", "correlationid", "userid"} {
+		if o.str(field) == "" {
+			o[field] = "0"
+		}
+	}
+	for _, field := range []string{"tags", "acknowledges", "suppression_data"} {
+		if _, ok := o[field]; !ok {
+			o[field] = []any{}
+		}
+	}
+	return s.tables["problems"].insert(o)
+}
+
+// AddItem adds an item to a host and returns its ID. Items with Flags 1 are
+// LLD rules, which item.get doesn't return but check now tasks accept.
+func (s *Server) AddItem(hostID string, item zabbix.Item) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.tables["hosts"].get(hostID) == nil {
+		return "", fmt.Errorf("no host %s", hostID)
+	}
+	o, err := toObject(item)
+	if err != nil {
+		return "", err
+	}
+	o["hostid"] = hostID
+	return s.insertItem(o), nil
+}
+
+// SetScriptOutput sets the output script.execute returns for a script, which
+// is empty by default.
+func (s *Server) SetScriptOutput(scriptID, output string) error {
+	return s.setScript(scriptID, "output", output)
+}
+
+// SetScriptError makes script.execute fail for a script with message, as
+// when the script exits with an error, or succeed again if message is empty.
+func (s *Server) SetScriptError(scriptID, message string) error {
+	return s.setScript(scriptID, "error", message)
+}
+
+func (s *Server) setScript(scriptID, field, value string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	script := s.tables["scripts"].get(scriptID)
+	if script == nil {
+		return fmt.Errorf("no script %s", scriptID)
+	}
+	script[field] = value
+	return nil
+}
+
+// add runs a create method with v as params and returns the first created
+// ID.
+func (s *Server) add(method string, v any, idsKey string) (string, error) {
+	params, err := json.Marshal(v)
+	if err != nil {
+		return "", err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	result, apiErr := methods[method](s, &request{method: method, params: params, userID: DefaultUserID})
+	if apiErr != nil {
+		return "", apiErr
+	}
+	return result.(object)[idsKey].([]string)[0], nil
+}
+
+// toObject converts v to its API representation.
+func toObject(v any) (object, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	var o any
+	if err := decodeParams(data, &o); err != nil {
+		return nil, err
+	}
+
+	obj, ok := normalize(o).(object)
+	if !ok {
+		return nil, fmt.Errorf("%T is not an object", v)
+	}
+	return obj, nil
+}
@@ -0,0 +1,386 @@
+// Package zabbixtest provides an in-memory Zabbix API server for unit tests.
+//
+// The server speaks the JSON-RPC protocol of the Zabbix frontend and
+// implements user.login, user.logout, host.*, hostgroup.get,
+// hostgroup.create, hostgroup.delete, hostinterface.*, item.get,
+// template.get, template.update, proxy.*, proxygroup.get, problem.get,
+// maintenance.create, dashboard.*, map.*, script.*, service.*, sla.*, task.*
+// and token.* against in-memory state. It starts with the objects of a
+// fresh Zabbix install (the "Zabbix server" host and a few of its items, the
+// default host groups, templates and global scripts) and the Admin/zabbix
+// user, so code written against a real server can be pointed at it
+// unchanged:
+//
+//	srv := zabbixtest.NewServer()
+//	defer srv.Close()
+//
+//	client, err := zabbix.NewClient(srv.URL, zabbix.WithUserPass(zabbixtest.DefaultUsername, zabbixtest.DefaultPassword))
+//
+// Check now tasks are processed by the item.get that follows them, which
+// sees the items with their old value; later calls see a new one.
+//
+// Only the parameters commonly used by this library are honoured; others are
+// ignored rather than rejected.
+package zabbixtest
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+)
+
+// Credentials of the super admin user every server starts with.
+const (
+	DefaultUsername = "Admin"
+	DefaultPassword = "zabbix"
+	DefaultUserID   = "1"
+)
+
+// DefaultVersion is the version reported by apiinfo.version.
+const DefaultVersion = "7.0.0"
+
+// JSON-RPC error codes returned by the Zabbix API.
+const (
+	CodeParseError     = -32700
+	CodeInvalidRequest = -32600
+	CodeMethodNotFound = -32601
+	CodeInvalidParams  = -32602
+	CodeInternalError  = -32603
+	CodeApplication    = -32500
+)
+
+var errorMessages = map[int]string{
+	CodeParseError:     "Parse error.",
+	CodeInvalidRequest: "Invalid request.",
+	CodeMethodNotFound: "Method not found.",
+	CodeInvalidParams:  "Invalid params.",
+	CodeInternalError:  "Internal error.",
+	CodeApplication:    "Application error.",
+}
+
+// Error messages shared by several methods.
+const (
+	errNoPermissions = "No permissions to referred object or it does not exist!"
+	errNotAuthorized = "Not authorized."
+	errSession       = "Session terminated, re-login, please."
+)
+
+// apiError is a JSON-RPC error returned by a method handler.
+type apiError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+	Data    string `json:"data"`
+}
+
+func (e *apiError) Error() string {
+	return fmt.Sprintf("%d - %s %s", e.Code, e.Message, e.Data)
+}
+
+func newError(code int, format string, args ...any) *apiError {
+	return &apiError{
+		Code:    code,
+		Message: errorMessages[code],
+		Data:    fmt.Sprintf(format, args...),
+	}
+}
+
+func invalidParams(format string, args ...any) *apiError {
+	return newError(CodeInvalidParams, format, args...)
+}
+
+// request is the state available to a method handler.
+type request struct {
+	method  string
+	params  json.RawMessage
+	userID  string
+	session string // Session ID the call was authenticated with, if any
+}
+
+type handlerFunc func(s *Server, req *request) (any, *apiError)
+
+// Server is an in-memory Zabbix API server. All methods are safe for
+// concurrent use.
+type Server struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	version  string
+	users    map[string]*user  // by username
+	sessions map[string]string // session ID to user ID
+	tables   map[string]*table
+	calls    []string
+}
+
+type user struct {
+	userID   string
+	username string
+	password string
+}
+
+// NewServer starts a server seeded with the objects of a fresh Zabbix
+// install. The caller should call Close when finished.
+func NewServer() *Server {
+	s := &Server{
+		version:  DefaultVersion,
+		users:    make(map[string]*user),
+		sessions: make(map[string]string),
+		tables:   newTables(),
+	}
+	s.users[DefaultUsername] = &user{userID: DefaultUserID, username: DefaultUsername, password: DefaultPassword}
+	s.seed()
+
+	s.Server = httptest.NewServer(s)
+	return s
+}
+
+// SetVersion sets the version reported by apiinfo.version.
+func (s *Server) SetVersion(version string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.version = version
+}
+
+// AddUser adds a user that can log in with user.login and returns its ID.
+func (s *Server) AddUser(username, password string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	id := fmt.Sprint(len(s.users) + 1)
+	s.users[username] = &user{userID: id, username: username, password: password}
+	return id
+}
+
+// Calls returns the methods called so far, in order.
+func (s *Server) Calls() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]string(nil), s.calls...)
+}
+
+var methods = map[string]handlerFunc{
+	"apiinfo.version": (*Server).apiinfoVersion,
+
+	"user.login":  (*Server).userLogin,
+	"user.logout": (*Server).userLogout,
+
+	"host.get":     (*Server).hostGet,
+	"host.create":  (*Server).hostCreate,
+	"host.update":  (*Server).hostUpdate,
+	"host.delete":  (*Server).hostDelete,
+	"host.massadd": (*Server).hostMassAdd,
+
+	"dashboard.get":    (*Server).dashboardGet,
+	"dashboard.create": (*Server).dashboardCreate,
+	"dashboard.update": (*Server).dashboardUpdate,
+	"dashboard.delete": (*Server).dashboardDelete,
+
+	"hostgroup.get":    (*Server).hostgroupGet,
+	"hostgroup.create": (*Server).hostgroupCreate,
+	"hostgroup.delete": (*Server).hostgroupDelete,
+
+	"hostinterface.get":    (*Server).hostinterfaceGet,
+	"hostinterface.create": (*Server).hostinterfaceCreate,
+	"hostinterface.update": (*Server).hostinterfaceUpdate,
+	"hostinterface.delete": (*Server).hostinterfaceDelete,
+
+	"template.get":    (*Server).templateGet,
+	"template.update": (*Server).templateUpdate,
+
+	"proxy.get":    (*Server).proxyGet,
+	"proxy.create": (*Server).proxyCreate,
+	"proxy.update": (*Server).proxyUpdate,
+	"proxy.delete": (*Server).proxyDelete,
+
+	"proxygroup.get": (*Server).proxygroupGet,
+
+	"item.get": (*Server).itemGet,
+
+	"problem.get": (*Server).problemGet,
+
+	"maintenance.create": (*Server).maintenanceCreate,
+
+	"script.get":                (*Server).scriptGet,
+	"script.create":             (*Server).scriptCreate,
+	"script.update":             (*Server).scriptUpdate,
+	"script.delete":             (*Server).scriptDelete,
+	"script.execute":            (*Server).scriptExecute,
+	"script.getscriptsbyhosts":  (*Server).scriptGetscriptsbyhosts,
+	"script.getscriptsbyevents": (*Server).scriptGetscriptsbyevents,
+
+	"task.get":    (*Server).taskGet,
+	"task.create": (*Server).taskCreate,
+
+	"map.get":    (*Server).mapGet,
+	"map.create": (*Server).mapCreate,
+	"map.update": (*Server).mapUpdate,
+	"map.delete": (*Server).mapDelete,
+
+	"service.get":    (*Server).serviceGet,
+	"service.create": (*Server).serviceCreate,
+	"service.update": (*Server).serviceUpdate,
+	"service.delete": (*Server).serviceDelete,
+
+	"sla.get":    (*Server).slaGet,
+	"sla.create": (*Server).slaCreate,
+	"sla.update": (*Server).slaUpdate,
+	"sla.delete": (*Server).slaDelete,
+	"sla.getsli": (*Server).slaGetsli,
+
+	"token.get":      (*Server).tokenGet,
+	"token.create":   (*Server).tokenCreate,
+	"token.update":   (*Server).tokenUpdate,
+	"token.generate": (*Server).tokenGenerate,
+	"token.delete":   (*Server).tokenDelete,
+}
+
+// Methods that are called without authentication.
+var unauthenticated = map[string]bool{
+	"apiinfo.version": true,
+	"user.login":      true,
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var rpc struct {
+		JSONRPC string          `json:"jsonrpc"`
+		Method  string          `json:"method"`
+		Params  json.RawMessage `json:"params"`
+		ID      any             `json:"id"`
+	}
+
+	var result any
+	apiErr := func() *apiError {
+		if err := json.NewDecoder(r.Body).Decode(&rpc); err != nil {
+			return newError(CodeParseError, "Invalid JSON. An error occurred on the server while parsing the JSON text.")
+		}
+		if rpc.JSONRPC != "2.0" {
+			return newError(CodeInvalidRequest, `Invalid parameter "/jsonrpc": value must be "2.0".`)
+		}
+
+		handler, ok := methods[rpc.Method]
+		if !ok {
+			return newError(CodeMethodNotFound, "Incorrect method %q.", rpc.Method)
+		}
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		s.calls = append(s.calls, rpc.Method)
+
+		req := &request{method: rpc.Method, params: rpc.Params}
+		if rpc.Method == "apiinfo.version" && r.Header.Get("Authorization") != "" {
+			return invalidParams(`The "apiinfo.version" method must be called without the "auth" parameter.`)
+		}
+		if !unauthenticated[rpc.Method] {
+			userID, err := s.authenticate(r)
+			if err != nil {
+				return err
+			}
+			req.userID = userID
+			if bearer := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer "); s.sessions[bearer] != "" {
+				req.session = bearer
+			}
+		}
+
+		var err *apiError
+		result, err = handler(s, req)
+		return err
+	}()
+
+	response := map[string]any{
+		"jsonrpc": "2.0",
+		"id":      rpc.ID,
+	}
+	if apiErr != nil {
+		response["error"] = apiErr
+	} else {
+		response["result"] = result
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// authenticate resolves the bearer token of r, either a session ID or an API
+// token, to a user ID.
+func (s *Server) authenticate(r *http.Request) (string, *apiError) {
+	bearer, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !ok || bearer == "" {
+		return "", invalidParams(errNotAuthorized)
+	}
+
+	if userID, ok := s.sessions[bearer]; ok {
+		return userID, nil
+	}
+
+	for _, token := range s.tables["tokens"].rows {
+		if token["token"] == bearer {
+			if token["status"] != "0" {
+				return "", invalidParams("API token is disabled.")
+			}
+			return token["userid"].(string), nil
+		}
+	}
+
+	return "", invalidParams(errSession)
+}
+
+func (s *Server) apiinfoVersion(req *request) (any, *apiError) {
+	return s.version, nil
+}
+
+func (s *Server) userLogin(req *request) (any, *apiError) {
+	var params struct {
+		Username string `json:"username"`
+		Password string `json:"password"`
+	}
+	if err := decodeParams(req.params, &params); err != nil {
+		return nil, err
+	}
+
+	u, ok := s.users[params.Username]
+	if !ok || u.password != params.Password {
+		return nil, newError(CodeApplication, "Incorrect user name or password or account is temporarily blocked.")
+	}
+
+	session := randomHex(16)
+	s.sessions[session] = u.userID
+	return session, nil
+}
+
+// userLogout ends the session the call was made with; other sessions of the
+// user stay valid.
+func (s *Server) userLogout(req *request) (any, *apiError) {
+	delete(s.sessions, req.session)
+	return true, nil
+}
+
+func decodeParams(data json.RawMessage, v any) *apiError {
+	if len(data) == 0 {
+		return invalidParams(`Invalid parameter "/": an array or object is expected.`)
+	}
+
+	decoder := json.NewDecoder(strings.NewReader(string(data)))
+	decoder.UseNumber()
+	if err := decoder.Decode(v); err != nil {
+		return invalidParams("Invalid parameter \"/\": %v.", err)
+	}
+	return nil
+}
+
+func randomHex(n int) string {
+	b := make([]byte, n)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
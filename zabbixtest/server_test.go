@@ -0,0 +1,680 @@
+package zabbixtest_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	zabbix "github.com/nimok/nim-go-zabbix"
+	"github.com/nimok/nim-go-zabbix/zabbixtest"
+)
+
+func newClient(t *testing.T, srv *zabbixtest.Server) zabbix.Client {
+	t.Helper()
+
+	client, err := zabbix.NewClient(srv.URL, zabbix.WithUserPass(zabbixtest.DefaultUsername, zabbixtest.DefaultPassword))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := client.Authenticate(); err != nil {
+		t.Fatal(err)
+	}
+	return client
+}
+
+func agentInterface(ip string) zabbix.HostInterface {
+	return zabbix.HostInterface{
+		Type:  zabbix.InterfaceTypeAgent,
+		Main:  zabbix.MainInterfaceYes,
+		UseIP: zabbix.UseIPOptionIP,
+		IP:    ip,
+		Port:  "10050",
+	}
+}
+
+func TestLogin(t *testing.T) {
+	srv := zabbixtest.NewServer()
+	defer srv.Close()
+
+	client, err := zabbix.NewClient(srv.URL, zabbix.WithUserPass(zabbixtest.DefaultUsername, "wrong"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := client.Authenticate(); err == nil {
+		t.Fatal("expected authentication to fail with a wrong password")
+	}
+
+	srv.AddUser("guest", "secret")
+	client, err = zabbix.NewClient(srv.URL, zabbix.WithUserPass("guest", "secret"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := client.Authenticate(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.HostgroupGet(context.Background(), zabbix.HostGroupGetParameters{}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestSeed(t *testing.T) {
+	srv := zabbixtest.NewServer()
+	defer srv.Close()
+
+	client := newClient(t, srv)
+
+	hosts, err := client.HostGet(context.Background(), zabbix.HostGetParameters{
+		GetParameters:         zabbix.GetParameters{Filter: map[string]any{"host": "Zabbix server"}},
+		SelectInterfaces:      "extend",
+		SelectParentTemplates: []string{"host"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(hosts) != 1 {
+		t.Fatalf("got %d hosts, want 1", len(hosts))
+	}
+	if hosts[0].HostID != "10084" || len(hosts[0].Interfaces) != 1 || len(hosts[0].ParentTemplates) != 2 {
+		t.Errorf("unexpected host: %+v", hosts[0])
+	}
+}
+
+func TestHostLifecycle(t *testing.T) {
+	ctx := context.Background()
+	srv := zabbixtest.NewServer()
+	defer srv.Close()
+
+	client := newClient(t, srv)
+
+	created, err := client.HostCreate(ctx, []zabbix.Host{{
+		Host:       "web-01",
+		Groups:     []zabbix.HostGroup{{GroupID: "2"}},
+		Templates:  []zabbix.Template{{TemplateID: "10001"}},
+		Interfaces: []zabbix.HostInterface{agentInterface("10.0.0.1")},
+		Tags:       []zabbix.Tag{{Tag: "env", Value: "prod"}},
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	hostID := created.HostIDs[0]
+	if hostID != "10700" {
+		t.Errorf("got host ID %s, want 10700", hostID)
+	}
+
+	_, err = client.HostCreate(ctx, []zabbix.Host{{
+		Host:   "web-01",
+		Groups: []zabbix.HostGroup{{GroupID: "2"}},
+	}})
+	if err == nil || !strings.Contains(err.Error(), `Host with the same name "web-01" already exists.`) {
+		t.Errorf("got %v, want a duplicate host error", err)
+	}
+
+	status := 1
+	if _, err := client.HostUpdate(ctx, zabbix.Host{HostID: hostID, Name: "Web 01", Status: &status}); err != nil {
+		t.Fatal(err)
+	}
+
+	hosts, err := client.HostGet(ctx, zabbix.HostGetParameters{
+		HostIDs:      []string{hostID},
+		SelectGroups: "extend",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(hosts) != 1 {
+		t.Fatalf("got %d hosts, want 1", len(hosts))
+	}
+	host := hosts[0]
+	if host.Name != "Web 01" || host.Status == nil || *host.Status != 1 {
+		t.Errorf("update not applied: %+v", host)
+	}
+	if len(host.Groups) != 1 || host.Groups[0].Name != "Linux servers" {
+		t.Errorf("got groups %+v, want Linux servers", host.Groups)
+	}
+
+	groups, err := client.HostgroupGet(ctx, zabbix.HostGroupGetParameters{HostIDs: []string{hostID}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(groups) != 1 || groups[0].GroupID != "2" {
+		t.Errorf("got groups %+v, want group 2", groups)
+	}
+
+	if _, err := client.HostDelete(ctx, []string{hostID}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.HostDelete(ctx, []string{hostID}); err == nil {
+		t.Error("expected deleting a deleted host to fail")
+	}
+
+	ifaces, err := client.HostInterfaceGet(ctx, zabbix.HostInterfaceGetParams{HostIDs: []string{hostID}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ifaces) != 0 {
+		t.Errorf("interfaces of deleted host were kept: %+v", ifaces)
+	}
+}
+
+func TestHostCreateValidation(t *testing.T) {
+	srv := zabbixtest.NewServer()
+	defer srv.Close()
+
+	client := newClient(t, srv)
+
+	tests := []struct {
+		name string
+		host zabbix.Host
+		want string
+	}{
+		{
+			name: "missing groups",
+			host: zabbix.Host{Host: "h"},
+			want: `the parameter "groups" is missing`,
+		},
+		{
+			name: "unknown group",
+			host: zabbix.Host{Host: "h", Groups: []zabbix.HostGroup{{GroupID: "999"}}},
+			want: "No permissions to referred object or it does not exist!",
+		},
+		{
+			name: "missing port",
+			host: zabbix.Host{
+				Host:   "h",
+				Groups: []zabbix.HostGroup{{GroupID: "2"}},
+				Interfaces: []zabbix.HostInterface{{
+					Type: zabbix.InterfaceTypeAgent, Main: zabbix.MainInterfaceYes, UseIP: zabbix.UseIPOptionIP, IP: "127.0.0.1",
+				}},
+			},
+			want: `Invalid parameter "/1/interfaces/1/port": cannot be empty.`,
+		},
+		{
+			name: "two main interfaces",
+			host: zabbix.Host{
+				Host:       "h",
+				Groups:     []zabbix.HostGroup{{GroupID: "2"}},
+				Interfaces: []zabbix.HostInterface{agentInterface("10.0.0.1"), agentInterface("10.0.0.2")},
+			},
+			want: "more than one default interface",
+		},
+		{
+			name: "unknown proxy",
+			host: zabbix.Host{
+				Host:        "h",
+				Groups:      []zabbix.HostGroup{{GroupID: "2"}},
+				MonitoredBy: 1,
+				ProxyID:     "42",
+			},
+			want: "No permissions to referred object or it does not exist!",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := client.HostCreate(context.Background(), []zabbix.Host{tt.host})
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("got %v, want error containing %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestProxyInUse(t *testing.T) {
+	ctx := context.Background()
+	srv := zabbixtest.NewServer()
+	defer srv.Close()
+
+	proxyID, err := srv.AddProxy(zabbix.Proxy{Name: "proxy-01", OperatingMode: 0})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := srv.AddHost(zabbix.Host{
+		Host:        "behind-proxy",
+		Groups:      []zabbix.HostGroup{{GroupID: "2"}},
+		MonitoredBy: 1,
+		ProxyID:     proxyID,
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	client := newClient(t, srv)
+
+	hosts, err := client.HostGet(ctx, zabbix.HostGetParameters{ProxyIDs: []string{proxyID}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(hosts) != 1 || hosts[0].Host != "behind-proxy" {
+		t.Errorf("unexpected hosts: %+v", hosts)
+	}
+
+	_, err = client.ProxyDelete(ctx, []string{proxyID})
+	if err == nil || !strings.Contains(err.Error(), `Proxy "proxy-01" is used by host "behind-proxy".`) {
+		t.Errorf("got %v, want a proxy in use error", err)
+	}
+}
+
+func TestHostGroupInUse(t *testing.T) {
+	ctx := context.Background()
+	srv := zabbixtest.NewServer()
+	defer srv.Close()
+
+	groupID := srv.AddHostGroup("only-group")
+	if _, err := srv.AddHost(zabbix.Host{Host: "grouped", Groups: []zabbix.HostGroup{{GroupID: groupID}}}); err != nil {
+		t.Fatal(err)
+	}
+
+	client := newClient(t, srv)
+
+	if _, err := client.HostgroupCreate(ctx, []zabbix.HostGroup{{Name: "only-group"}}); err == nil || !strings.Contains(err.Error(), `Host group "only-group" already exists.`) {
+		t.Errorf("got %v, want a duplicate host group error", err)
+	}
+
+	_, err := client.HostgroupDelete(ctx, []string{groupID})
+	if err == nil || !strings.Contains(err.Error(), `Host "grouped" cannot be without host group.`) {
+		t.Errorf("got %v, want a host group in use error", err)
+	}
+}
+
+func TestProxyGroupGet(t *testing.T) {
+	ctx := context.Background()
+	srv := zabbixtest.NewServer()
+	defer srv.Close()
+
+	groupID := srv.AddProxyGroup(zabbix.ProxyGroup{Name: "proxies-eu"})
+	if _, err := srv.AddProxy(zabbix.Proxy{Name: "proxy-eu-01", ProxyGroupID: groupID, LocalAddress: "10.0.0.1"}); err != nil {
+		t.Fatal(err)
+	}
+
+	client := newClient(t, srv)
+
+	groups, err := client.ProxyGroupGet(ctx, zabbix.ProxyGroupGetParameters{
+		GetParameters: zabbix.GetParameters{Filter: map[string]any{"name": "proxies-eu"}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(groups) != 1 || groups[0].ProxyGroupID != groupID || groups[0].FailoverDelay != "1m" {
+		t.Errorf("unexpected proxy groups: %+v", groups)
+	}
+}
+
+func TestAPIVersion(t *testing.T) {
+	ctx := context.Background()
+	srv := zabbixtest.NewServer()
+	defer srv.Close()
+	srv.SetVersion("6.4.12")
+
+	client, err := zabbix.NewClient(srv.URL,
+		zabbix.WithUserPass(zabbixtest.DefaultUsername, zabbixtest.DefaultPassword),
+		zabbix.WithCompatibility(zabbix.CompatibilityStrict))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := client.Authenticate(); err != nil {
+		t.Fatal(err)
+	}
+
+	// Called after the login, without the bearer token
+	version, err := client.APIVersion(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if version != (zabbix.Version{Major: 6, Minor: 4, Patch: 12}) {
+		t.Errorf("got version %s", version)
+	}
+
+	var unsupported *zabbix.UnsupportedError
+	if _, err := client.ProxyGroupGet(ctx, zabbix.ProxyGroupGetParameters{}); !errors.As(err, &unsupported) {
+		t.Errorf("got %v, want an *UnsupportedError", err)
+	}
+	if calls := srv.Calls(); strings.Join(calls, " ") != "user.login apiinfo.version" {
+		t.Errorf("unexpected calls %v", calls)
+	}
+}
+
+func TestProblemGet(t *testing.T) {
+	ctx := context.Background()
+	srv := zabbixtest.NewServer()
+	defer srv.Close()
+
+	now := time.Now().Truncate(time.Second)
+	open := srv.AddProblem("10084", zabbix.Problem{
+		Name:     "High CPU",
+		Clock:    now.Add(-time.Hour),
+		Severity: zabbix.SeverityHigh,
+		Tags:     []zabbix.ProblemTag{{Tag: "service", Value: "web"}},
+	})
+	srv.AddProblem("10084", zabbix.Problem{
+		Name:     "Disk full",
+		Clock:    now.Add(-2 * time.Hour),
+		REventID: "100",
+		RClock:   now.Add(-time.Hour),
+		Severity: zabbix.SeverityAverage,
+	})
+
+	client := newClient(t, srv)
+
+	problems, err := client.ProblemGet(ctx, zabbix.ProblemGetParams{
+		HostIDs:    []string{"10084"},
+		Severities: []int{int(zabbix.SeverityHigh)},
+		Tags:       []zabbix.ProblemGetTag{{Tag: "service", Value: "web"}},
+		SelectTags: "extend",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(*problems) != 1 {
+		t.Fatalf("got %d problems, want 1", len(*problems))
+	}
+
+	p := (*problems)[0]
+	if p.EventID != open || !p.Clock.Equal(now.Add(-time.Hour)) || p.Severity != zabbix.SeverityHigh || len(p.Tags) != 1 {
+		t.Errorf("unexpected problem: %+v", p)
+	}
+}
+
+func TestTokenAuth(t *testing.T) {
+	ctx := context.Background()
+	srv := zabbixtest.NewServer()
+	defer srv.Close()
+
+	client := newClient(t, srv)
+
+	created, err := client.TokenCreate(ctx, zabbix.Token{Name: "ci"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.TokenCreate(ctx, zabbix.Token{Name: "ci"}); err == nil {
+		t.Error("expected a duplicate token name to fail")
+	}
+
+	generated, err := client.TokenGenerate(ctx, created.TokenIDs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(generated) != 1 || len(generated[0].Token) != 64 {
+		t.Fatalf("unexpected generated tokens: %+v", generated)
+	}
+
+	tokenClient, err := zabbix.NewClient(srv.URL, zabbix.WithAPIToken(generated[0].Token))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := tokenClient.Authenticate(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := tokenClient.HostGet(ctx, zabbix.HostGetParameters{}); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := client.TokenDelete(ctx, created.TokenIDs); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := tokenClient.HostGet(ctx, zabbix.HostGetParameters{}); err == nil {
+		t.Error("expected a deleted token to be rejected")
+	}
+}
+
+func TestLogoutEndsOnlyItsSession(t *testing.T) {
+	ctx := context.Background()
+	srv := zabbixtest.NewServer()
+	defer srv.Close()
+
+	first := newClient(t, srv)
+	second := newClient(t, srv)
+
+	if _, err := first.Logout(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := second.HostGet(ctx, zabbix.HostGetParameters{}); err != nil {
+		t.Errorf("expected the other session of the user to stay valid, got %v", err)
+	}
+	if _, err := first.HostGet(ctx, zabbix.HostGetParameters{}); err == nil {
+		t.Error("expected the logged out session to be rejected")
+	}
+}
+
+func TestSLAGetSLI(t *testing.T) {
+	ctx := context.Background()
+	srv := zabbixtest.NewServer()
+	defer srv.Close()
+
+	client := newClient(t, srv)
+
+	serviceID, err := srv.AddService(zabbix.Service{Name: "Checkout", Tags: []zabbix.Tag{{Tag: "sla", Value: "shop"}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := srv.AddService(zabbix.Service{Name: "Internal", Tags: []zabbix.Tag{{Tag: "sla", Value: "internal"}}}); err != nil {
+		t.Fatal(err)
+	}
+
+	day := time.Date(2025, 3, 10, 0, 0, 0, 0, time.UTC)
+	for _, change := range []struct {
+		status int
+		at     time.Duration
+	}{
+		{int(zabbix.SeverityHigh), 10 * time.Hour},
+		{zabbix.ServiceStatusOK, 11 * time.Hour},
+		{int(zabbix.SeverityHigh), 25 * time.Hour}, // During the excluded downtime
+		{zabbix.ServiceStatusOK, 26 * time.Hour},
+	} {
+		if err := srv.SetServiceStatus(serviceID, change.status, day.Add(change.at)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := srv.SetServiceStatus("999", zabbix.ServiceStatusOK, day); err == nil {
+		t.Error("expected an unknown service to fail")
+	}
+
+	slaID, err := srv.AddSLA(zabbix.SLA{
+		Name:        "Shop",
+		Period:      zabbix.SLAPeriodDaily,
+		SLO:         99,
+		ServiceTags: []zabbix.SLAServiceTag{{Tag: "sla", Operator: zabbix.TagOperatorLike, Value: "SHO"}},
+		ExcludedDowntimes: []zabbix.SLAExcludedDowntime{{
+			Name:       "Upgrade",
+			PeriodFrom: zabbix.FlexInt64(day.Add(24 * time.Hour).Unix()),
+			PeriodTo:   zabbix.FlexInt64(day.Add(30 * time.Hour).Unix()),
+		}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sli, err := client.SLAGetSLI(ctx, zabbix.SLAGetSLIParameters{
+		SLAID:      slaID,
+		PeriodFrom: day.Unix(),
+		PeriodTo:   day.Add(36 * time.Hour).Unix(),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(sli.ServiceIDs) != 1 || sli.ServiceIDs[0] != serviceID {
+		t.Fatalf("unexpected service IDs %v", sli.ServiceIDs)
+	}
+	if len(sli.Periods) != 2 || !sli.Periods[0].From.Equal(day) || !sli.Periods[1].To.Equal(day.Add(48*time.Hour)) {
+		t.Fatalf("unexpected periods %+v", sli.Periods)
+	}
+
+	results := sli.Results()
+	if len(results) != 2 {
+		t.Fatalf("expected 2 results, got %d", len(results))
+	}
+	first, second := results[0].SLI, results[1].SLI
+	if first.Uptime != 23*time.Hour || first.Downtime != time.Hour || first.ErrorBudget != 864*time.Second-time.Hour {
+		t.Errorf("unexpected first SLI %+v", first)
+	}
+	if first.SLI < 95.83 || first.SLI > 95.84 {
+		t.Errorf("got SLI %f, want 95.83", first.SLI)
+	}
+	if second.Uptime != 18*time.Hour || second.Downtime != 0 || second.SLI != 100 || len(second.ExcludedDowntimes) != 1 {
+		t.Errorf("unexpected second SLI %+v", second)
+	}
+
+	services, err := client.ServiceGet(ctx, zabbix.ServiceGetParameters{SLAIDs: []string{slaID}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(services) != 1 || services[0].ServiceID != serviceID || services[0].Status != zabbix.ServiceStatusOK {
+		t.Errorf("unexpected services %+v", services)
+	}
+
+	srv.SetVersion("5.4.0")
+	strict, err := zabbix.NewClient(srv.URL,
+		zabbix.WithUserPass(zabbixtest.DefaultUsername, zabbixtest.DefaultPassword),
+		zabbix.WithCompatibility(zabbix.CompatibilityStrict))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := strict.Authenticate(); err != nil {
+		t.Fatal(err)
+	}
+	var unsupported *zabbix.UnsupportedError
+	if _, err := strict.SLAGetSLI(ctx, zabbix.SLAGetSLIParameters{SLAID: slaID}); !errors.As(err, &unsupported) {
+		t.Errorf("got %v, want an *UnsupportedError", err)
+	}
+}
+
+func TestDashboardReferences(t *testing.T) {
+	ctx := context.Background()
+	srv := zabbixtest.NewServer()
+	defer srv.Close()
+
+	client := newClient(t, srv)
+
+	// Widgets must reference existing hosts
+	_, err := client.DashboardCreate(ctx, []zabbix.Dashboard{{
+		Name:  "Team",
+		Pages: []zabbix.DashboardPage{{Widgets: []zabbix.DashboardWidget{zabbix.NewProblemsWidget([]string{"999"}, nil)}}},
+	}})
+	if err == nil || !strings.Contains(err.Error(), "No permissions") {
+		t.Errorf("expected a permission error, got %v", err)
+	}
+
+	// Maps can only be deleted once
+	resp, err := client.MapCreate(ctx, []zabbix.Map{{
+		Name:      "Team",
+		Width:     800,
+		Height:    600,
+		Selements: []zabbix.MapElement{{ElementType: zabbix.MapElementTypeImage, IconIDOff: "2"}},
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.MapDelete(ctx, resp.SysmapIDs); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.MapDelete(ctx, resp.SysmapIDs); err == nil {
+		t.Error("expected deleting a deleted map to fail")
+	}
+}
+
+func TestScriptExecute(t *testing.T) {
+	ctx := context.Background()
+	srv := zabbixtest.NewServer()
+	defer srv.Close()
+
+	client := newClient(t, srv)
+
+	resp, err := client.ScriptCreate(ctx, []zabbix.Script{{
+		Name:    "Restart web",
+		Type:    zabbix.ScriptTypeWebhook,
+		Scope:   zabbix.ScriptScopeEvent,
+		Command: "return 'restarted';",
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	scriptID := resp.ScriptIDs[0]
+	if err := srv.SetScriptOutput(scriptID, "restarted"); err != nil {
+		t.Fatal(err)
+	}
+
+	eventID := srv.AddProblem("10084", zabbix.Problem{Name: "Web down", Severity: zabbix.SeverityHigh})
+	byEvent, err := client.ScriptGetScriptsByEvents(ctx, []string{eventID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(byEvent[eventID]) != 1 || byEvent[eventID][0].ScriptID != scriptID {
+		t.Errorf("expected only script %s for event %s, got %+v", scriptID, eventID, byEvent)
+	}
+
+	result, err := client.ScriptExecute(ctx, zabbix.ScriptExecuteParameters{ScriptID: scriptID, EventID: eventID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Value != "restarted" || result.Debug == nil {
+		t.Errorf("unexpected result %+v", result)
+	}
+
+	if err := srv.SetScriptError(scriptID, "Connection refused"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.ScriptExecute(ctx, zabbix.ScriptExecuteParameters{ScriptID: scriptID, EventID: eventID}); err == nil || !strings.Contains(err.Error(), "Connection refused") {
+		t.Errorf("expected the script to fail, got %v", err)
+	}
+}
+
+func TestCheckNowDiscoveryRule(t *testing.T) {
+	ctx := context.Background()
+	srv := zabbixtest.NewServer()
+	defer srv.Close()
+
+	client := newClient(t, srv)
+
+	ruleID, err := srv.AddItem("10084", zabbix.Item{Name: "Network interface discovery", Key: "net.if.discovery", Flags: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	items, err := client.ItemGet(ctx, zabbix.ItemGetParameters{ItemIDs: []string{ruleID}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(items) != 0 {
+		t.Errorf("expected item.get not to return LLD rules, got %+v", items)
+	}
+
+	resp, err := client.TaskCreate(ctx, zabbix.NewCheckNowTasks(ruleID))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resp.TaskIDs) != 1 {
+		t.Fatalf("expected 1 task, got %v", resp.TaskIDs)
+	}
+	if err := zabbix.WaitForChecks(ctx, client, []string{ruleID}, time.Now(), time.Millisecond); err == nil {
+		t.Error("expected waiting for the check of an LLD rule to fail")
+	}
+
+	if _, err := client.TaskCreate(ctx, zabbix.NewCheckNowTasks("999")); err == nil {
+		t.Error("expected a check now of an unknown item to fail")
+	}
+}
+
+func TestTaskGetDiagnosticInfoOnly(t *testing.T) {
+	ctx := context.Background()
+	srv := zabbixtest.NewServer()
+	defer srv.Close()
+
+	client := newClient(t, srv)
+
+	resp, err := client.TaskCreate(ctx, append(zabbix.NewCheckNowTasks("42237"), zabbix.Task{Type: zabbix.TaskTypeDiagnosticInfo}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resp.TaskIDs) != 2 {
+		t.Fatalf("expected 2 tasks, got %v", resp.TaskIDs)
+	}
+
+	for _, status := range []int{zabbix.TaskStatusNew, zabbix.TaskStatusInProgress, zabbix.TaskStatusCompleted} {
+		tasks, err := client.TaskGet(ctx, zabbix.TaskGetParameters{TaskIDs: resp.TaskIDs})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(tasks) != 1 || tasks[0].TaskID != resp.TaskIDs[1] || int(tasks[0].Status) != status {
+			t.Fatalf("expected the diagnostic information task with status %d, got %+v", status, tasks)
+		}
+	}
+}
@@ -0,0 +1,276 @@
+package zabbixtest
+
+import (
+	"slices"
+	"strings"
+)
+
+var serviceDefaults = object{
+	"weight":            "0",
+	"propagation_rule":  "0",
+	"propagation_value": "0",
+	"status":            "-1",
+	"description":       "",
+	"readonly":          "0",
+	"parents":           []any{},
+	"tags":              []any{},
+	"problem_tags":      []any{},
+	"status_rules":      []any{},
+	"alarms":            []any{},
+}
+
+func (s *Server) serviceGet(req *request) (any, *apiError) {
+	var params struct {
+		getOptions
+		ServiceIDs        ids   `json:"serviceids"`
+		ParentIDs         ids   `json:"parentids"`
+		DeepParentIDs     bool  `json:"deep_parentids"`
+		ChildIDs          ids   `json:"childids"`
+		SLAIDs            ids   `json:"slaids"`
+		Tags              []any `json:"tags"`
+		SelectParents     any   `json:"selectParents"`
+		SelectChildren    any   `json:"selectChildren"`
+		SelectTags        any   `json:"selectTags"`
+		SelectProblemTags any   `json:"selectProblemTags"`
+		SelectStatusRules any   `json:"selectStatusRules"`
+	}
+	if err := decodeParams(req.params, &params); err != nil {
+		return nil, err
+	}
+
+	services := s.tables["services"]
+	rows := services.query(params.getOptions, func(service object) bool {
+		id := service.str("serviceid")
+		if params.ServiceIDs != nil && !slices.Contains(params.ServiceIDs, id) {
+			return false
+		}
+		if params.ParentIDs != nil {
+			parents := s.serviceParents(service)
+			if params.DeepParentIDs {
+				parents = s.serviceAncestors(service)
+			}
+			if !slices.ContainsFunc(parents, func(parent object) bool {
+				return slices.Contains(params.ParentIDs, parent.str("serviceid"))
+			}) {
+				return false
+			}
+		}
+		if params.ChildIDs != nil && !slices.ContainsFunc(s.serviceChildren(id), func(child object) bool {
+			return slices.Contains(params.ChildIDs, child.str("serviceid"))
+		}) {
+			return false
+		}
+		if params.SLAIDs != nil && !slices.ContainsFunc(params.SLAIDs, func(slaID string) bool {
+			sla := s.tables["slas"].get(slaID)
+			return sla != nil && matchServiceTags(objectList(sla["service_tags"]), service)
+		}) {
+			return false
+		}
+		if params.Tags != nil && !matchServiceTags(objectList(normalize(params.Tags)), service) {
+			return false
+		}
+		return true
+	})
+
+	return services.result(rows, params.getOptions, func(out, service object) {
+		if params.SelectParents != nil {
+			out["parents"] = services.related(params.SelectParents, s.serviceParents(service))
+		}
+		if params.SelectChildren != nil {
+			out["children"] = services.related(params.SelectChildren, s.serviceChildren(service.str("serviceid")))
+		}
+		if params.SelectTags != nil {
+			out["tags"] = listOrEmpty(service["tags"])
+		}
+		if params.SelectProblemTags != nil {
+			out["problem_tags"] = listOrEmpty(service["problem_tags"])
+		}
+		if params.SelectStatusRules != nil {
+			out["status_rules"] = listOrEmpty(service["status_rules"])
+		}
+	}), nil
+}
+
+func (s *Server) serviceCreate(req *request) (any, *apiError) {
+	services, err := objects(req.params)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, service := range services {
+		for _, param := range []string{"name", "algorithm", "sortorder"} {
+			if _, ok := service[param]; !ok {
+				return nil, invalidParams(`Invalid parameter "/%d": the parameter "%s" is missing.`, i+1, param)
+			}
+		}
+		if err := s.checkServiceRefs(service); err != nil {
+			return nil, err
+		}
+	}
+
+	serviceIDs := []string{}
+	for _, service := range services {
+		row := serviceDefaults.clone()
+		for k, v := range service {
+			if k != "serviceid" && k != "status" && k != "children" {
+				row[k] = v
+			}
+		}
+		id := s.tables["services"].insert(row)
+		if children, ok := service["children"]; ok {
+			s.setServiceChildren(id, objectList(children))
+		}
+		serviceIDs = append(serviceIDs, id)
+	}
+	return object{"serviceids": serviceIDs}, nil
+}
+
+func (s *Server) serviceUpdate(req *request) (any, *apiError) {
+	services, err := objects(req.params)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, service := range services {
+		id := service.str("serviceid")
+		if id == "" {
+			return nil, invalidParams(`Invalid parameter "/%d": the parameter "serviceid" is missing.`, i+1)
+		}
+		if s.tables["services"].get(id) == nil {
+			return nil, invalidParams(errNoPermissions)
+		}
+		if _, ok := service["name"]; ok && service.str("name") == "" {
+			return nil, invalidParams(`Invalid parameter "/%d/name": cannot be empty.`, i+1)
+		}
+		if err := s.checkServiceRefs(service); err != nil {
+			return nil, err
+		}
+	}
+
+	serviceIDs := []string{}
+	for _, service := range services {
+		id := service.str("serviceid")
+		current := s.tables["services"].get(id)
+		for k, v := range service {
+			if k != "status" && k != "children" {
+				current[k] = v
+			}
+		}
+		if children, ok := service["children"]; ok {
+			s.setServiceChildren(id, objectList(children))
+		}
+		serviceIDs = append(serviceIDs, id)
+	}
+	return object{"serviceids": serviceIDs}, nil
+}
+
+func (s *Server) serviceDelete(req *request) (any, *apiError) {
+	var serviceIDs ids
+	if err := decodeParams(req.params, &serviceIDs); err != nil {
+		return nil, err
+	}
+
+	for _, id := range serviceIDs {
+		if s.tables["services"].get(id) == nil {
+			return nil, invalidParams(errNoPermissions)
+		}
+	}
+
+	for _, id := range serviceIDs {
+		s.tables["services"].delete(id)
+		s.setServiceChildren(id, nil)
+	}
+	return object{"serviceids": []string(serviceIDs)}, nil
+}
+
+// checkServiceRefs fails if the parents or children of a service do not
+// exist or include the service itself.
+func (s *Server) checkServiceRefs(service object) *apiError {
+	id := service.str("serviceid")
+	for _, field := range []string{"parents", "children"} {
+		for _, ref := range objectList(service[field]) {
+			refID := ref.str("serviceid")
+			if s.tables["services"].get(refID) == nil {
+				return invalidParams(errNoPermissions)
+			}
+			if id != "" && refID == id {
+				return invalidParams(`Service "%s" cannot be parent and child at the same time.`, service.str("name"))
+			}
+		}
+	}
+	return nil
+}
+
+// serviceParents returns the parents of a service, which are stored on the
+// service as references.
+func (s *Server) serviceParents(service object) []object {
+	return s.refs("services", service["parents"], "serviceid")
+}
+
+// serviceAncestors returns the parents of a service, their parents, and so
+// on.
+func (s *Server) serviceAncestors(service object) []object {
+	var ancestors []object
+	queue := s.serviceParents(service)
+	for len(queue) > 0 {
+		parent := queue[0]
+		queue = queue[1:]
+		if slices.ContainsFunc(ancestors, func(a object) bool { return a.str("serviceid") == parent.str("serviceid") }) {
+			continue
+		}
+		ancestors = append(ancestors, parent)
+		queue = append(queue, s.serviceParents(parent)...)
+	}
+	return ancestors
+}
+
+func (s *Server) serviceChildren(serviceID string) []object {
+	return s.tables["services"].where(func(service object) bool {
+		return intersects([]string{serviceID}, service["parents"])
+	})
+}
+
+// setServiceChildren makes children the only children of the service by
+// updating the parents of all services.
+func (s *Server) setServiceChildren(serviceID string, children []object) {
+	var childIDs []string
+	for _, child := range children {
+		childIDs = append(childIDs, child.str("serviceid"))
+	}
+
+	for _, service := range s.tables["services"].rows {
+		parents := slices.DeleteFunc(objectList(service["parents"]), func(parent object) bool {
+			return parent.str("serviceid") == serviceID
+		})
+		if slices.Contains(childIDs, service.str("serviceid")) {
+			parents = append(parents, object{"serviceid": serviceID})
+		}
+		list := make([]any, len(parents))
+		for i, parent := range parents {
+			list[i] = parent
+		}
+		service["parents"] = list
+	}
+}
+
+// matchServiceTags reports whether any of tags matches a tag of service:
+// with the same name and, with operator 0, the same value, or with operator
+// 2, a value containing it.
+func matchServiceTags(tags []object, service object) bool {
+	for _, want := range tags {
+		for _, tag := range objectList(service["tags"]) {
+			if tag.str("tag") != want.str("tag") {
+				continue
+			}
+			value, wantValue := tag.str("value"), want.str("value")
+			if want.str("operator") == "2" {
+				if strings.Contains(strings.ToLower(value), strings.ToLower(wantValue)) {
+					return true
+				}
+			} else if value == wantValue {
+				return true
+			}
+		}
+	}
+	return false
+}
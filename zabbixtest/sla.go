@@ -0,0 +1,356 @@
+package zabbixtest
+
+import (
+	"math"
+	"slices"
+	"strconv"
+	"time"
+)
+
+var slaDefaults = object{
+	"effective_date":     "0",
+	"timezone":           "UTC",
+	"status":             "1",
+	"description":        "",
+	"schedule":           []any{},
+	"excluded_downtimes": []any{},
+}
+
+func (s *Server) slaGet(req *request) (any, *apiError) {
+	var params struct {
+		getOptions
+		SLAIDs                  ids `json:"slaids"`
+		ServiceIDs              ids `json:"serviceids"`
+		SelectSchedule          any `json:"selectSchedule"`
+		SelectExcludedDowntimes any `json:"selectExcludedDowntimes"`
+		SelectServiceTags       any `json:"selectServiceTags"`
+	}
+	if err := decodeParams(req.params, &params); err != nil {
+		return nil, err
+	}
+
+	slas := s.tables["slas"]
+	rows := slas.query(params.getOptions, func(sla object) bool {
+		if params.SLAIDs != nil && !slices.Contains(params.SLAIDs, sla.str("slaid")) {
+			return false
+		}
+		if params.ServiceIDs != nil && !slices.ContainsFunc(s.slaServices(sla), func(service object) bool {
+			return slices.Contains(params.ServiceIDs, service.str("serviceid"))
+		}) {
+			return false
+		}
+		return true
+	})
+
+	return slas.result(rows, params.getOptions, func(out, sla object) {
+		if params.SelectSchedule != nil {
+			out["schedule"] = listOrEmpty(sla["schedule"])
+		}
+		if params.SelectExcludedDowntimes != nil {
+			out["excluded_downtimes"] = listOrEmpty(sla["excluded_downtimes"])
+		}
+		if params.SelectServiceTags != nil {
+			out["service_tags"] = listOrEmpty(sla["service_tags"])
+		}
+	}), nil
+}
+
+func (s *Server) slaCreate(req *request) (any, *apiError) {
+	slas, err := objects(req.params)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make(map[string]bool)
+	for i, sla := range slas {
+		for _, param := range []string{"name", "period", "slo", "service_tags"} {
+			if _, ok := sla[param]; !ok {
+				return nil, invalidParams(`Invalid parameter "/%d": the parameter "%s" is missing.`, i+1, param)
+			}
+		}
+		name := sla.str("name")
+		if names[name] || slices.ContainsFunc(s.tables["slas"].rows, func(o object) bool { return o.str("name") == name }) {
+			return nil, invalidParams(`SLA "%s" already exists.`, name)
+		}
+		names[name] = true
+		if err := checkSLA(i, sla); err != nil {
+			return nil, err
+		}
+	}
+
+	slaIDs := []string{}
+	for _, sla := range slas {
+		row := slaDefaults.clone()
+		for k, v := range sla {
+			if k != "slaid" {
+				row[k] = v
+			}
+		}
+		slaIDs = append(slaIDs, s.tables["slas"].insert(row))
+	}
+	return object{"slaids": slaIDs}, nil
+}
+
+func (s *Server) slaUpdate(req *request) (any, *apiError) {
+	slas, err := objects(req.params)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, sla := range slas {
+		id := sla.str("slaid")
+		if id == "" {
+			return nil, invalidParams(`Invalid parameter "/%d": the parameter "slaid" is missing.`, i+1)
+		}
+		if s.tables["slas"].get(id) == nil {
+			return nil, invalidParams(errNoPermissions)
+		}
+		if _, ok := sla["name"]; ok {
+			name := sla.str("name")
+			if name == "" {
+				return nil, invalidParams(`Invalid parameter "/%d/name": cannot be empty.`, i+1)
+			}
+			if slices.ContainsFunc(s.tables["slas"].rows, func(o object) bool { return o.str("name") == name && o.str("slaid") != id }) {
+				return nil, invalidParams(`SLA "%s" already exists.`, name)
+			}
+		}
+		if err := checkSLA(i, sla); err != nil {
+			return nil, err
+		}
+	}
+
+	slaIDs := []string{}
+	for _, sla := range slas {
+		current := s.tables["slas"].get(sla.str("slaid"))
+		for k, v := range sla {
+			current[k] = v
+		}
+		slaIDs = append(slaIDs, current.str("slaid"))
+	}
+	return object{"slaids": slaIDs}, nil
+}
+
+func (s *Server) slaDelete(req *request) (any, *apiError) {
+	var slaIDs ids
+	if err := decodeParams(req.params, &slaIDs); err != nil {
+		return nil, err
+	}
+
+	for _, id := range slaIDs {
+		if s.tables["slas"].get(id) == nil {
+			return nil, invalidParams(errNoPermissions)
+		}
+	}
+
+	for _, id := range slaIDs {
+		s.tables["slas"].delete(id)
+	}
+	return object{"slaids": []string(slaIDs)}, nil
+}
+
+// checkSLA checks the properties of the i-th SLA of a create or update
+// request that are set.
+func checkSLA(i int, sla object) *apiError {
+	if _, ok := sla["period"]; ok {
+		if n, err := strconv.Atoi(sla.str("period")); err != nil || n < 0 || n > 4 {
+			return invalidParams(`Invalid parameter "/%d/period": value must be one of 0, 1, 2, 3, 4.`, i+1)
+		}
+	}
+	if _, ok := sla["slo"]; ok {
+		if slo, err := strconv.ParseFloat(sla.str("slo"), 64); err != nil || slo < 0 || slo > 100 {
+			return invalidParams(`Invalid parameter "/%d/slo": value must be within the range of 0-100.`, i+1)
+		}
+	}
+	if tags, ok := sla["service_tags"]; ok && len(objectList(tags)) == 0 {
+		return invalidParams(`Invalid parameter "/%d/service_tags": cannot be empty.`, i+1)
+	}
+	if _, ok := sla["timezone"]; ok {
+		if _, err := time.LoadLocation(sla.str("timezone")); err != nil {
+			return invalidParams(`Invalid parameter "/%d/timezone": value must be one of the time zones.`, i+1)
+		}
+	}
+	return nil
+}
+
+// slaGetsli computes the SLI of the services of an SLA from their status
+// timelines, as set by SetServiceStatus. The schedule of the SLA is ignored:
+// services are expected to be up 24x7.
+func (s *Server) slaGetsli(req *request) (any, *apiError) {
+	var params struct {
+		SLAID      string `json:"slaid"`
+		PeriodFrom int64  `json:"period_from"`
+		PeriodTo   int64  `json:"period_to"`
+		Periods    int    `json:"periods"`
+		ServiceIDs ids    `json:"serviceids"`
+	}
+	if err := decodeParams(req.params, &params); err != nil {
+		return nil, err
+	}
+
+	sla := s.tables["slas"].get(params.SLAID)
+	if sla == nil {
+		return nil, invalidParams(errNoPermissions)
+	}
+	if params.Periods == 0 {
+		params.Periods = 20
+	}
+	if params.Periods < 1 || params.Periods > 100 {
+		return nil, invalidParams(`Invalid parameter "/periods": value must be within the range of 1-100.`)
+	}
+
+	loc, _ := time.LoadLocation(sla.str("timezone"))
+	period, _ := strconv.Atoi(sla.str("period"))
+	slo, _ := strconv.ParseFloat(sla.str("slo"), 64)
+	effective, _ := strconv.ParseInt(sla.str("effective_date"), 10, 64)
+	now := time.Now()
+
+	last := now
+	if params.PeriodTo != 0 {
+		last = time.Unix(params.PeriodTo, 0)
+	}
+	var starts []time.Time
+	for start := periodStart(last.In(loc), period); len(starts) < params.Periods; start = addPeriods(start, period, -1) {
+		if params.PeriodFrom != 0 && !addPeriods(start, period, 1).After(time.Unix(params.PeriodFrom, 0)) {
+			break
+		}
+		if addPeriods(start, period, 1).Unix() <= effective {
+			break
+		}
+		starts = append(starts, start)
+	}
+	slices.Reverse(starts)
+
+	services := s.slaServices(sla)
+	if params.ServiceIDs != nil {
+		services = slices.DeleteFunc(services, func(service object) bool {
+			return !slices.Contains(params.ServiceIDs, service.str("serviceid"))
+		})
+	}
+	serviceIDs := []int64{}
+	for _, service := range services {
+		id, _ := strconv.ParseInt(service.str("serviceid"), 10, 64)
+		serviceIDs = append(serviceIDs, id)
+	}
+
+	downtimes := objectList(sla["excluded_downtimes"])
+	periods := []any{}
+	sli := []any{}
+	for _, start := range starts {
+		from, to := start.Unix(), addPeriods(start, period, 1).Unix()
+		periods = append(periods, map[string]int64{"period_from": from, "period_to": to})
+
+		var excluded []any
+		for _, downtime := range downtimes {
+			if unix(downtime, "period_from") < to && unix(downtime, "period_to") > from {
+				excluded = append(excluded, object{
+					"name":        downtime.str("name"),
+					"period_from": unix(downtime, "period_from"),
+					"period_to":   unix(downtime, "period_to"),
+				})
+			}
+		}
+
+		row := []any{}
+		for _, service := range services {
+			uptime, downtime := serviceUptime(service, downtimes, from, min(to, now.Unix()))
+			value := 100.0
+			if uptime+downtime > 0 {
+				value = float64(uptime) / float64(uptime+downtime) * 100
+			}
+			row = append(row, map[string]any{
+				"uptime":             uptime,
+				"downtime":           downtime,
+				"sli":                value,
+				"error_budget":       int64(math.Floor(float64(uptime+downtime)*(100-slo)/100)) - downtime,
+				"excluded_downtimes": append([]any{}, excluded...),
+			})
+		}
+		sli = append(sli, row)
+	}
+
+	return map[string]any{"periods": periods, "serviceids": serviceIDs, "sli": sli}, nil
+}
+
+// slaServices returns the services an SLA applies to by their tags.
+func (s *Server) slaServices(sla object) []object {
+	return s.tables["services"].where(func(service object) bool {
+		return matchServiceTags(objectList(sla["service_tags"]), service)
+	})
+}
+
+// serviceUptime returns the seconds the service was OK and not OK between
+// from and to, outside of the excluded downtimes.
+func serviceUptime(service object, excluded []object, from, to int64) (uptime, downtime int64) {
+	alarms := objectList(service["alarms"])
+
+	// Split [from, to) at every status change and excluded downtime bound
+	bounds := []int64{from, to}
+	for _, alarm := range alarms {
+		bounds = append(bounds, unix(alarm, "clock"))
+	}
+	for _, d := range excluded {
+		bounds = append(bounds, unix(d, "period_from"), unix(d, "period_to"))
+	}
+	bounds = slices.DeleteFunc(bounds, func(t int64) bool { return t < from || t > to })
+	slices.Sort(bounds)
+	bounds = slices.Compact(bounds)
+
+	for i := 0; i+1 < len(bounds); i++ {
+		start, end := bounds[i], bounds[i+1]
+		if slices.ContainsFunc(excluded, func(d object) bool {
+			return unix(d, "period_from") <= start && unix(d, "period_to") >= end
+		}) {
+			continue
+		}
+		status := "-1"
+		for _, alarm := range alarms {
+			if unix(alarm, "clock") <= start {
+				status = alarm.str("value")
+			}
+		}
+		if status == "-1" {
+			uptime += end - start
+		} else {
+			downtime += end - start
+		}
+	}
+	return uptime, downtime
+}
+
+// periodStart returns the start of the SLA period of the given type
+// containing t, in the location of t. Weeks start on Sunday.
+func periodStart(t time.Time, period int) time.Time {
+	year, month, day := t.Date()
+	switch period {
+	case 1:
+		day -= int(t.Weekday())
+	case 2:
+		day = 1
+	case 3:
+		month, day = month-(month-1)%3, 1
+	case 4:
+		month, day = time.January, 1
+	}
+	return time.Date(year, month, day, 0, 0, 0, 0, t.Location())
+}
+
+// addPeriods returns start moved by n SLA periods of the given type.
+func addPeriods(start time.Time, period, n int) time.Time {
+	switch period {
+	case 1:
+		return start.AddDate(0, 0, 7*n)
+	case 2:
+		return start.AddDate(0, n, 0)
+	case 3:
+		return start.AddDate(0, 3*n, 0)
+	case 4:
+		return start.AddDate(n, 0, 0)
+	}
+	return start.AddDate(0, 0, n)
+}
+
+// unix returns a timestamp property of o.
+func unix(o object, key string) int64 {
+	t, _ := strconv.ParseInt(o.str(key), 10, 64)
+	return t
+}
@@ -0,0 +1,372 @@
+package zabbixtest
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// object is a Zabbix object as returned by the API: scalar properties are
+// stored as strings, related objects as nested objects or lists.
+type object map[string]any
+
+// str returns the string property key of o, or "" if it is not set.
+func (o object) str(key string) string {
+	s, _ := o[key].(string)
+	return s
+}
+
+func (o object) clone() object {
+	c := make(object, len(o))
+	for k, v := range o {
+		c[k] = v
+	}
+	return c
+}
+
+// table holds the objects of one type.
+type table struct {
+	idField string
+	next    *int
+	rows    []object
+
+	// hidden lists the properties that are stored but only returned through
+	// a select parameter, or never (write-only properties).
+	hidden []string
+}
+
+func newTable(idField string, next *int, hidden ...string) *table {
+	return &table{idField: idField, next: next, hidden: hidden}
+}
+
+func (t *table) insert(o object) string {
+	id := o.str(t.idField)
+	if id == "" {
+		id = strconv.Itoa(*t.next)
+		*t.next++
+		o[t.idField] = id
+	}
+	t.rows = append(t.rows, o)
+	return id
+}
+
+func (t *table) get(id string) object {
+	for _, row := range t.rows {
+		if row.str(t.idField) == id {
+			return row
+		}
+	}
+	return nil
+}
+
+func (t *table) delete(id string) {
+	t.rows = slices.DeleteFunc(t.rows, func(row object) bool {
+		return row.str(t.idField) == id
+	})
+}
+
+func (t *table) where(match func(object) bool) []object {
+	var rows []object
+	for _, row := range t.rows {
+		if match(row) {
+			rows = append(rows, row)
+		}
+	}
+	return rows
+}
+
+// normalize converts decoded JSON into the representation used by the
+// store: numbers and booleans become strings, like the API returns them.
+func normalize(v any) any {
+	switch v := v.(type) {
+	case json.Number:
+		return v.String()
+	case bool:
+		if v {
+			return "1"
+		}
+		return "0"
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case map[string]any:
+		o := make(object, len(v))
+		for k, e := range v {
+			o[k] = normalize(e)
+		}
+		return o
+	case []any:
+		l := make([]any, len(v))
+		for i, e := range v {
+			l[i] = normalize(e)
+		}
+		return l
+	}
+	return v
+}
+
+// objects decodes params holding a single object or an array of objects.
+func objects(params json.RawMessage) ([]object, *apiError) {
+	var v any
+	if err := decodeParams(params, &v); err != nil {
+		return nil, err
+	}
+
+	switch v := normalize(v).(type) {
+	case object:
+		return []object{v}, nil
+	case []any:
+		objs := make([]object, 0, len(v))
+		for i, e := range v {
+			o, ok := e.(object)
+			if !ok {
+				return nil, invalidParams(`Invalid parameter "/%d": an array is expected.`, i+1)
+			}
+			objs = append(objs, o)
+		}
+		return objs, nil
+	}
+	return nil, invalidParams(`Invalid parameter "/": an array or object is expected.`)
+}
+
+// objectList returns the objects of a list property, such as the groups of
+// a host.
+func objectList(v any) []object {
+	l, _ := v.([]any)
+	objs := make([]object, 0, len(l))
+	for _, e := range l {
+		if o, ok := e.(object); ok {
+			objs = append(objs, o)
+		}
+	}
+	return objs
+}
+
+// ids is a list of IDs that also accepts a single ID.
+type ids []string
+
+func (l *ids) UnmarshalJSON(data []byte) error {
+	var v any
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+
+	*l = nil
+	switch v := v.(type) {
+	case nil:
+	case []any:
+		for _, e := range v {
+			*l = append(*l, fmt.Sprint(normalize(e)))
+		}
+	default:
+		*l = ids{fmt.Sprint(normalize(v))}
+	}
+	return nil
+}
+
+// getOptions are the common parameters of get methods.
+type getOptions struct {
+	Output                 any            `json:"output"`
+	CountOutput            bool           `json:"countOutput"`
+	Filter                 map[string]any `json:"filter"`
+	Search                 map[string]any `json:"search"`
+	SearchByAny            bool           `json:"searchByAny"`
+	SearchWildcardsEnabled bool           `json:"searchWildcardsEnabled"`
+	StartSearch            bool           `json:"startSearch"`
+	ExcludeSearch          bool           `json:"excludeSearch"`
+	Limit                  int            `json:"limit"`
+	Sortfield              any            `json:"sortfield"`
+	Sortorder              any            `json:"sortorder"`
+	PreserveKeys           bool           `json:"preservekeys"`
+}
+
+// query returns the rows of t matching match and the filter and search
+// options, sorted and limited.
+func (t *table) query(opts getOptions, match func(object) bool) []object {
+	rows := t.where(func(row object) bool {
+		return (match == nil || match(row)) && opts.matchFilter(row) && opts.matchSearch(row)
+	})
+
+	fields := stringList(opts.Sortfield)
+	orders := stringList(opts.Sortorder)
+	if len(fields) > 0 {
+		slices.SortStableFunc(rows, func(a, b object) int {
+			for i, field := range fields {
+				c := compareValues(a.str(field), b.str(field))
+				// A single sort order applies to all fields.
+				order := ""
+				if i < len(orders) {
+					order = orders[i]
+				} else if len(orders) == 1 {
+					order = orders[0]
+				}
+				if strings.EqualFold(order, "DESC") {
+					c = -c
+				}
+				if c != 0 {
+					return c
+				}
+			}
+			return 0
+		})
+	}
+
+	if opts.Limit > 0 && len(rows) > opts.Limit {
+		rows = rows[:opts.Limit]
+	}
+	return rows
+}
+
+func (opts getOptions) matchFilter(row object) bool {
+	for field, want := range opts.Filter {
+		values := stringList(normalize(want))
+		if len(values) > 0 && !slices.Contains(values, row.str(field)) {
+			return false
+		}
+	}
+	return true
+}
+
+func (opts getOptions) matchSearch(row object) bool {
+	if len(opts.Search) == 0 {
+		return true
+	}
+
+	matched := 0
+	for field, want := range opts.Search {
+		ok := false
+		for _, pattern := range stringList(normalize(want)) {
+			if opts.matchPattern(row.str(field), pattern) {
+				ok = true
+				break
+			}
+		}
+		if ok {
+			matched++
+		}
+	}
+
+	if opts.ExcludeSearch {
+		return matched == 0
+	}
+	if opts.SearchByAny {
+		return matched > 0
+	}
+	return matched == len(opts.Search)
+}
+
+func (opts getOptions) matchPattern(value, pattern string) bool {
+	value, pattern = strings.ToLower(value), strings.ToLower(pattern)
+
+	if opts.SearchWildcardsEnabled {
+		expr := regexp.QuoteMeta(pattern)
+		expr = strings.ReplaceAll(expr, `\*`, ".*")
+		if !opts.StartSearch {
+			expr = ".*" + expr
+		}
+		return regexp.MustCompile("^" + expr + ".*$").MatchString(value)
+	}
+	if opts.StartSearch {
+		return strings.HasPrefix(value, pattern)
+	}
+	return strings.Contains(value, pattern)
+}
+
+// compareValues compares numerically if both values are integers, and as
+// strings otherwise.
+func compareValues(a, b string) int {
+	x, errA := strconv.ParseInt(a, 10, 64)
+	y, errB := strconv.ParseInt(b, 10, 64)
+	if errA == nil && errB == nil {
+		switch {
+		case x < y:
+			return -1
+		case x > y:
+			return 1
+		}
+		return 0
+	}
+	return strings.Compare(a, b)
+}
+
+// stringList returns a string or list parameter as a list of strings.
+func stringList(v any) []string {
+	switch v := v.(type) {
+	case nil:
+		return nil
+	case string:
+		return []string{v}
+	case []string:
+		return v
+	case []any:
+		l := make([]string, 0, len(v))
+		for _, e := range v {
+			l = append(l, fmt.Sprint(normalize(e)))
+		}
+		return l
+	}
+	return []string{fmt.Sprint(normalize(v))}
+}
+
+// project returns the properties of row selected by an output parameter:
+// "extend" or nil for all of them, or a list of property names.
+func (t *table) project(row object, output any) object {
+	out := make(object)
+	switch output := output.(type) {
+	case nil, string:
+		for k, v := range row {
+			if !slices.Contains(t.hidden, k) {
+				out[k] = v
+			}
+		}
+	default:
+		for _, k := range stringList(output) {
+			if v, ok := row[k]; ok && !slices.Contains(t.hidden, k) {
+				out[k] = v
+			}
+		}
+	}
+	return out
+}
+
+// result builds the result of a get method from the matching rows. extend
+// adds the related objects requested through select parameters.
+func (t *table) result(rows []object, opts getOptions, extend func(out, row object)) any {
+	if opts.CountOutput {
+		return strconv.Itoa(len(rows))
+	}
+
+	outs := make([]object, 0, len(rows))
+	for _, row := range rows {
+		out := t.project(row, opts.Output)
+		if extend != nil {
+			extend(out, row)
+		}
+		outs = append(outs, out)
+	}
+
+	if opts.PreserveKeys {
+		m := make(map[string]object, len(outs))
+		for i, out := range outs {
+			m[rows[i].str(t.idField)] = out
+		}
+		return m
+	}
+	return outs
+}
+
+// related returns the related rows selected by a select parameter: a count
+// for "count", and the projected rows otherwise.
+func (t *table) related(selectParam any, rows []object) any {
+	if s, ok := selectParam.(string); ok && s == "count" {
+		return strconv.Itoa(len(rows))
+	}
+
+	outs := make([]object, 0, len(rows))
+	for _, row := range rows {
+		outs = append(outs, t.project(row, selectParam))
+	}
+	return outs
+}
@@ -0,0 +1,84 @@
+package zabbixtest
+
+import (
+	"slices"
+	"strconv"
+	"time"
+)
+
+// Diagnostic information tasks are the only tasks task.get returns; the
+// server deletes the others once processed.
+const taskTypeDiagnosticInfo = "1"
+
+// Check now tasks are processed by the next item.get, see processChecks.
+const taskTypeCheckNow = "6"
+
+// Types of tasks the server accepts.
+var taskTypes = []string{taskTypeDiagnosticInfo, "2", taskTypeCheckNow}
+
+func (s *Server) taskGet(req *request) (any, *apiError) {
+	var params struct {
+		getOptions
+		TaskIDs ids `json:"taskids"`
+	}
+	if err := decodeParams(req.params, &params); err != nil {
+		return nil, err
+	}
+
+	tasks := s.tables["tasks"]
+	rows := tasks.query(params.getOptions, func(task object) bool {
+		if task.str("type") != taskTypeDiagnosticInfo {
+			return false
+		}
+		return params.TaskIDs == nil || slices.Contains(params.TaskIDs, task.str("taskid"))
+	})
+	result := tasks.result(rows, params.getOptions, nil)
+
+	// The tasks returned are processed before the next call: new tasks are
+	// taken in progress, and tasks in progress are completed.
+	for _, task := range rows {
+		switch task.str("status") {
+		case "1":
+			task["status"] = "2"
+		case "2":
+			task["status"] = "3"
+		}
+	}
+	return result, nil
+}
+
+func (s *Server) taskCreate(req *request) (any, *apiError) {
+	tasks, err := objects(req.params)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, task := range tasks {
+		if !slices.Contains(taskTypes, task.str("type")) {
+			return nil, invalidParams(`Invalid parameter "/%d/type": value must be one of 1, 2, 6.`, i+1)
+		}
+		request, _ := task["request"].(object)
+		if task.str("type") == taskTypeCheckNow {
+			if request.str("itemid") == "" {
+				return nil, invalidParams(`Invalid parameter "/%d/request": the parameter "itemid" is missing.`, i+1)
+			}
+			if s.tables["items"].get(request.str("itemid")) == nil {
+				return nil, invalidParams(errNoPermissions)
+			}
+		}
+	}
+
+	taskIDs := []string{}
+	for _, task := range tasks {
+		row := object{
+			"type":    task.str("type"),
+			"status":  "1",
+			"clock":   strconv.FormatInt(time.Now().Unix(), 10),
+			"ttl":     "3600",
+			"proxyid": "0",
+			"request": task["request"],
+		}
+		taskIDs = append(taskIDs, s.tables["tasks"].insert(row))
+	}
+	return object{"taskids": taskIDs}, nil
+}
@@ -0,0 +1,115 @@
+package zabbixtest
+
+import (
+	"slices"
+)
+
+func (s *Server) templateGet(req *request) (any, *apiError) {
+	var params struct {
+		getOptions
+		TemplateIDs           ids `json:"templateids"`
+		HostIDs               ids `json:"hostids"`
+		ParentTemplateIDs     ids `json:"parentTemplateids"`
+		SelectHosts           any `json:"selectHosts"`
+		SelectParentTemplates any `json:"selectParentTemplates"`
+		SelectTags            any `json:"selectTags"`
+	}
+	if err := decodeParams(req.params, &params); err != nil {
+		return nil, err
+	}
+
+	templates := s.tables["templates"]
+	rows := templates.query(params.getOptions, func(template object) bool {
+		if params.TemplateIDs != nil && !slices.Contains(params.TemplateIDs, template.str("templateid")) {
+			return false
+		}
+		if params.HostIDs != nil && !slices.ContainsFunc(s.templateHosts(template), func(host object) bool {
+			return slices.Contains(params.HostIDs, host.str("hostid"))
+		}) {
+			return false
+		}
+		if params.ParentTemplateIDs != nil && !intersects(params.ParentTemplateIDs, template["templates"]) {
+			return false
+		}
+		return true
+	})
+
+	return templates.result(rows, params.getOptions, func(out, template object) {
+		if params.SelectHosts != nil {
+			out["hosts"] = s.tables["hosts"].related(params.SelectHosts, s.templateHosts(template))
+		}
+		if params.SelectParentTemplates != nil {
+			out["parentTemplates"] = templates.related(params.SelectParentTemplates, s.refs("templates", template["templates"], "templateid"))
+		}
+		if params.SelectTags != nil {
+			out["tags"] = listOrEmpty(template["tags"])
+		}
+	}), nil
+}
+
+func (s *Server) templateUpdate(req *request) (any, *apiError) {
+	templates, err := objects(req.params)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, template := range templates {
+		id := template.str("templateid")
+		if id == "" {
+			return nil, invalidParams(`Invalid parameter "/%d": the parameter "templateid" is missing.`, i+1)
+		}
+		if s.tables["templates"].get(id) == nil {
+			return nil, invalidParams(errNoPermissions)
+		}
+		if _, ok := template["host"]; ok {
+			name := template.str("host")
+			if name == "" {
+				return nil, invalidParams(`Invalid parameter "/%d/host": cannot be empty.`, i+1)
+			}
+			if slices.ContainsFunc(s.tables["templates"].rows, func(t object) bool { return t.str("host") == name && t.str("templateid") != id }) {
+				return nil, invalidParams(`Template with the same name "%s" already exists.`, name)
+			}
+			if slices.ContainsFunc(s.tables["hosts"].rows, func(h object) bool { return h.str("host") == name }) {
+				return nil, invalidParams(`Host with the same name "%s" already exists.`, name)
+			}
+		}
+	}
+
+	templateIDs := []string{}
+	for _, template := range templates {
+		current := s.tables["templates"].get(template.str("templateid"))
+		for k, v := range template {
+			current[k] = v
+		}
+		if current.str("name") == "" {
+			current["name"] = current.str("host")
+		}
+		templateIDs = append(templateIDs, current.str("templateid"))
+	}
+	return object{"templateids": templateIDs}, nil
+}
+
+// insertTemplate stores a template with the defaults of template.create.
+func (s *Server) insertTemplate(template object) string {
+	row := object{
+		"description":    "",
+		"uuid":           randomHex(16),
+		"vendor_name":    "",
+		"vendor_version": "",
+		"templates":      []any{},
+		"tags":           []any{},
+	}
+	for k, v := range template {
+		row[k] = v
+	}
+	if row.str("name") == "" {
+		row["name"] = row.str("host")
+	}
+	return s.tables["templates"].insert(row)
+}
+
+func (s *Server) templateHosts(template object) []object {
+	return s.tables["hosts"].where(func(host object) bool {
+		return intersects([]string{template.str("templateid")}, host["templates"])
+	})
+}
@@ -0,0 +1,175 @@
+package zabbixtest
+
+import (
+	"slices"
+	"strconv"
+	"time"
+)
+
+func (s *Server) tokenGet(req *request) (any, *apiError) {
+	var params struct {
+		getOptions
+		TokenIDs ids    `json:"tokenids"`
+		UserIDs  ids    `json:"userids"`
+		Token    string `json:"token"`
+	}
+	if err := decodeParams(req.params, &params); err != nil {
+		return nil, err
+	}
+
+	tokens := s.tables["tokens"]
+	rows := tokens.query(params.getOptions, func(token object) bool {
+		if params.TokenIDs != nil && !slices.Contains(params.TokenIDs, token.str("tokenid")) {
+			return false
+		}
+		if params.UserIDs != nil && !slices.Contains(params.UserIDs, token.str("userid")) {
+			return false
+		}
+		if params.Token != "" && token.str("token") != params.Token {
+			return false
+		}
+		return true
+	})
+	return tokens.result(rows, params.getOptions, nil), nil
+}
+
+func (s *Server) tokenCreate(req *request) (any, *apiError) {
+	tokens, err := objects(req.params)
+	if err != nil {
+		return nil, err
+	}
+
+	type key struct{ userID, name string }
+	names := make(map[key]bool)
+	for _, token := range s.tables["tokens"].rows {
+		names[key{token.str("userid"), token.str("name")}] = true
+	}
+
+	for i, token := range tokens {
+		if token.str("name") == "" {
+			return nil, invalidParams(`Invalid parameter "/%d": the parameter "name" is missing.`, i+1)
+		}
+		if token.str("userid") == "" {
+			token["userid"] = req.userID
+		} else if !s.userExists(token.str("userid")) {
+			return nil, invalidParams(errNoPermissions)
+		}
+
+		k := key{token.str("userid"), token.str("name")}
+		if names[k] {
+			return nil, invalidParams(`API token "%s" already exists for userid "%s".`, k.name, k.userID)
+		}
+		names[k] = true
+	}
+
+	tokenIDs := []string{}
+	for _, token := range tokens {
+		row := object{
+			"description":    "",
+			"status":         "0",
+			"lastaccess":     "0",
+			"expires_at":     "0",
+			"created_at":     strconv.FormatInt(time.Now().Unix(), 10),
+			"creator_userid": req.userID,
+		}
+		for k, v := range token {
+			if k != "tokenid" && k != "token" {
+				row[k] = v
+			}
+		}
+		tokenIDs = append(tokenIDs, s.tables["tokens"].insert(row))
+	}
+	return object{"tokenids": tokenIDs}, nil
+}
+
+func (s *Server) tokenUpdate(req *request) (any, *apiError) {
+	tokens, err := objects(req.params)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, token := range tokens {
+		id := token.str("tokenid")
+		if id == "" {
+			return nil, invalidParams(`Invalid parameter "/%d": the parameter "tokenid" is missing.`, i+1)
+		}
+		current := s.tables["tokens"].get(id)
+		if current == nil {
+			return nil, invalidParams(errNoPermissions)
+		}
+		for _, field := range []string{"userid", "token", "lastaccess"} {
+			if _, ok := token[field]; ok {
+				return nil, invalidParams(`Invalid parameter "/%d": unexpected parameter "%s".`, i+1, field)
+			}
+		}
+		if _, ok := token["name"]; ok {
+			name := token.str("name")
+			if name == "" {
+				return nil, invalidParams(`Invalid parameter "/%d/name": cannot be empty.`, i+1)
+			}
+			if slices.ContainsFunc(s.tables["tokens"].rows, func(t object) bool {
+				return t.str("name") == name && t.str("userid") == current.str("userid") && t.str("tokenid") != id
+			}) {
+				return nil, invalidParams(`API token "%s" already exists for userid "%s".`, name, current.str("userid"))
+			}
+		}
+	}
+
+	tokenIDs := []string{}
+	for _, token := range tokens {
+		current := s.tables["tokens"].get(token.str("tokenid"))
+		for k, v := range token {
+			current[k] = v
+		}
+		tokenIDs = append(tokenIDs, current.str("tokenid"))
+	}
+	return object{"tokenids": tokenIDs}, nil
+}
+
+func (s *Server) tokenGenerate(req *request) (any, *apiError) {
+	var tokenIDs ids
+	if err := decodeParams(req.params, &tokenIDs); err != nil {
+		return nil, err
+	}
+
+	for _, id := range tokenIDs {
+		if s.tables["tokens"].get(id) == nil {
+			return nil, invalidParams(errNoPermissions)
+		}
+	}
+
+	generated := []object{}
+	for _, id := range tokenIDs {
+		token := s.tables["tokens"].get(id)
+		token["token"] = randomHex(32)
+		generated = append(generated, object{"tokenid": id, "token": token["token"]})
+	}
+	return generated, nil
+}
+
+func (s *Server) tokenDelete(req *request) (any, *apiError) {
+	var tokenIDs ids
+	if err := decodeParams(req.params, &tokenIDs); err != nil {
+		return nil, err
+	}
+
+	for _, id := range tokenIDs {
+		if s.tables["tokens"].get(id) == nil {
+			return nil, invalidParams(errNoPermissions)
+		}
+	}
+
+	for _, id := range tokenIDs {
+		s.tables["tokens"].delete(id)
+	}
+	return object{"tokenids": []string(tokenIDs)}, nil
+}
+
+func (s *Server) userExists(userID string) bool {
+	for _, u := range s.users {
+		if u.userID == userID {
+			return true
+		}
+	}
+	return false
+}
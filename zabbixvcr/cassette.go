@@ -0,0 +1,123 @@
+package zabbixvcr
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	zabbix "github.com/nimok/nim-go-zabbix"
+)
+
+// Redacted replaces scrubbed values in cassettes.
+const Redacted = "REDACTED"
+
+// DefaultScrubFields are the parameter and result properties whose values
+// are never written to a cassette: the secrets of zabbix.SecretFields.
+var DefaultScrubFields = slices.Clone(zabbix.SecretFields)
+
+// Cassette holds the interactions recorded by a Recorder.
+type Cassette struct {
+	Interactions []Interaction `json:"interactions"`
+}
+
+// Interaction is a recorded JSON-RPC request and its response.
+type Interaction struct {
+	Method   string          `json:"method"`   // JSON-RPC method, e.g. "host.get"
+	Params   json.RawMessage `json:"params"`   // Normalized and scrubbed params
+	Status   int             `json:"status"`   // HTTP status code of the response
+	Response json.RawMessage `json:"response"` // Scrubbed response body
+}
+
+// key identifies the requests an interaction answers. Params are compacted
+// because cassettes are written indented.
+func (i Interaction) key() string {
+	var params bytes.Buffer
+	if err := json.Compact(&params, i.Params); err != nil {
+		return i.Method + " " + string(i.Params)
+	}
+	return i.Method + " " + params.String()
+}
+
+// Load reads a cassette file.
+func Load(path string) (*Cassette, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var c Cassette
+	if err := json.Unmarshal(data, &c); err != nil {
+		return nil, fmt.Errorf("zabbixvcr: invalid cassette %s: %v", path, err)
+	}
+	return &c, nil
+}
+
+// Save writes the cassette to path, creating its directory if needed.
+func (c *Cassette) Save(path string) error {
+	data, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0o644)
+}
+
+// normalize returns data re-encoded with sorted object keys, without
+// insignificant whitespace, and with the values of the scrub fields
+// replaced.
+func normalize(data []byte, scrub []string) (json.RawMessage, error) {
+	if len(bytes.TrimSpace(data)) == 0 {
+		return json.RawMessage("null"), nil
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	var v any
+	if err := decoder.Decode(&v); err != nil {
+		return nil, err
+	}
+	return json.Marshal(scrubValue(v, scrub))
+}
+
+func scrubValue(v any, scrub []string) any {
+	switch v := v.(type) {
+	case map[string]any:
+		for k, e := range v {
+			if e != nil && slices.ContainsFunc(scrub, func(f string) bool { return strings.EqualFold(f, k) }) {
+				v[k] = Redacted
+			} else {
+				v[k] = scrubValue(e, scrub)
+			}
+		}
+	case []any:
+		for i, e := range v {
+			v[i] = scrubValue(e, scrub)
+		}
+	}
+	return v
+}
+
+// scrubResponse scrubs a response body. The result of user.login is the
+// session ID and is replaced entirely.
+func scrubResponse(method string, body []byte, scrub []string) (json.RawMessage, error) {
+	if method == "user.login" {
+		var response map[string]any
+		if err := json.Unmarshal(body, &response); err != nil {
+			return nil, err
+		}
+		if _, ok := response["result"]; ok {
+			response["result"] = Redacted
+		}
+		if body, err := json.Marshal(response); err == nil {
+			return normalize(body, scrub)
+		}
+	}
+	return normalize(body, scrub)
+}
@@ -0,0 +1,237 @@
+// Package zabbixvcr records the JSON-RPC traffic of a zabbix.Client to a
+// cassette file and replays it, so that tests written against a real Zabbix
+// server can run deterministically without one.
+//
+// A Recorder is an http.RoundTripper used through zabbix.WithHTTPClient:
+//
+//	rec, err := zabbixvcr.New("testdata/hosts.json", zabbixvcr.ModeReplay)
+//	if err != nil {
+//		t.Fatal(err)
+//	}
+//	defer rec.Stop()
+//
+//	client, err := zabbix.NewClient(url, zabbix.WithUserPass(user, password),
+//		zabbix.WithHTTPClient(rec.HTTPClient()))
+//
+// Requests are matched by JSON-RPC method and normalized params; request
+// headers, and thus bearer tokens, are never recorded. Passwords, PSKs,
+// tokens and session IDs are scrubbed from the params and responses before
+// they are written, see DefaultScrubFields.
+package zabbixvcr
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sync"
+)
+
+// Mode selects whether a Recorder records or replays.
+type Mode int
+
+const (
+	// ModeReplay answers requests from the cassette and fails requests that
+	// were not recorded.
+	ModeReplay Mode = iota
+	// ModeRecord sends requests to the server and records them. The cassette
+	// is written by Stop.
+	ModeRecord
+	// ModeReplayOrRecord replays the cassette if it exists and records a new
+	// one otherwise.
+	ModeReplayOrRecord
+)
+
+// ErrNoInteraction is returned, wrapped, for requests that have no recorded
+// interaction in replay mode.
+var ErrNoInteraction = errors.New("zabbixvcr: no recorded interaction")
+
+// Recorder is an http.RoundTripper that records or replays Zabbix API
+// requests. It is safe for concurrent use.
+type Recorder struct {
+	path      string
+	mode      Mode
+	transport http.RoundTripper
+	scrub     []string
+
+	mu       sync.Mutex
+	cassette *Cassette
+	replayed map[string]int // times each key has been replayed
+}
+
+// Option configures a Recorder.
+type Option func(*Recorder)
+
+// WithTransport sets the transport used to reach the server when
+// recording. It defaults to http.DefaultTransport.
+func WithTransport(transport http.RoundTripper) Option {
+	return func(r *Recorder) {
+		r.transport = transport
+	}
+}
+
+// WithScrubFields scrubs the given properties in addition to
+// DefaultScrubFields.
+func WithScrubFields(fields ...string) Option {
+	return func(r *Recorder) {
+		r.scrub = append(r.scrub, fields...)
+	}
+}
+
+// New returns a Recorder for the cassette at path. In replay mode the
+// cassette must exist.
+func New(path string, mode Mode, opts ...Option) (*Recorder, error) {
+	r := &Recorder{
+		path:      path,
+		mode:      mode,
+		transport: http.DefaultTransport,
+		scrub:     append([]string(nil), DefaultScrubFields...),
+		cassette:  &Cassette{},
+		replayed:  make(map[string]int),
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+
+	if r.mode == ModeReplayOrRecord {
+		r.mode = ModeRecord
+		if _, err := os.Stat(path); err == nil {
+			r.mode = ModeReplay
+		}
+	}
+
+	if r.mode == ModeReplay {
+		cassette, err := Load(path)
+		if err != nil {
+			return nil, err
+		}
+		r.cassette = cassette
+	}
+	return r, nil
+}
+
+// Mode returns the mode the recorder runs in; ModeReplayOrRecord is
+// resolved to ModeReplay or ModeRecord by New.
+func (r *Recorder) Mode() Mode {
+	return r.mode
+}
+
+// HTTPClient returns an HTTP client using the recorder as transport, for use
+// with zabbix.WithHTTPClient.
+func (r *Recorder) HTTPClient() *http.Client {
+	return &http.Client{Transport: r}
+}
+
+// Stop writes the cassette when recording. It does nothing in replay mode.
+func (r *Recorder) Stop() error {
+	if r.mode != ModeRecord {
+		return nil
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.cassette.Save(r.path)
+}
+
+// RoundTrip implements http.RoundTripper.
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	body, err := readBody(req)
+	if err != nil {
+		return nil, err
+	}
+
+	var rpc struct {
+		Method string          `json:"method"`
+		Params json.RawMessage `json:"params"`
+	}
+	if err := json.Unmarshal(body, &rpc); err != nil {
+		return nil, fmt.Errorf("zabbixvcr: request is not JSON-RPC: %v", err)
+	}
+	params, err := normalize(rpc.Params, r.scrub)
+	if err != nil {
+		return nil, fmt.Errorf("zabbixvcr: invalid params: %v", err)
+	}
+	interaction := Interaction{Method: rpc.Method, Params: params}
+
+	if r.mode == ModeReplay {
+		return r.replay(req, interaction)
+	}
+	return r.record(req, body, interaction)
+}
+
+func (r *Recorder) replay(req *http.Request, interaction Interaction) (*http.Response, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key := interaction.key()
+
+	var matches []Interaction
+	for _, i := range r.cassette.Interactions {
+		if i.key() == key {
+			matches = append(matches, i)
+		}
+	}
+	if len(matches) == 0 {
+		return nil, fmt.Errorf("%w for %s %s", ErrNoInteraction, interaction.Method, interaction.Params)
+	}
+
+	// Identical requests are answered in recording order; once all have
+	// been used the last one is repeated.
+	n := r.replayed[key]
+	r.replayed[key]++
+	match := matches[min(n, len(matches)-1)]
+
+	return &http.Response{
+		Status:        http.StatusText(match.Status),
+		StatusCode:    match.Status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        http.Header{"Content-Type": {"application/json"}},
+		Body:          io.NopCloser(bytes.NewReader(match.Response)),
+		ContentLength: int64(len(match.Response)),
+		Request:       req,
+	}, nil
+}
+
+func (r *Recorder) record(req *http.Request, body []byte, interaction Interaction) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.Body = io.NopCloser(bytes.NewReader(body))
+
+	resp, err := r.transport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	interaction.Status = resp.StatusCode
+	interaction.Response, err = scrubResponse(interaction.Method, respBody, r.scrub)
+	if err != nil {
+		return nil, fmt.Errorf("zabbixvcr: invalid response to %s: %v", interaction.Method, err)
+	}
+
+	r.mu.Lock()
+	r.cassette.Interactions = append(r.cassette.Interactions, interaction)
+	r.mu.Unlock()
+
+	resp.Body = io.NopCloser(bytes.NewReader(respBody))
+	return resp, nil
+}
+
+func readBody(req *http.Request) ([]byte, error) {
+	if req.Body == nil {
+		return nil, nil
+	}
+	defer req.Body.Close()
+
+	return io.ReadAll(req.Body)
+}
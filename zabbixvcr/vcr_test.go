@@ -0,0 +1,125 @@
+package zabbixvcr_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	zabbix "github.com/nimok/nim-go-zabbix"
+	"github.com/nimok/nim-go-zabbix/zabbixtest"
+	"github.com/nimok/nim-go-zabbix/zabbixvcr"
+)
+
+// run is the code under test: it logs in, creates a PSK host and reads it
+// back.
+func run(t *testing.T, url string, rec *zabbixvcr.Recorder) []zabbix.Host {
+	t.Helper()
+	ctx := context.Background()
+
+	client, err := zabbix.NewClient(url,
+		zabbix.WithUserPass(zabbixtest.DefaultUsername, zabbixtest.DefaultPassword),
+		zabbix.WithHTTPClient(rec.HTTPClient()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := client.Authenticate(); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := client.HostCreate(ctx, []zabbix.Host{{
+		Host:           "psk-host",
+		Groups:         []zabbix.HostGroup{{GroupID: "2"}},
+		TlsConnect:     2,
+		TlsAccept:      2,
+		TlsPSKIdentity: "psk-identity",
+		TlsPSK:         "0123456789abcdef0123456789abcdef",
+	}}); err != nil {
+		t.Fatal(err)
+	}
+
+	hosts, err := client.HostGet(ctx, zabbix.HostGetParameters{
+		GetParameters: zabbix.GetParameters{Filter: map[string]any{"host": "psk-host"}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return hosts
+}
+
+func TestRecordAndReplay(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cassette.json")
+
+	srv := zabbixtest.NewServer()
+	rec, err := zabbixvcr.New(path, zabbixvcr.ModeRecord)
+	if err != nil {
+		t.Fatal(err)
+	}
+	recorded := run(t, srv.URL, rec)
+	if err := rec.Stop(); err != nil {
+		t.Fatal(err)
+	}
+	srv.Close()
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, secret := range []string{zabbixtest.DefaultPassword, "psk-identity", "0123456789abcdef"} {
+		if strings.Contains(string(data), secret) {
+			t.Errorf("cassette contains %q:\n%s", secret, data)
+		}
+	}
+
+	// The server is gone: everything is answered from the cassette.
+	rec, err = zabbixvcr.New(path, zabbixvcr.ModeReplay)
+	if err != nil {
+		t.Fatal(err)
+	}
+	replayed := run(t, srv.URL, rec)
+	if len(replayed) != 1 || replayed[0].HostID != recorded[0].HostID {
+		t.Errorf("replayed %+v, recorded %+v", replayed, recorded)
+	}
+}
+
+func TestReplayUnmatched(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cassette.json")
+	if err := (&zabbixvcr.Cassette{}).Save(path); err != nil {
+		t.Fatal(err)
+	}
+
+	rec, err := zabbixvcr.New(path, zabbixvcr.ModeReplay)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	client, err := zabbix.NewClient("http://zabbix.invalid/api_jsonrpc.php",
+		zabbix.WithAPIToken("token"),
+		zabbix.WithHTTPClient(rec.HTTPClient()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = client.HostGet(context.Background(), zabbix.HostGetParameters{})
+	if !errors.Is(err, zabbixvcr.ErrNoInteraction) {
+		t.Errorf("got %v, want ErrNoInteraction", err)
+	}
+}
+
+func TestReplayMissingCassette(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "missing.json")
+
+	if _, err := zabbixvcr.New(path, zabbixvcr.ModeReplay); err == nil {
+		t.Error("expected an error for a missing cassette")
+	}
+
+	rec, err := zabbixvcr.New(path, zabbixvcr.ModeReplayOrRecord)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rec.Mode() != zabbixvcr.ModeRecord {
+		t.Errorf("got mode %d, want ModeRecord", rec.Mode())
+	}
+}